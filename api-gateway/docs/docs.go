@@ -15,6 +15,151 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the access token of the request and, when given, the refresh token of the same session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token of the session",
+                        "name": "token",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.RefreshReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "logged out",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all-sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke every refresh and access token issued to the user so far.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout of all sessions",
+                "responses": {
+                    "200": {
+                        "description": "logged out of all sessions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Trade a refresh token for a new access and refresh token. A refresh token works once; reusing one revokes every refresh token issued since the login it came from.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh Request",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.RefreshReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.LoginRes"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid, revoked or reused refresh token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/forgot-password": {
             "post": {
                 "description": "Request to reset user's password",
@@ -139,13 +284,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.RegisterReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "User registered successfully",
+                    "202": {
+                        "description": "command_id, and the status_token to look it up with at /register/status",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -163,6 +317,43 @@ const docTemplate = `{
                 }
             }
         },
+        "/register/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the outcome of a registration, with the status_token it was accepted with as the bearer token. A registration flash_service has not picked up yet is reported as pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get Registration Status",
+                "responses": {
+                    "200": {
+                        "description": "Command status",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CommandStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid status token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/reset-password": {
             "post": {
                 "description": "Reset user's password with a reset code",
@@ -275,6 +466,104 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/admin/users/{id}/revoke-sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke every refresh and access token issued to the user so far, for admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke all sessions of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "sessions revoked",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/commands/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the outcome of a write that was accepted with 202. A command flash_service has not picked up yet, or one of another user, is reported as pending.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Command"
+                ],
+                "summary": "Get Command Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Command ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Command status",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CommandStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/flashSale/create": {
             "post": {
                 "security": [
@@ -568,13 +857,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.UpdateFlashSalesReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "message\":\"Flash Sale updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -654,7 +952,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel an existing flash sale",
+                "description": "Cancel a flash sale, its open orders are canceled and refunded, the sale stops offering stock and buyers are notified",
                 "consumes": [
                     "application/json"
                 ],
@@ -676,7 +974,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Flash sale cancelled successfully",
+                        "description": "Cancellation and the refunds it opened",
                         "schema": {
                             "$ref": "#/definitions/genproto.CancelFlashSaleRes"
                         }
@@ -693,6 +991,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "409": {
+                        "description": "Flash sale already completed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -702,24 +1006,122 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/flashSaleProduct/create": {
-            "post": {
+        "/v1/flashSale/{id}/queue": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new FlashSale",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Join the waiting room of a flash sale, or check the place in line when a Queue-Token is sent. Once admitted, the same token must be sent with order creation.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "FlashSaleProduct"
+                    "FlashSale"
                 ],
-                "summary": "Create FlashSale",
+                "summary": "Flash Sale Queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "FlashSale ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token returned by an earlier call",
+                        "name": "Queue-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Place in line",
+                        "schema": {
+                            "$ref": "#/definitions/waitingroom.Status"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue token unknown or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flashSale/{id}/watch": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream the status, countdown times and stock of a Flash Sale as Server-Sent Events. The first events carry the current state; \"status\" and \"stock\" events follow on every change.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "FlashSale"
+                ],
+                "summary": "Watch Flash Sale",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Flash Sale ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of flash sale updates",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.FlashSaleUpdate"
+                        }
+                    },
+                    "404": {
+                        "description": "Flash sale not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/flashSaleProduct/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new FlashSale",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FlashSaleProduct"
+                ],
+                "summary": "Create FlashSale",
                 "parameters": [
                     {
                         "description": "FlashSale data",
@@ -729,13 +1131,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.CreateFlashSaleProductReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Flash Sale Product created successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -889,13 +1300,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.UpdateFlashSaleProductReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "message\":\"Flash Sale Product updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -938,6 +1358,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User whose remaining purchase allowance is returned",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -995,13 +1421,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.NotificationCreate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "$ref": "#/definitions/genproto.Void"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1280,6 +1715,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.CreateOrderReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admitted waiting room token, required while the waiting room is on",
+                        "name": "Queue-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -1295,6 +1742,36 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "403": {
+                        "description": "Admission token missing or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Flash sale product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Out of stock, purchase limit reached or flash sale not active",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key reused with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Still waiting in the queue",
+                        "schema": {
+                            "$ref": "#/definitions/waitingroom.Status"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1486,7 +1963,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing Order by ID",
+                "description": "Update an existing Order by ID. Orders are canceled and refunded through their own routes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1506,17 +1983,32 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.UpdateOrderReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "message\":\"Order updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request, or a cancellation or refund that belongs to its own route",
                         "schema": {
                             "type": "string"
                         }
@@ -1585,6 +2077,128 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/order/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an order and initiate a refund",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Cancel Order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cancellation response",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CancelOrderRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Order can no longer be canceled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key reused with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/order/{id}/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get every status an order went through",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Order"
+                ],
+                "summary": "Get Order Timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order timeline",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.OrderTimeline"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/product/create": {
             "post": {
                 "security": [
@@ -1612,13 +2226,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.CreateProductReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Product created successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1778,19 +2401,987 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.UpdateProductReq"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "message\":\"Product updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/product/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an Product by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Product"
+                ],
+                "summary": "Get Product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product data",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Products"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List Refunds with filters, for admins reviewing refunds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "List Refunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund status (pending, approved, processed, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "order_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of Refunds",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.RefundListRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Request a refund of what was paid for an order, amount 0 refunds everything left",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Request Refund",
+                "parameters": [
+                    {
+                        "description": "Refund data",
+                        "name": "Refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.RefundCreateReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending refund",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Refund"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or amount over what was paid",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Nothing left to refund",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a Refund by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Get Refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Refund",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Refund"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending refund",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Approve Refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Approved refund",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Refund"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund is not pending",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/{id}/process": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay an approved refund back through the payment provider, then credit the buyer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Process Refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Processed refund",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Refund"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund is not approved, or the order has no captured payment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Payment provider did not refund, the refund stays approved",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/refund/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending refund with a reason",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Reject Refund",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refund ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "Review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.RefundReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rejected refund",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Refund"
+                        }
+                    },
+                    "400": {
+                        "description": "Reason missing",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or revoked token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Permission denied",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Refund not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund is not pending",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservation/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hold flash sale stock for a pending order until the reservation expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Create Reservation",
+                "parameters": [
+                    {
+                        "description": "Reservation data",
+                        "name": "Reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CreateReservationReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admitted waiting room token, required while the waiting room is on",
+                        "name": "Queue-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Admission token missing or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Flash sale product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Out of stock, purchase limit reached or flash sale not active",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Still waiting in the queue",
+                        "schema": {
+                            "$ref": "#/definitions/waitingroom.Status"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservation/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a Reservation by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Get Reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reservation",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Reservation"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservation/{id}/checkout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pay for a held reservation and confirm its order, a failed checkout releases the reservation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Checkout Reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment method token",
+                        "name": "Checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CheckoutReq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmed reservation and its payment",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CheckoutRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Payment declined, or reservation no longer held",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key reused with a different request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Payment provider unavailable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservation/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm the order of a reservation that has not expired without taking a payment. For admins only, buyers go through checkout.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Confirm Reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmed reservation",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Reservation"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation expired or already released",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reservation/{id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the order of a reservation and return its units to the sale",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservation"
+                ],
+                "summary": "Release Reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reservation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Released reservation",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Reservation"
+                        }
+                    },
+                    "404": {
+                        "description": "Reservation not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation already confirmed or expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reviews": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submit a review for a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Create a Review",
+                "parameters": [
+                    {
+                        "description": "Create Review Request",
+                        "name": "CreateReviewReq",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.CreateReviewReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.Void"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "type": "string"
                         }
+                    }
+                }
+            }
+        },
+        "/v1/reviews/{productId}/rating": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the average rating and total reviews for a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Get Product Rating",
+                "responses": {
+                    "200": {
+                        "description": "Product rating response",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.ProductRatingRes"
+                        }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/transaction/balance/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a user's balance computed from debits and credits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transaction"
+                ],
+                "summary": "Get Balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance",
+                        "schema": {
+                            "$ref": "#/definitions/genproto.BalanceGetRes"
                         }
                     },
                     "500": {
@@ -1802,14 +3393,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/product/{id}": {
-            "get": {
+        "/v1/transaction/create": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get an Product by ID",
+                "description": "Record a debit or credit for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1817,23 +3408,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Product"
+                    "Transaction"
                 ],
-                "summary": "Get Product",
+                "summary": "Create Transaction",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Transaction data",
+                        "name": "Transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/genproto.TransactionCreateReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product data",
+                        "description": "Transaction created successfully",
                         "schema": {
-                            "$ref": "#/definitions/genproto.Products"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1842,12 +3435,6 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1857,14 +3444,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/reviews": {
-            "post": {
+        "/v1/transaction/list": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Submit a review for a product",
+                "description": "List Transactions with filters",
                 "consumes": [
                     "application/json"
                 ],
@@ -1872,25 +3459,64 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Review"
+                    "Transaction"
                 ],
-                "summary": "Create a Review",
+                "summary": "List Transactions",
                 "parameters": [
                     {
-                        "description": "Create Review Request",
-                        "name": "CreateReviewReq",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/genproto.CreateReviewReq"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Flash Sale Product ID",
+                        "name": "flash_sale_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "debit or credit",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount from",
+                        "name": "amount_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Amount to",
+                        "name": "amount_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Review created successfully",
+                        "description": "List of Transactions",
                         "schema": {
-                            "$ref": "#/definitions/genproto.Void"
+                            "$ref": "#/definitions/genproto.TransactionListRes"
                         }
                     },
                     "400": {
@@ -1908,14 +3534,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/reviews/{productId}/rating": {
+        "/v1/transaction/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the average rating and total reviews for a product",
+                "description": "Get a Transaction by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1923,24 +3549,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Review"
+                    "Transaction"
+                ],
+                "summary": "Get Transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Product Rating",
                 "responses": {
                     "200": {
-                        "description": "Product rating response",
-                        "schema": {
-                            "$ref": "#/definitions/genproto.ProductRatingRes"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Transaction data",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/genproto.TransactionGetRes"
                         }
                     },
                     "404": {
-                        "description": "Product not found",
+                        "description": "Transaction not found",
                         "schema": {
                             "type": "string"
                         }
@@ -2110,13 +3739,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.EditProfileReqBpdy"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -2199,13 +3837,22 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/genproto.Setting"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kafka topic to publish the outcome to, must start with replies.",
+                        "name": "Reply-To",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Setting updated successfully",
+                    "202": {
+                        "description": "command_id to look up at /v1/commands/{id}",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -2236,6 +3883,34 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.BalanceGetRes": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "get_usage": {
+                    "type": "boolean"
+                },
+                "usage": {
+                    "$ref": "#/definitions/genproto.BalanceGetRes_Stats"
+                }
+            }
+        },
+        "genproto.BalanceGetRes_Stats": {
+            "type": "object",
+            "properties": {
+                "flash_sale_spendings": {
+                    "type": "number"
+                },
+                "payments": {
+                    "type": "number"
+                },
+                "products_spendings": {
+                    "type": "number"
+                }
+            }
+        },
         "genproto.CancelFlashSaleRes": {
             "type": "object",
             "properties": {
@@ -2250,6 +3925,17 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.CancelOrderRes": {
+            "type": "object",
+            "properties": {
+                "cancellation_status": {
+                    "type": "string"
+                },
+                "refund_status": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.ChangePasswordReqBody": {
             "type": "object",
             "properties": {
@@ -2261,6 +3947,58 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.CheckoutReq": {
+            "type": "object",
+            "properties": {
+                "payment_token": {
+                    "type": "string"
+                },
+                "reservation_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.CheckoutRes": {
+            "type": "object",
+            "properties": {
+                "payment": {
+                    "$ref": "#/definitions/genproto.Payment"
+                },
+                "reservation": {
+                    "$ref": "#/definitions/genproto.Reservation"
+                }
+            }
+        },
+        "genproto.CommandStatus": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "topic": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "who sent the command, empty for registrations",
+                    "type": "string"
+                }
+            }
+        },
         "genproto.CreateFlashSaleProductReq": {
             "type": "object",
             "properties": {
@@ -2273,6 +4011,10 @@ const docTemplate = `{
                 "flashSaleId": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "description": "0 means no limit",
+                    "type": "integer"
+                },
                 "productId": {
                     "type": "string"
                 }
@@ -2287,6 +4029,10 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "description": "units one user may buy across the whole sale, 0 means no limit",
+                    "type": "integer"
+                },
                 "start_time": {
                     "type": "string"
                 },
@@ -2298,12 +4044,31 @@ const docTemplate = `{
         "genproto.CreateOrderReq": {
             "type": "object",
             "properties": {
+                "caller_id": {
+                    "description": "user who sent the request, idempotency keys are scoped to them",
+                    "type": "string"
+                },
                 "flashSaleID": {
                     "type": "string"
                 },
+                "flash_sale_product_id": {
+                    "type": "string"
+                },
+                "idempotency_key": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.OrderItemReq"
+                    }
+                },
                 "order_status": {
                     "type": "string"
                 },
+                "quantity": {
+                    "type": "integer"
+                },
                 "userID": {
                     "type": "string"
                 }
@@ -2329,6 +4094,23 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.CreateReservationReq": {
+            "type": "object",
+            "properties": {
+                "flash_sale_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.OrderItemReq"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.CreateReviewReq": {
             "type": "object",
             "properties": {
@@ -2381,6 +4163,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "type": "integer"
+                },
                 "products": {
                     "type": "array",
                     "items": {
@@ -2416,14 +4201,50 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "type": "integer"
+                },
                 "product": {
                     "$ref": "#/definitions/genproto.Products"
                 },
+                "remaining_allowance": {
+                    "description": "-1 when neither the product nor the sale has a limit",
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "genproto.FlashSaleUpdate": {
+            "type": "object",
+            "properties": {
+                "available_quantity": {
+                    "type": "integer"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "flash_sale_id": {
+                    "type": "string"
+                },
+                "flash_sale_product_id": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.GetByEmail": {
             "type": "object",
             "properties": {
@@ -2526,13 +4347,27 @@ const docTemplate = `{
                 }
             }
         },
-        "genproto.LoginReq": {
+        "genproto.LoginReq": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.LoginRes": {
             "type": "object",
             "properties": {
-                "password": {
+                "access_token": {
                     "type": "string"
                 },
-                "username": {
+                "refresh_token": {
+                    "type": "string"
+                },
+                "role": {
                     "type": "string"
                 }
             }
@@ -2620,9 +4455,21 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.OrderItem"
+                    }
+                },
                 "order_status": {
                     "type": "string"
                 },
+                "original_amount": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
                 "user": {
                     "$ref": "#/definitions/genproto.UserRes"
                 }
@@ -2642,6 +4489,109 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.OrderItem": {
+            "type": "object",
+            "properties": {
+                "discounted_price": {
+                    "type": "number"
+                },
+                "flash_sale_product_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "original_price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "genproto.OrderItemReq": {
+            "type": "object",
+            "properties": {
+                "flash_sale_product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "genproto.OrderStatusEntry": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current_location": {
+                    "type": "string"
+                },
+                "estimated_delivery": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.OrderTimeline": {
+            "type": "object",
+            "properties": {
+                "current_status": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.OrderStatusEntry"
+                    }
+                },
+                "order_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_payment_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.Product": {
             "type": "object",
             "properties": {
@@ -2705,23 +4655,85 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.RefreshReq": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.Refund": {
             "type": "object",
             "properties": {
                 "amount": {
                     "type": "number"
                 },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "order_id": {
                     "type": "string"
                 },
+                "reason": {
+                    "type": "string"
+                },
                 "refund_status": {
                     "type": "string"
                 },
+                "transaction_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
+        "genproto.RefundCreateReq": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.RefundListRes": {
+            "type": "object",
+            "properties": {
+                "refunds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.Refund"
+                    }
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "genproto.RefundReviewReq": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.RegisterReq": {
             "type": "object",
             "properties": {
@@ -2753,6 +4765,35 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.Reservation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.OrderItem"
+                    }
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "genproto.ResetPassReqBody": {
             "type": "object",
             "properties": {
@@ -2781,6 +4822,69 @@ const docTemplate = `{
                 }
             }
         },
+        "genproto.TransactionCreateReq": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "flash_sale_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.TransactionGetRes": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flash_sale": {
+                    "$ref": "#/definitions/genproto.FlashSaleProduct"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "products": {
+                    "$ref": "#/definitions/genproto.Products"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "genproto.TransactionListRes": {
+            "type": "object",
+            "properties": {
+                "total_count": {
+                    "type": "integer"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/genproto.TransactionGetRes"
+                    }
+                }
+            }
+        },
         "genproto.UpdateBody": {
             "type": "object",
             "properties": {
@@ -2810,6 +4914,10 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "description": "0 keeps the current limit, -1 removes it",
+                    "type": "integer"
+                },
                 "products": {
                     "type": "array",
                     "items": {
@@ -2836,6 +4944,10 @@ const docTemplate = `{
                 "flashSaleId": {
                     "type": "string"
                 },
+                "per_user_limit": {
+                    "description": "0 keeps the current limit, -1 removes it",
+                    "type": "integer"
+                },
                 "productId": {
                     "type": "string"
                 }
@@ -2866,6 +4978,12 @@ const docTemplate = `{
         "genproto.UpdateOrder": {
             "type": "object",
             "properties": {
+                "current_location": {
+                    "type": "string"
+                },
+                "estimated_delivery": {
+                    "type": "string"
+                },
                 "flashSaleID": {
                     "type": "string"
                 },
@@ -2883,8 +5001,15 @@ const docTemplate = `{
                 "body": {
                     "$ref": "#/definitions/genproto.UpdateOrder"
                 },
+                "caller_id": {
+                    "description": "user who sent the request, idempotency keys are scoped to them",
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
+                },
+                "idempotency_key": {
+                    "type": "string"
                 }
             }
         },
@@ -2924,6 +5049,23 @@ const docTemplate = `{
         },
         "genproto.Void": {
             "type": "object"
+        },
+        "waitingroom.Status": {
+            "type": "object",
+            "properties": {
+                "admitted": {
+                    "type": "boolean"
+                },
+                "estimated_wait_seconds": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -1,9 +1,15 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"log"
+	nethttp "net/http"
+	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"syscall"
 
 	grpc "flashSale_gateway/internal/gRPC"
 	"flashSale_gateway/internal/http"
@@ -11,6 +17,7 @@ import (
 	"flashSale_gateway/internal/pkg/config"
 	"flashSale_gateway/internal/pkg/kafka"
 	"flashSale_gateway/internal/pkg/logger"
+	"flashSale_gateway/internal/pkg/waitingroom"
 
 	"github.com/go-redis/redis/v8"
 )
@@ -42,14 +49,57 @@ func Run(cfg config.Config) {
 		log.Fatal(err)
 		return
 	}
-	defer kafka.Close()
+
+	// queue buyers in front of order creation only when asked to
+	var room *waitingroom.Room
+	if cfg.WaitingRoomEnabled {
+		room = waitingroom.New(rdb, cfg.WaitingRoomRate, cfg.WaitingRoomTokenTTL)
+	}
 
 	// make handler
-	h := handlers.NewHandler(*clients, kafka, rdb, logger)
+	h := handlers.NewHandler(*clients, kafka, rdb, room, logger)
 
 	// make gin
 	router := http.NewGin(h)
 
 	// start server
-	router.Run(":5050")
+	srv := &nethttp.Server{
+		Addr:    ":5050",
+		Handler: router,
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		logger.ERROR.Println("Failed to start server", err)
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	logger.INFO.Println("Shutting down, waiting up to", cfg.ShutdownTimeout)
+	shutdown, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// stop accepting requests and let the running ones finish
+	if err := srv.Shutdown(shutdown); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+		logger.ERROR.Println("Requests did not finish in time", err)
+	}
+	// the writer flushes what is still buffered
+	if err := kafka.Close(); err != nil {
+		logger.ERROR.Println("Failed to close Kafka producer", err)
+	}
+	if err := clients.Close(); err != nil {
+		logger.ERROR.Println("Failed to close gRPC connection", err)
+	}
+	if err := rdb.Close(); err != nil {
+		logger.ERROR.Println("Failed to close Redis", err)
+	}
+	logger.INFO.Println("Server stopped")
 }
@@ -15,14 +15,22 @@ type Clients struct {
 	FlashSaleProduct pb.FlashSaleProductServiceClient
 	Product          pb.ProductServiceClient
 	Transaction      pb.TransactionServiceClient
+	Reservation      pb.ReservationServiceClient
+	Refund           pb.RefundServiceClient
 	User             pb.UserServiceClient
 	Notification     pb.NotificationServiceClient
 	Social           pb.SocialSharingServiceClient
 	Review           pb.ReviewServiceClient
+	Command          pb.CommandServiceClient
+
+	conn *grpc.ClientConn
 }
 
 func NewClients(cfg *config.Config) (*Clients, error) {
-	service_conn, err := grpc.NewClient("flash_sale_service:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	service_conn, err := grpc.NewClient("flash_sale_service:50051",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(withDeadlines(cfg.RPCTimeout, cfg.RPCTimeouts)),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +42,12 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 	flashSaleClient := pb.NewFlashSaleServiceClient(service_conn)
 	flashSaleProductClient := pb.NewFlashSaleProductServiceClient(service_conn)
 	transactionClient := pb.NewTransactionServiceClient(service_conn)
+	reservationClient := pb.NewReservationServiceClient(service_conn)
+	refundClient := pb.NewRefundServiceClient(service_conn)
 	notificationClient := pb.NewNotificationServiceClient(service_conn)
 	reviewClient := pb.NewReviewServiceClient(service_conn)
 	socialClient := pb.NewSocialSharingServiceClient(service_conn)
+	commandClient := pb.NewCommandServiceClient(service_conn)
 
 	return &Clients{
 		Auth:             authClient,
@@ -44,10 +55,19 @@ func NewClients(cfg *config.Config) (*Clients, error) {
 		FlashSale:        flashSaleClient,
 		Product:          productClient,
 		Transaction:      transactionClient,
+		Reservation:      reservationClient,
+		Refund:           refundClient,
 		User:             userClient,
 		FlashSaleProduct: flashSaleProductClient,
 		Notification:     notificationClient,
 		Review:           reviewClient,
 		Social:           socialClient,
+		Command:          commandClient,
+		conn:             service_conn,
 	}, nil
 }
+
+// Close closes the connection to the flash sale service.
+func (c *Clients) Close() error {
+	return c.conn.Close()
+}
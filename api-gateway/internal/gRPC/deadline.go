@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// withDeadlines gives a unary call that has no deadline of its own the timeout
+// configured for its method, or def when there is none. Streams are left alone,
+// they last as long as the client watches.
+func withDeadlines(def time.Duration, perMethod map[string]time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			timeout, ok := perMethod[method[strings.LastIndex(method, ".")+1:]]
+			if !ok {
+				timeout = def
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "flashSale_gateway/internal/pkg/genproto"
+
+	"google.golang.org/grpc"
+)
+
+// deadlineOf calls method through the interceptor and returns how long the
+// invoked call had left, or false when it had no deadline.
+func deadlineOf(t *testing.T, interceptor grpc.UnaryClientInterceptor, ctx context.Context, method string) (time.Duration, bool) {
+	var (
+		left time.Duration
+		ok   bool
+	)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		var deadline time.Time
+		deadline, ok = ctx.Deadline()
+		left = time.Until(deadline)
+		return nil
+	}
+	if err := interceptor(ctx, method, nil, nil, nil, invoker); err != nil {
+		t.Fatalf("error was not expected while invoking %s: %s", method, err)
+	}
+	return left, ok
+}
+
+func TestWithDeadlines(t *testing.T) {
+	interceptor := withDeadlines(5*time.Second, map[string]time.Duration{
+		"ReservationService/Checkout": 30 * time.Second,
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		want   time.Duration
+	}{
+		{"configured method", pb.ReservationService_Checkout_FullMethodName, 30 * time.Second},
+		{"other method", pb.OrderService_GetOrder_FullMethodName, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, ok := deadlineOf(t, interceptor, context.Background(), tt.method)
+			if !ok || left <= tt.want-time.Second || left > tt.want {
+				t.Errorf("expected a deadline of %s, got %s (set: %v)", tt.want, left, ok)
+			}
+		})
+	}
+}
+
+func TestWithDeadlinesKeepsCallerDeadline(t *testing.T) {
+	interceptor := withDeadlines(5*time.Second, map[string]time.Duration{
+		"ReservationService/Checkout": 30 * time.Second,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	left, ok := deadlineOf(t, interceptor, ctx, pb.ReservationService_Checkout_FullMethodName)
+	if !ok || left > time.Second {
+		t.Errorf("expected the caller's deadline of 1s to be kept, got %s (set: %v)", left, ok)
+	}
+}
+
+func TestWithDeadlinesDisabled(t *testing.T) {
+	// a timeout of 0 leaves calls without a deadline
+	interceptor := withDeadlines(0, nil)
+
+	if left, ok := deadlineOf(t, interceptor, context.Background(), pb.OrderService_GetOrder_FullMethodName); ok {
+		t.Errorf("expected no deadline, got %s", left)
+	}
+}
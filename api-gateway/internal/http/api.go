@@ -1,6 +1,8 @@
 package http
 
 import (
+	"time"
+
 	"flashSale_gateway/internal/http/handlers"
 
 	_ "flashSale_gateway/docs"
@@ -26,10 +28,11 @@ func NewGin(h *handlers.Handler) *gin.Engine {
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"}, // Adjust for your specific origins
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", m.IdempotencyHeader, handlers.QueueTokenHeader, m.ReplyToHeader},
+		ExposeHeaders:    []string{"Content-Length", "Idempotent-Replayed", "Retry-After", "Location"},
 		AllowCredentials: true,
 	}))
+	router.Use(m.ReplyTo())
 
 	// enforcer, err := casbin.NewEnforcer("./internal/http/casbin/model.conf", "./internal/http/casbin/policy.csv")
 	// if err != nil {
@@ -38,12 +41,20 @@ func NewGin(h *handlers.Handler) *gin.Engine {
 	// router.Use(m.NewAuth(enforcer))
 
 	router.POST("/register", h.RegisterUser).Use(m.Middleware())
+	router.GET("/register/status", h.GetRegistrationStatus)
 	router.POST("/login", h.LoginUser).Use(m.Middleware())
+	router.POST("/auth/refresh", h.RefreshToken)
 	router.POST("/forgot-password", h.ForgotPassword)
 	router.POST("/reset-password", h.ResetPassword)
-	router.GET("/users", h.GetAllUsers).Use(m.JWTMiddleware())
 
-	user := router.Group("/v1/user").Use(m.JWTMiddleware())
+	denylist := m.NewDenylist(h.Redis)
+	router.POST("/auth/logout", m.JWTMiddleware(denylist), h.Logout)
+	router.POST("/auth/logout-all-sessions", m.JWTMiddleware(denylist), h.LogoutAllSessions)
+	router.POST("/v1/admin/users/:id/revoke-sessions", m.JWTMiddleware(denylist), m.RequireRole("admin"), h.RevokeUserSessions)
+
+	router.GET("/users", h.GetAllUsers).Use(m.JWTMiddleware(denylist))
+
+	user := router.Group("/v1/user").Use(m.JWTMiddleware(denylist))
 	{
 		user.GET("/profiles", h.GetProfile)
 		user.PUT("/profiles", h.EditProfile)
@@ -70,20 +81,53 @@ func NewGin(h *handlers.Handler) *gin.Engine {
 		flashSale.DELETE("/delete/:id", h.DeleteFlashSale)
 
 		flashSale.GET("/:id/location", h.GetStoreLocation)
+		flashSale.GET("/:id/queue", h.GetFlashSaleQueue)
+		flashSale.GET("/:id/watch", h.WatchFlashSale)
 		flashSale.POST("/products", h.AddProductToFlashSale)
 		flashSale.DELETE("/products", h.RemoveProductFromFlashSale)
 		flashSale.POST("/:id/cancel", h.CancelFlashSale)
 	}
+	idempotent := m.Idempotency(h.Redis, 24*time.Hour)
 	order := router.Group("/v1/order")
 	{
-		order.POST("/create", h.CreateOrder)
+		order.POST("/create", idempotent, h.CreateOrder)
 		order.GET("/:id", h.GetOrder)
 		order.GET("/list", h.ListOrders)
-		order.PUT("/update/:id", h.UpdateOrder)
+		order.PUT("/update/:id", idempotent, h.UpdateOrder)
 		order.DELETE("/delete/:id", h.DeleteOrder)
 
 		order.GET("/history", h.GetOrderHistory)
-		order.POST("/:id/cancel", h.CancelOrder)
+		order.POST("/:id/cancel", idempotent, h.CancelOrder)
+		order.GET("/:id/timeline", h.GetOrderTimeline)
+	}
+	reservation := router.Group("/v1/reservation")
+	{
+		reservation.POST("/create", idempotent, h.CreateReservation)
+		reservation.GET("/:id", h.GetReservation)
+		reservation.POST("/:id/release", h.ReleaseReservation)
+		reservation.POST("/:id/checkout", idempotent, h.Checkout)
+
+		// buyers confirm through checkout, which takes the payment first
+		reservation.POST("/:id/confirm", m.RequireRole("admin"), h.ConfirmReservation)
+	}
+	refund := router.Group("/v1/refund")
+	{
+		refund.POST("/request", idempotent, h.RequestRefund)
+		refund.GET("/:id", h.GetRefund)
+
+		// reviewing refunds and booking them in the ledger is for admins
+		refundAdmin := refund.Group("", m.JWTMiddleware(denylist), m.RequireRole("admin"))
+		refundAdmin.GET("/list", h.ListRefunds)
+		refundAdmin.POST("/:id/approve", h.ApproveRefund)
+		refundAdmin.POST("/:id/reject", h.RejectRefund)
+		refundAdmin.POST("/:id/process", h.ProcessRefund)
+	}
+	transaction := router.Group("/v1/transaction")
+	{
+		transaction.POST("/create", h.CreateTransaction)
+		transaction.GET("/:id", h.GetTransaction)
+		transaction.GET("/list", h.ListTransactions)
+		transaction.GET("/balance/:user_id", h.GetBalance)
 	}
 	product := router.Group("/v1/product")
 	{
@@ -112,6 +156,8 @@ func NewGin(h *handlers.Handler) *gin.Engine {
 		social.GET("/:flashSaleId/sharing", h.GetSharingStats)
 		
 	}
+	// outcome of the writes answered with 202
+	router.GET("/v1/commands/:id", m.JWTMiddleware(denylist), h.GetCommandStatus)
 
 
 	return router
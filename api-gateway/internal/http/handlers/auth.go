@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -13,7 +11,9 @@ import (
 	t "flashSale_gateway/internal/pkg/token"
 	auth "flashSale_gateway/internal/pkg/genproto"
 	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
 	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/status"
 
 	md "flashSale_gateway/internal/http/middleware"
 	"flashSale_gateway/internal/pkg/email"
@@ -40,7 +40,8 @@ var (
 // @Produce json
 // @Security BearerAuth
 // @Param user body auth.RegisterReq true "Register User Request"
-// @Success 200 {object} string "User registered successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id, and the status_token to look it up with at /register/status"
 // @Failure 400 {string} string "invalid request"
 // @Failure 500 {string} string "internal server error"
 // @Router /register [post]
@@ -96,14 +97,7 @@ func (h *Handler) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	input, err := json.Marshal(req)
-	if err != nil {
-		slog.Error("failed to marshal JSON: %v", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err})
-		return
-	}
-
-	err = h.Producer.ProduceMessages("create", input)
+	commandID, err := h.sendCommand(c, "create", req, 1)
 	if err != nil {
 		slog.Error("failed to produce message: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
@@ -116,8 +110,10 @@ func (h *Handler) RegisterUser(c *gin.Context) {
 	registeredUsers.users[req.Username] = struct{}{}
 	registeredUsers.Unlock()
 
-	slog.Info("User registered successfully", "username", req.Username)
-	c.JSON(http.StatusOK, gin.H{"message": "User registered successfully"})
+	slog.Info("User registration accepted", "username", req.Username, "command_id", commandID)
+	// there is no account to look the command up with yet
+	c.Header("Location", "/register/status")
+	c.JSON(http.StatusAccepted, gin.H{"command_id": commandID, "status_token": t.GenerateCommandToken(commandID)})
 }
 
 // LoginUser handles user login
@@ -140,7 +136,7 @@ func (h *Handler) LoginUser(c *gin.Context) {
 		return
 	}
 
-	res, err := h.Clients.Auth.Login(context.Background(), &req)
+	res, err := h.Clients.Auth.Login(c.Request.Context(), &req)
 	if err != nil {
 		slog.Error("failed to login user: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
@@ -149,6 +145,17 @@ func (h *Handler) LoginUser(c *gin.Context) {
 
 	token, refToken := t.GenerateJWTToken(res)
 
+	// the refresh token starts a family of its own, see RefreshToken
+	_, err = h.Clients.Auth.SaveRefreshToken(c.Request.Context(), &auth.RefToken{
+		UserId: res.Id,
+		Token:  refToken,
+	})
+	if err != nil {
+		slog.Error("failed to save refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
+		return
+	}
+
 	slog.Info("User logged in successfully", "username", req.Username)
 	c.JSON(http.StatusOK, auth.LoginRes{
 		AccessToken:  token,
@@ -157,6 +164,167 @@ func (h *Handler) LoginUser(c *gin.Context) {
 	})
 }
 
+// RefreshToken handles refresh token rotation
+// @Summary Refresh tokens
+// @Description Trade a refresh token for a new access and refresh token. A refresh token works once; reusing one revokes every refresh token issued since the login it came from.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body auth.RefreshReq true "Refresh Request"
+// @Success 200 {object} auth.LoginRes
+// @Failure 400 {string} string "invalid request"
+// @Failure 401 {string} string "invalid, revoked or reused refresh token"
+// @Failure 500 {string} string "internal server error"
+// @Router /auth/refresh [post]
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req auth.RefreshReq
+	if err := c.BindJSON(&req); err != nil || req.RefreshToken == "" {
+		slog.Error("failed to bind JSON: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	claims, err := t.ExtractClaim(req.RefreshToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+	userID, ok := claims["user_id"].(string)
+	if !ok || claims["typ"] == t.TypeAccess {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+
+	refToken := t.GenerateRefreshToken(userID)
+	user, err := h.Clients.Auth.RotateRefreshToken(c.Request.Context(), &auth.RotateTokenReq{
+		Token:    req.RefreshToken,
+		NewToken: refToken,
+	})
+	if err != nil {
+		slog.Error("failed to rotate refresh token: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	slog.Info("Tokens refreshed", "user_id", user.Id)
+	c.JSON(http.StatusOK, auth.LoginRes{
+		AccessToken:  t.GenerateAccessToken(user),
+		RefreshToken: refToken,
+		Role:         user.Role,
+	})
+}
+
+// Logout handles logging out of the current session
+// @Summary Logout
+// @Description Revoke the access token of the request and, when given, the refresh token of the same session.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body auth.RefreshReq false "Refresh token of the session"
+// @Success 200 {string} string "logged out"
+// @Failure 400 {string} string "invalid request"
+// @Failure 401 {string} string "invalid or revoked token"
+// @Failure 500 {string} string "internal server error"
+// @Router /auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	claims := c.MustGet("claims").(jwt.MapClaims)
+	userID, _ := claims["user_id"].(string)
+
+	var req auth.RefreshReq
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&req); err != nil {
+			slog.Error("failed to bind JSON: %v", err)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	}
+
+	if req.RefreshToken != "" {
+		refClaims, err := t.ExtractClaim(req.RefreshToken)
+		if err != nil || refClaims["user_id"] != userID || refClaims["typ"] == t.TypeAccess {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid refresh token"})
+			return
+		}
+		if _, err := h.Clients.Auth.RevokeRefreshToken(c.Request.Context(), &req); err != nil {
+			slog.Error("failed to revoke refresh token: %v", err)
+			c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+			return
+		}
+	}
+
+	if err := md.NewDenylist(h.Redis).RevokeToken(c.Request.Context(), claims); err != nil {
+		slog.Error("failed to revoke access token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	slog.Info("User logged out", "user_id", userID)
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// LogoutAllSessions handles logging out of every session
+// @Summary Logout of all sessions
+// @Description Revoke every refresh and access token issued to the user so far.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {string} string "logged out of all sessions"
+// @Failure 401 {string} string "invalid or revoked token"
+// @Failure 500 {string} string "internal server error"
+// @Router /auth/logout-all-sessions [post]
+func (h *Handler) LogoutAllSessions(c *gin.Context) {
+	claims := c.MustGet("claims").(jwt.MapClaims)
+	userID, _ := claims["user_id"].(string)
+
+	if !h.revokeSessions(c, userID) {
+		return
+	}
+
+	slog.Info("User logged out of all sessions", "user_id", userID)
+	c.JSON(http.StatusOK, gin.H{"message": "logged out of all sessions"})
+}
+
+// RevokeUserSessions handles revoking every session of a user
+// @Summary Revoke all sessions of a user
+// @Description Revoke every refresh and access token issued to the user so far, for admins.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {string} string "sessions revoked"
+// @Failure 401 {string} string "invalid or revoked token"
+// @Failure 403 {string} string "permission denied"
+// @Failure 500 {string} string "internal server error"
+// @Router /v1/admin/users/{id}/revoke-sessions [post]
+func (h *Handler) RevokeUserSessions(c *gin.Context) {
+	userID := c.Param("id")
+
+	if !h.revokeSessions(c, userID) {
+		return
+	}
+
+	slog.Info("Sessions of user revoked", "user_id", userID)
+	c.JSON(http.StatusOK, gin.H{"message": "sessions revoked"})
+}
+
+// revokeSessions marks the user's refresh tokens deleted and denylists the
+// access tokens issued so far. It writes the error response and reports
+// false when either fails.
+func (h *Handler) revokeSessions(c *gin.Context, userID string) bool {
+	if _, err := h.Clients.Auth.RevokeUserTokens(c.Request.Context(), &auth.GetById{Id: userID}); err != nil {
+		slog.Error("failed to revoke refresh tokens: %v", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return false
+	}
+	if err := md.NewDenylist(h.Redis).RevokeUser(c.Request.Context(), userID); err != nil {
+		slog.Error("failed to revoke access tokens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return false
+	}
+	return true
+}
+
 // ForgotPassword handles forgot password functionality
 // @Summary Forgot password
 // @Description Request to reset user's password
@@ -176,7 +344,7 @@ func (h *Handler) ForgotPassword(c *gin.Context) {
 		return
 	}
 
-	_, err := h.Clients.Auth.ForgotPassword(context.Background(), &req)
+	_, err := h.Clients.Auth.ForgotPassword(c.Request.Context(), &req)
 	if err != nil {
 		slog.Error("failed to send password reset email: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
@@ -185,7 +353,7 @@ func (h *Handler) ForgotPassword(c *gin.Context) {
 
 	forgotPasswordCode := email.GenForgotPassword()
 
-	err = h.Redis.Set(context.Background(), forgotPasswordCode, req.Email, 15*time.Minute).Err()
+	err = h.Redis.Set(c.Request.Context(), forgotPasswordCode, req.Email, 15*time.Minute).Err()
 	if err != nil {
 		slog.Error("failed to store forgot password code in Redis: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
@@ -237,7 +405,7 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 
 	req.NewPassword = password
 
-	email, err := h.Redis.Get(context.Background(), req.ResetToken).Result()
+	email, err := h.Redis.Get(c.Request.Context(), req.ResetToken).Result()
 	if err == redis.Nil {
 		slog.Error("forgot password code not found in Redis: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err})
@@ -246,7 +414,7 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 
 	req.Email = email
 
-	_, err = h.Clients.Auth.ResetPassword(context.Background(), &req)
+	_, err = h.Clients.Auth.ResetPassword(c.Request.Context(), &req)
 	if err != nil {
 		slog.Error("failed to reset password: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -315,7 +483,7 @@ func (h *Handler) GetAllUsers(c *gin.Context) {
 		},
 	}
 
-	res, err := h.Clients.Auth.GetAllUsers(context.Background(), req)
+	res, err := h.Clients.Auth.GetAllUsers(c.Request.Context(), req)
 	if err != nil {
 		slog.Error("failed to get all Users: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
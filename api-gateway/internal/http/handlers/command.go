@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	m "flashSale_gateway/internal/http/middleware"
+	pb "flashSale_gateway/internal/pkg/genproto"
+	tokens "flashSale_gateway/internal/pkg/token"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// sendCommand hands a write over to flash_service through kafka and returns
+// the ID its outcome can be looked up under, by the caller only. version is
+// that of the payload's schema. The Reply-To header was checked by m.ReplyTo
+// already.
+func (h *Handler) sendCommand(c *gin.Context, topic string, payload proto.Message, version int32) (string, error) {
+	return h.Producer.ProduceCommand(topic, payload, version, c.GetHeader(m.ReplyToHeader), callerID(c))
+}
+
+// callerID is the user the access token of the request was issued to, empty
+// for requests without one.
+func callerID(c *gin.Context) string {
+	claims, _ := c.Get("claims")
+	mc, _ := claims.(jwt.MapClaims)
+	userID, _ := mc["user_id"].(string)
+	return userID
+}
+
+// accepted answers a request whose write was handed over as a command.
+func accepted(c *gin.Context, commandID string) {
+	c.Header("Location", "/v1/commands/"+commandID)
+	c.JSON(http.StatusAccepted, gin.H{"command_id": commandID})
+}
+
+// @Summary Get Command Status
+// @Description Get the outcome of a write that was accepted with 202. A command flash_service has not picked up yet, or one of another user, is reported as pending.
+// @Tags Command
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Command ID"
+// @Success 200 {object} pb.CommandStatus "Command status"
+// @Failure 401 {string} string "Unauthorized"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/commands/{id} [get]
+func (h *Handler) GetCommandStatus(c *gin.Context) {
+	h.commandStatus(c, c.Param("id"), callerID(c))
+}
+
+// @Summary Get Registration Status
+// @Description Get the outcome of a registration, with the status_token it was accepted with as the bearer token. A registration flash_service has not picked up yet is reported as pending.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} pb.CommandStatus "Command status"
+// @Failure 401 {string} string "Invalid status token"
+// @Failure 500 {string} string "Internal server error"
+// @Router /register/status [get]
+func (h *Handler) GetRegistrationStatus(c *gin.Context) {
+	claims, err := tokens.ExtractClaim(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	if err != nil || claims["typ"] != tokens.TypeCommand {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid status token"})
+		return
+	}
+	commandID, _ := claims["command_id"].(string)
+	if commandID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid status token"})
+		return
+	}
+
+	// registrations are sent without logging in, and so by nobody
+	h.commandStatus(c, commandID, "")
+}
+
+// commandStatus answers with the status of a command userID sent.
+func (h *Handler) commandStatus(c *gin.Context, id, userID string) {
+	res, err := h.Clients.Command.GetCommandStatus(c.Request.Context(), &pb.CommandStatusReq{Id: id, UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		// flash_service records a command once it handled it the first time
+		c.JSON(http.StatusOK, &pb.CommandStatus{Id: id, Status: "pending"})
+		return
+	} else if err != nil {
+		h.Logger.ERROR.Println("Failed to get command status:", err)
+		c.JSON(httpStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -1,12 +1,12 @@
 package handlers
 
 import (
-	"context"
 	pb "flashSale_gateway/internal/pkg/genproto"
+	"io"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/grpc/status"
 )
 
 // CreateFlashSale creates a new FlashSale
@@ -29,7 +29,7 @@ func (h *Handler) CreateFlashSale(c *gin.Context) {
 		return
 	}
 
-	_,err := h.Clients.FlashSale.CreateFlashSale(context.Background(), &req)
+	_,err := h.Clients.FlashSale.CreateFlashSale(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -57,7 +57,7 @@ func (h *Handler) GetFlashSale(c *gin.Context) {
 
 	req.Id = id
 
-	res, err := h.Clients.FlashSale.GetFlashSale(context.Background(), &req)
+	res, err := h.Clients.FlashSale.GetFlashSale(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -74,7 +74,8 @@ func (h *Handler) GetFlashSale(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param FlashSale body pb.UpdateFlashSalesReq true "FlashSale update data"
-// @Success 200 {string} string "message":"Flash Sale updated successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /v1/flashSale/update/{id} [put]
@@ -85,21 +86,14 @@ func (h *Handler) UpdateFlashSale(c *gin.Context) {
 		return
 	}
 
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("update-flash", input)
+	commandID, err := h.sendCommand(c, "update-flash", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Flash sale updated successfully"})
+	accepted(c, commandID)
 }
 
 // @Summary List FlashSales
@@ -144,7 +138,7 @@ func (h *Handler) ListFlashSales(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.Clients.FlashSale.ListAllFlashSales(context.Background(), &filter)
+	resp, err := h.Clients.FlashSale.ListAllFlashSales(c.Request.Context(), &filter)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -168,7 +162,7 @@ func (h *Handler) DeleteFlashSale(c *gin.Context) {
 	id := c.Param("id")
 
 	req := &pb.GetById{Id: id}
-	_, err := h.Clients.FlashSale.DeleteFlashSale(context.Background(), req)
+	_, err := h.Clients.FlashSale.DeleteFlashSale(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -196,7 +190,7 @@ func (h *Handler) AddProductToFlashSale(c *gin.Context) {
 		return
 	}
 
-	_, err := h.Clients.FlashSale.AddProductToFlashSale(context.Background(), &req)
+	_, err := h.Clients.FlashSale.AddProductToFlashSale(c.Request.Context(), &req)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to add product to flash sale:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
@@ -223,7 +217,7 @@ func (h *Handler) RemoveProductFromFlashSale(c *gin.Context) {
 	productId := c.Query("id")
 
 	req := &pb.RemoveProductReq{FlashSaleId: flashSaleId, ProductId: productId}
-	_, err := h.Clients.FlashSale.RemoveProductFromFlashSale(context.Background(), req)
+	_, err := h.Clients.FlashSale.RemoveProductFromFlashSale(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -233,33 +227,29 @@ func (h *Handler) RemoveProductFromFlashSale(c *gin.Context) {
 }
 
 // @Summary       Cancel Flash Sale
-// @Description   Cancel an existing flash sale
+// @Description   Cancel a flash sale, its open orders are canceled and refunded, the sale stops offering stock and buyers are notified
 // @Tags          FlashSale
 // @Accept        json
 // @Produce       json
 // @Security      BearerAuth
 // @Param         id path string true "Flash Sale ID"
-// @Success       200  {object} pb.CancelFlashSaleRes "Flash sale cancelled successfully"
+// @Success       200  {object} pb.CancelFlashSaleRes "Cancellation and the refunds it opened"
 // @Failure       400  {string}  string "Invalid request"
 // @Failure       404  {string}  string "Flash Sale not found"
+// @Failure       409  {string}  string "Flash sale already completed"
 // @Failure       500  {string}  string "Internal server error"
 // @Router        /v1/flashSale/{id}/cancel [post]
 func (h *Handler) CancelFlashSale(c *gin.Context) {
-    req := &pb.GetById{}
-    
-    // Extract the ID from the URL path
-    id := c.Param("id")
-    req.Id = id
-
-    // Call the gRPC service to cancel the flash sale
-    _, err := h.Clients.FlashSale.CancelFlashSale(context.Background(), req)
-    if err != nil {
-        h.Logger.ERROR.Println("Failed to cancel flash sale:", err)
-        c.JSON(500, gin.H{"error": "Internal server error: " + err.Error()})
-        return
-    }
-
-    c.JSON(200, gin.H{"message": "Flash sale cancelled successfully"})
+	req := &pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.FlashSale.CancelFlashSale(c.Request.Context(), req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to cancel flash sale:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
 }
 
 
@@ -283,7 +273,7 @@ func(h *Handler)GetStoreLocation(c *gin.Context){
 		StoreId: c.Param("storeId"),
 	}
 
-	res, err := h.Clients.FlashSale.GetStoreLocation(context.Background(), req)
+	res, err := h.Clients.FlashSale.GetStoreLocation(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -292,3 +282,47 @@ func(h *Handler)GetStoreLocation(c *gin.Context){
 
 
 }
+
+// @Summary       Watch Flash Sale
+// @Description   Stream the status, countdown times and stock of a Flash Sale as Server-Sent Events. The first events carry the current state; "status" and "stock" events follow on every change.
+// @Tags          FlashSale
+// @Produce       text/event-stream
+// @Security      BearerAuth
+// @Param         id path string true "Flash Sale ID"
+// @Success       200  {object} pb.FlashSaleUpdate "Stream of flash sale updates"
+// @Failure       404  {string}  string "Flash sale not found"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/flashSale/{id}/watch [get]
+func (h *Handler) WatchFlashSale(c *gin.Context) {
+	// the stream lives as long as the client stays connected
+	stream, err := h.Clients.FlashSale.WatchFlashSale(c.Request.Context(), &pb.GetById{Id: c.Param("id")})
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	// errors such as an unknown sale arrive with the first message, answer them
+	// before the response turns into an event stream
+	update, err := stream.Recv()
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		if update == nil {
+			update, err = stream.Recv()
+			if err == io.EOF {
+				return false
+			} else if err != nil {
+				if c.Request.Context().Err() == nil {
+					c.SSEvent("error", status.Convert(err).Message())
+				}
+				return false
+			}
+		}
+		c.SSEvent(update.Type, update)
+		update = nil
+		return true
+	})
+}
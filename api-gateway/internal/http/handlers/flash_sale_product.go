@@ -1,12 +1,11 @@
 package handlers
 
 import (
-	"context"
 	pb "flashSale_gateway/internal/pkg/genproto"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/grpc/status"
 )
 
 // CreateFlashSale creates a new FlashSale
@@ -17,7 +16,8 @@ import (
 // @Produce       json
 // @Security      BearerAuth
 // @Param         FlashSale body pb.CreateFlashSaleProductReq true "FlashSale data"
-// @Success       200  {string}  string "Flash Sale Product created successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure       400  {string}  string "Invalid request"
 // @Failure       500  {string}  string "Internal server error"
 // @Router        /v1/flashSaleProduct/create [post]
@@ -29,21 +29,14 @@ func (h *Handler) CreateFlashSaleProduct(c *gin.Context) {
 		return
 	}
 
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("create-flash-sale", input)
+	commandID, err := h.sendCommand(c, "create-flash-sale", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Flash sale product created successfully"})
+	accepted(c, commandID)
 
 }
 
@@ -54,20 +47,21 @@ func (h *Handler) CreateFlashSaleProduct(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "FlashSaleProduct ID"
+// @Param user_id query string false "User whose remaining purchase allowance is returned"
 // @Success 200 {object} pb.FlashSaleProduct "FlashSaleProduct data"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 404 {string} string "Flash Sale Product not found"
 // @Failure 500 {string} string "Internal server error"
 // @Router /v1/flashSaleProduct/{id} [get]
 func (h *Handler) GetFlashSaleProduct(c *gin.Context) {
-	req := pb.GetById{}
-	id := c.Param("id")
-
-	req.Id = id
+	req := pb.GetFlashSaleProductReq{
+		Id:     c.Param("id"),
+		UserId: c.Query("user_id"),
+	}
 
-	res, err := h.Clients.FlashSaleProduct.GetFlashSaleProduct(context.Background(), &req)
+	res, err := h.Clients.FlashSaleProduct.GetFlashSaleProduct(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 
@@ -82,7 +76,8 @@ func (h *Handler) GetFlashSaleProduct(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param FlashSaleProduct body pb.UpdateFlashSaleProductReq true "FlashSaleProduct update data"
-// @Success 200 {string} string "message":"Flash Sale Product updated successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /v1/flashSaleProduct/update/{id} [put]
@@ -92,21 +87,14 @@ func (h *Handler) UpdateFlashSaleProduct(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("update-flash-sale", input)
+	commandID, err := h.sendCommand(c, "update-flash-sale", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Flash sale product updated successfully"})
+	accepted(c, commandID)
 }
 
 // @Summary List Flash Sale Products
@@ -157,7 +145,7 @@ func (h *Handler) ListFlashSaleProducts(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.Clients.FlashSaleProduct.ListAllFlashSaleProducts(context.Background(), &filter)
+	resp, err := h.Clients.FlashSaleProduct.ListAllFlashSaleProducts(c.Request.Context(), &filter)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -181,7 +169,7 @@ func (h *Handler) DeleteFlashSaleProduct(c *gin.Context) {
 	id := c.Param("id")
 
 	req := &pb.GetById{Id: id}
-	_, err := h.Clients.FlashSaleProduct.DeleteFlashSaleProduct(context.Background(), req)
+	_, err := h.Clients.FlashSaleProduct.DeleteFlashSaleProduct(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,120 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	clients "flashSale_gateway/internal/gRPC"
+	"flashSale_gateway/internal/http/handlers"
+	pb "flashSale_gateway/internal/pkg/genproto"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flashSales answers WatchFlashSale with a stream that sends first and then
+// waits for the call to be canceled, as flash_service does while nothing changes.
+type flashSales struct {
+	pb.FlashSaleServiceClient
+	first *pb.FlashSaleUpdate
+}
+
+func (f flashSales) WatchFlashSale(ctx context.Context, in *pb.GetById, opts ...grpc.CallOption) (pb.FlashSaleService_WatchFlashSaleClient, error) {
+	return &watchStream{ctx: ctx, first: f.first}, nil
+}
+
+type watchStream struct {
+	grpc.ClientStream
+	ctx   context.Context
+	first *pb.FlashSaleUpdate
+}
+
+func (s *watchStream) Recv() (*pb.FlashSaleUpdate, error) {
+	if update := s.first; update != nil {
+		s.first = nil
+		return update, nil
+	}
+	<-s.ctx.Done()
+	return nil, status.FromContextError(s.ctx.Err()).Err()
+}
+
+func TestWatchFlashSaleEndsOnDisconnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(clients.Clients{
+		FlashSale: flashSales{first: &pb.FlashSaleUpdate{Type: "status", FlashSaleId: "sale-1", Status: "active"}},
+	}, nil, nil, nil, nil)
+
+	ended := make(chan struct{})
+	router := gin.New()
+	router.GET("/:id/watch", func(c *gin.Context) {
+		defer close(ended)
+		h.WatchFlashSale(c)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	defer disconnect()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sale-1/watch", nil)
+	if err != nil {
+		t.Fatalf("error was not expected while building the request: %s", err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("error was not expected while watching: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK || !strings.HasPrefix(res.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("expected an event stream, got %d %s", res.StatusCode, res.Header.Get("Content-Type"))
+	}
+	event, err := bufio.NewReader(res.Body).ReadString('\n')
+	if err != nil || event != "event:status\n" {
+		t.Fatalf("expected the status event first, got %q: %v", event, err)
+	}
+
+	disconnect()
+	select {
+	case <-ended:
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream to end once the client disconnected")
+	}
+}
+
+func TestWatchFlashSaleUnknownSale(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := handlers.NewHandler(clients.Clients{FlashSale: unknownSale{}}, nil, nil, nil, nil)
+	router := gin.New()
+	router.GET("/:id/watch", h.WatchFlashSale)
+
+	// answered before the response turns into an event stream
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sale-1/watch", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+// unknownSale answers WatchFlashSale the way flash_service does for a sale it
+// does not know.
+type unknownSale struct {
+	pb.FlashSaleServiceClient
+}
+
+func (unknownSale) WatchFlashSale(ctx context.Context, in *pb.GetById, opts ...grpc.CallOption) (pb.FlashSaleService_WatchFlashSaleClient, error) {
+	return &failedStream{err: status.Errorf(codes.NotFound, "flash sale %s not found", in.Id)}, nil
+}
+
+type failedStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *failedStream) Recv() (*pb.FlashSaleUpdate, error) { return nil, s.err }
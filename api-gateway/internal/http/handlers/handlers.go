@@ -1,20 +1,48 @@
 package handlers
 
 import (
+	"net/http"
+
 	grpc "flashSale_gateway/internal/gRPC"
 	"flashSale_gateway/internal/pkg/kafka"
 	"flashSale_gateway/internal/pkg/logger"
+	"flashSale_gateway/internal/pkg/waitingroom"
 
 	"github.com/go-redis/redis/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Handler struct {
-	Clients  grpc.Clients
-	Producer kafka.KafkaProducer
-	Redis    *redis.Client
-	Logger   *logger.Logger
+	Clients     grpc.Clients
+	Producer    kafka.KafkaProducer
+	Redis       *redis.Client
+	WaitingRoom *waitingroom.Room // nil when the waiting room is off
+	Logger      *logger.Logger
+}
+
+func NewHandler(clients grpc.Clients, producer kafka.KafkaProducer, redis *redis.Client, room *waitingroom.Room, logger *logger.Logger) *Handler {
+	return &Handler{Clients: clients, Producer: producer, Redis: redis, WaitingRoom: room, Logger: logger}
 }
 
-func NewHandler(clients grpc.Clients, producer kafka.KafkaProducer, redis *redis.Client, logger *logger.Logger) *Handler {
-	return &Handler{Clients: clients, Producer: producer, Redis: redis, Logger: logger}
+// httpStatus maps a gRPC error returned by flash_service to an HTTP status code.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.ResourceExhausted, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
 }
@@ -1,13 +1,11 @@
 package handlers
 
 import (
-	"context"
 	"strconv"
 
 	pb "flashSale_gateway/internal/pkg/genproto"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // CreateNotification godoc
@@ -18,7 +16,8 @@ import (
 // @Produce json
 // @Security BearerAuth
 // @Param notification body pb.NotificationCreate true "Notification details"
-// @Success 201 {object} pb.Void
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {object} string "Bad Request"
 // @Failure 500 {object} string "Internal Server Error"
 // @Router /v1/notification/create [post]
@@ -30,21 +29,14 @@ func (h *Handler) CreateNotification(c *gin.Context) {
 		return
 	}
 
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("notif", input)
+	commandID, err := h.sendCommand(c, "notif", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(201, gin.H{"message":"Notification created and sent to Kafka successfully"})
+	accepted(c, commandID)
 }
 
 // UpdateNotification godoc
@@ -75,7 +67,7 @@ func (h *Handler) UpdateNotification(c *gin.Context) {
 		Body:           &body,
 	}
 
-	_, err := h.Clients.Notification.UpdateNotification(context.Background(), req)
+	_, err := h.Clients.Notification.UpdateNotification(c.Request.Context(), req)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to update notification:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
@@ -101,7 +93,7 @@ func (h *Handler) DeleteNotification(c *gin.Context) {
 	id := c.Param("id")
 	req := &pb.GetById{Id: id}
 
-	_, err := h.Clients.Notification.DeleteNotification(context.Background(), req)
+	_, err := h.Clients.Notification.DeleteNotification(c.Request.Context(), req)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to delete notification:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
@@ -144,7 +136,7 @@ func (h *Handler) ListNotifications(c *gin.Context) {
         }
     }
 
-	resp, err := h.Clients.Notification.GetNotifications(context.Background(), &filter)
+	resp, err := h.Clients.Notification.GetNotifications(c.Request.Context(), &filter)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to get notifications:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
@@ -170,7 +162,7 @@ func (h *Handler) GetNotification(c *gin.Context) {
 	id := c.Param("id")
 	req := &pb.GetById{Id: id}
 
-	resp, err := h.Clients.Notification.GetNotification(context.Background(), req)
+	resp, err := h.Clients.Notification.GetNotification(c.Request.Context(), req)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to get notification:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
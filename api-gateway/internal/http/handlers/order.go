@@ -1,12 +1,12 @@
 package handlers
 
 import (
-	"context"
+	m "flashSale_gateway/internal/http/middleware"
 	pb "flashSale_gateway/internal/pkg/genproto"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/grpc/status"
 )
 
 // CreateOrder creates a new Order
@@ -17,8 +17,15 @@ import (
 // @Produce       json
 // @Security      BearerAuth
 // @Param         Order body pb.CreateOrderReq true "Order data"
+// @Param         Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param         Queue-Token header string false "Admitted waiting room token, required while the waiting room is on"
 // @Success       200  {string}  string "Order created successfully"
 // @Failure       400  {string}  string "Invalid request"
+// @Failure       403  {string}  string "Admission token missing or expired"
+// @Failure       404  {string}  string "Flash sale product not found"
+// @Failure       409  {string}  string "Out of stock, purchase limit reached or flash sale not active"
+// @Failure       422  {string}  string "Idempotency key reused with a different request"
+// @Failure       429  {object}  waitingroom.Status "Still waiting in the queue"
 // @Failure       500  {string}  string "Internal server error"
 // @Router        /v1/order/create [post]
 func (h *Handler) CreateOrder(c *gin.Context) {
@@ -28,10 +35,18 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		c.JSON(400, gin.H{"message": "Invalid request: " + err.Error()})
 		return
 	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = c.GetHeader(m.IdempotencyHeader)
+	}
+	// keys are the caller's own, whatever the body says
+	req.CallerId = callerID(c)
+	if !h.admitted(c, req.FlashSaleID) {
+		return
+	}
 
-	_, err := h.Clients.Order.CreateOrder(context.Background(), &req)
+	_, err := h.Clients.Order.CreateOrder(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 	c.JSON(200, gin.H{"message": "Order created successfully"})
@@ -56,7 +71,7 @@ func (h *Handler) GetOrder(c *gin.Context) {
 
 	req.Id = id
 
-	res, err := h.Clients.Order.GetOrder(context.Background(), &req)
+	res, err := h.Clients.Order.GetOrder(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -67,14 +82,16 @@ func (h *Handler) GetOrder(c *gin.Context) {
 }
 
 // @Summary Update Order
-// @Description Update an existing Order by ID
+// @Description Update an existing Order by ID. Orders are canceled and refunded through their own routes.
 // @Tags Order
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Order body pb.UpdateOrderReq true "Order update data"
-// @Success 200 {string} string "message":"Order updated successfully"
-// @Failure 400 {string} string "Invalid request"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
+// @Failure 400 {string} string "Invalid request, or a cancellation or refund that belongs to its own route"
 // @Failure 500 {string} string "Internal server error"
 // @Router /v1/order/update/{id} [put]
 func (h *Handler) UpdateOrder(c *gin.Context) {
@@ -83,21 +100,28 @@ func (h *Handler) UpdateOrder(c *gin.Context) {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
-
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
+	// these release stock and return money, which a status update does not
+	switch req.GetBody().GetOrderStatus() {
+	case "canceled":
+		c.JSON(400, gin.H{"error": "use /v1/order/{id}/cancel to cancel an order"})
 		return
+	case "refunded":
+		c.JSON(400, gin.H{"error": "use /v1/refund/request to refund an order"})
+		return
+	}
+	// the key travels with the message so a redelivered update is applied once
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = c.GetHeader(m.IdempotencyHeader)
 	}
+	req.CallerId = callerID(c)
 
-	err = h.Producer.ProduceMessages("update-order", input)
+	commandID, err := h.sendCommand(c, "update-order", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
-	c.JSON(200, gin.H{"message": "Orders updated successfully"})
+	accepted(c, commandID)
 }
 
 // @Summary List Orders
@@ -142,7 +166,7 @@ func (h *Handler) ListOrders(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.Clients.Order.ListAllOrders(context.Background(), &filter)
+	resp, err := h.Clients.Order.ListAllOrders(c.Request.Context(), &filter)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -166,7 +190,7 @@ func (h *Handler) DeleteOrder(c *gin.Context) {
 	id := c.Param("id")
 
 	req := &pb.GetById{Id: id}
-	_, err := h.Clients.Order.DeleteOrder(context.Background(), req)
+	_, err := h.Clients.Order.DeleteOrder(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -212,8 +236,9 @@ func (h *Handler) GetOrderHistory(c *gin.Context) {
 			return
 		}
 	}
+	req.Pagination = filter
 
-	res, err := h.Clients.Order.GetOrderHistory(context.Background(), &req)
+	res, err := h.Clients.Order.GetOrderHistory(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -228,29 +253,46 @@ func (h *Handler) GetOrderHistory(c *gin.Context) {
 // @Produce       json
 // @Security      BearerAuth
 // @Param         id path string true "Order ID"
+// @Param         Idempotency-Key header string false "Key that makes retries of this request safe"
 // @Success       200  {object} pb.CancelOrderRes "Cancellation response"
 // @Failure       400  {string}  string "Invalid request"
 // @Failure       404  {string}  string "Order not found"
+// @Failure       409  {string}  string "Order can no longer be canceled"
+// @Failure       422  {string}  string "Idempotency key reused with a different request"
 // @Failure       500  {string}  string "Internal server error"
 // @Router        /v1/order/{id}/cancel [post]
-
 func (h *Handler) CancelOrder(c *gin.Context) {
-	var req pb.GetById
-	id := c.Param("id")
-	req.Id = id
-	if err := c.ShouldBindJSON(&req); err != nil {
-		h.Logger.ERROR.Println("Failed to bind request:", err)
-		c.JSON(400, gin.H{"message": "Invalid request: " + err.Error()})
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Order.CancelOrder(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to cancel order:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 
-	_, err := h.Clients.FlashSale.CancelFlashSale(context.Background(), &req)
+	c.JSON(200, res)
+}
+
+// @Summary       Get Order Timeline
+// @Description   Get every status an order went through
+// @Tags          Order
+// @Accept        json
+// @Produce       json
+// @Security      BearerAuth
+// @Param         id path string true "Order ID"
+// @Success       200  {object} pb.OrderTimeline "Order timeline"
+// @Failure       404  {string}  string "Order not found"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/order/{id}/timeline [get]
+func (h *Handler) GetOrderTimeline(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Order.GetOrderTimeline(c.Request.Context(), &req)
 	if err != nil {
-		h.Logger.ERROR.Println("Failed to add product to flash sale:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Flash sale cancel successfully"})
-
+	c.JSON(200, res)
 }
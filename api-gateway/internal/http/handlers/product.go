@@ -1,12 +1,10 @@
 package handlers
 
 import (
-	"context"
 	pb "flashSale_gateway/internal/pkg/genproto"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // CreateProduct creates a new Product
@@ -17,7 +15,8 @@ import (
 // @Produce       json
 // @Security      BearerAuth
 // @Param         Product body pb.CreateProductReq true "Product data"
-// @Success       200  {string}  string "Product created successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure       400  {string}  string "Invalid request"
 // @Failure       500  {string}  string "Internal server error"
 // @Router        /v1/product/create [post]
@@ -29,21 +28,14 @@ func (h *Handler) CreateProduct(c *gin.Context) {
 		return
 	}
 
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("create-product", input)
+	commandID, err := h.sendCommand(c, "create-product", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Product created successfully"})
+	accepted(c, commandID)
 
 }
 
@@ -65,7 +57,7 @@ func (h *Handler) GetProduct(c *gin.Context) {
 
 	req.Id = id
 
-	res, err := h.Clients.Product.GetProduct(context.Background(), &req)
+	res, err := h.Clients.Product.GetProduct(c.Request.Context(), &req)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -82,7 +74,8 @@ func (h *Handler) GetProduct(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param Product body pb.UpdateProductReq true "Product update data"
-// @Success 200 {string} string "message":"Product updated successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /v1/product/update/{id} [put]
@@ -93,21 +86,14 @@ func (h *Handler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	input, err := protojson.Marshal(&req)
-	if err != nil {
-		h.Logger.ERROR.Println("Failed to marshal request:", err)
-		c.JSON(500, "Internal server error: "+err.Error())
-		return
-	}
-
-	err = h.Producer.ProduceMessages("update-product", input)
+	commandID, err := h.sendCommand(c, "update-product", &req, 1)
 	if err != nil {
 		h.Logger.ERROR.Println("Failed to produce Kafka message:", err)
 		c.JSON(500, "Internal server error: "+err.Error())
 		return
 	}
 
-	c.JSON(200, gin.H{"message": "Products updated successfully"})
+	accepted(c, commandID)
 }
 
 // @Summary List Products
@@ -160,7 +146,7 @@ func (h *Handler) ListProducts(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.Clients.Product.ListAllProducts(context.Background(), &filter)
+	resp, err := h.Clients.Product.ListAllProducts(c.Request.Context(), &filter)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -184,7 +170,7 @@ func (h *Handler) DeleteProduct(c *gin.Context) {
 	id := c.Param("id")
 
 	req := &pb.GetById{Id: id}
-	_, err := h.Clients.Product.DeleteProduct(context.Background(), req)
+	_, err := h.Clients.Product.DeleteProduct(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
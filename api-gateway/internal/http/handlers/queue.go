@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"flashSale_gateway/internal/pkg/waitingroom"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueueTokenHeader carries the waiting room token of a buyer.
+const QueueTokenHeader = "Queue-Token"
+
+// @Summary       Flash Sale Queue
+// @Description   Join the waiting room of a flash sale, or check the place in line when a Queue-Token is sent. Once admitted, the same token must be sent with order creation.
+// @Tags          FlashSale
+// @Produce       json
+// @Security      BearerAuth
+// @Param         id path string true "FlashSale ID"
+// @Param         Queue-Token header string false "Token returned by an earlier call"
+// @Success       200  {object}  waitingroom.Status "Place in line"
+// @Failure       404  {string}  string "Queue token unknown or expired"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/flashSale/{id}/queue [get]
+func (h *Handler) GetFlashSaleQueue(c *gin.Context) {
+	if h.WaitingRoom == nil {
+		c.JSON(200, waitingroom.Status{Admitted: true})
+		return
+	}
+
+	var (
+		res *waitingroom.Status
+		err error
+	)
+	if token := c.GetHeader(QueueTokenHeader); token != "" {
+		res, err = h.WaitingRoom.Status(c.Request.Context(), c.Param("id"), token)
+	} else {
+		res, err = h.WaitingRoom.Join(c.Request.Context(), c.Param("id"))
+	}
+	if errors.Is(err, waitingroom.ErrUnknownToken) {
+		c.JSON(404, gin.H{"error": "Queue token unknown or expired, join the queue again"})
+		return
+	} else if err != nil {
+		h.Logger.ERROR.Println("Failed to read waiting room:", err)
+		c.JSON(500, gin.H{"error": "Internal server error: " + err.Error()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// admitted reports whether the request carries a Queue-Token that the waiting
+// room of the sale has let through. Otherwise it writes the response itself.
+func (h *Handler) admitted(c *gin.Context, flashSaleID string) bool {
+	if h.WaitingRoom == nil {
+		return true
+	}
+
+	token := c.GetHeader(QueueTokenHeader)
+	if token == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Admission token required, join GET /v1/flashSale/" + flashSaleID + "/queue first"})
+		return false
+	}
+
+	res, err := h.WaitingRoom.Status(c.Request.Context(), flashSaleID, token)
+	if errors.Is(err, waitingroom.ErrUnknownToken) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Queue token unknown or expired, join the queue again"})
+		return false
+	} else if err != nil {
+		h.Logger.ERROR.Println("Failed to read waiting room:", err)
+		c.JSON(500, gin.H{"error": "Internal server error: " + err.Error()})
+		return false
+	}
+	if !res.Admitted {
+		c.Header("Retry-After", strconv.FormatInt(res.EstimatedWaitSeconds, 10))
+		c.JSON(http.StatusTooManyRequests, res)
+		return false
+	}
+	return true
+}
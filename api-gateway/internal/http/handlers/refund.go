@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	pb "flashSale_gateway/internal/pkg/genproto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+)
+
+// RequestRefund opens a refund for an order
+// @Summary       Request Refund
+// @Description   Request a refund of what was paid for an order, amount 0 refunds everything left
+// @Tags          Refund
+// @Accept        json
+// @Produce       json
+// @Security      BearerAuth
+// @Param         Refund body pb.RefundCreateReq true "Refund data"
+// @Param         Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success       200  {object}  pb.Refund "Pending refund"
+// @Failure       400  {string}  string "Invalid request or amount over what was paid"
+// @Failure       404  {string}  string "Order not found"
+// @Failure       409  {string}  string "Nothing left to refund"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/refund/request [post]
+func (h *Handler) RequestRefund(c *gin.Context) {
+	var req pb.RefundCreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.ERROR.Println("Failed to bind request:", err)
+		c.JSON(400, gin.H{"message": "Invalid request: " + err.Error()})
+		return
+	}
+
+	res, err := h.Clients.Refund.RequestRefund(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to request refund:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Get Refund
+// @Description Get a Refund by ID
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Success 200 {object} pb.Refund "Refund"
+// @Failure 404 {string} string "Refund not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/refund/{id} [get]
+func (h *Handler) GetRefund(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Refund.GetRefund(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary List Refunds
+// @Description List Refunds with filters, for admins reviewing refunds
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Refund status (pending, approved, processed, rejected)"
+// @Param order_id query string false "Order ID"
+// @Param user_id query string false "User ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} pb.RefundListRes "List of Refunds"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 401 {string} string "Invalid or revoked token"
+// @Failure 403 {string} string "Permission denied"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/refund/list [get]
+func (h *Handler) ListRefunds(c *gin.Context) {
+	req := pb.RefundListReq{
+		RefundStatus: c.Query("status"),
+		OrderId:      c.Query("order_id"),
+		UserId:       c.Query("user_id"),
+		Filter:       &pb.Pagination{},
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if value, err := strconv.Atoi(limit); err == nil {
+			req.Filter.Limit = int32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if value, err := strconv.Atoi(offset); err == nil {
+			req.Filter.Offset = int32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	res, err := h.Clients.Refund.ListRefunds(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Approve Refund
+// @Description Approve a pending refund
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Success 200 {object} pb.Refund "Approved refund"
+// @Failure 404 {string} string "Refund not found"
+// @Failure 409 {string} string "Refund is not pending"
+// @Failure 401 {string} string "Invalid or revoked token"
+// @Failure 403 {string} string "Permission denied"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/refund/{id}/approve [post]
+func (h *Handler) ApproveRefund(c *gin.Context) {
+	req := pb.RefundReviewReq{Id: c.Param("id")}
+
+	res, err := h.Clients.Refund.ApproveRefund(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to approve refund:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Reject Refund
+// @Description Reject a pending refund with a reason
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Param Review body pb.RefundReviewReq true "Rejection reason"
+// @Success 200 {object} pb.Refund "Rejected refund"
+// @Failure 400 {string} string "Reason missing"
+// @Failure 404 {string} string "Refund not found"
+// @Failure 409 {string} string "Refund is not pending"
+// @Failure 401 {string} string "Invalid or revoked token"
+// @Failure 403 {string} string "Permission denied"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/refund/{id}/reject [post]
+func (h *Handler) RejectRefund(c *gin.Context) {
+	var req pb.RefundReviewReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	req.Id = c.Param("id")
+
+	res, err := h.Clients.Refund.RejectRefund(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to reject refund:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Process Refund
+// @Description Pay an approved refund back through the payment provider, then credit the buyer
+// @Tags Refund
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Refund ID"
+// @Success 200 {object} pb.Refund "Processed refund"
+// @Failure 404 {string} string "Refund not found"
+// @Failure 409 {string} string "Refund is not approved, or the order has no captured payment"
+// @Failure 401 {string} string "Invalid or revoked token"
+// @Failure 403 {string} string "Permission denied"
+// @Failure 500 {string} string "Internal server error"
+// @Failure 503 {string} string "Payment provider did not refund, the refund stays approved"
+// @Router /v1/refund/{id}/process [post]
+func (h *Handler) ProcessRefund(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Refund.ProcessRefund(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to process refund:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	pb "flashSale_gateway/internal/pkg/genproto"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+)
+
+// CreateReservation holds flash sale stock for a pending order
+// @Summary       Create Reservation
+// @Description   Hold flash sale stock for a pending order until the reservation expires
+// @Tags          Reservation
+// @Accept        json
+// @Produce       json
+// @Security      BearerAuth
+// @Param         Reservation body pb.CreateReservationReq true "Reservation data"
+// @Param         Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param         Queue-Token header string false "Admitted waiting room token, required while the waiting room is on"
+// @Success       200  {object}  pb.Reservation "Reservation"
+// @Failure       400  {string}  string "Invalid request"
+// @Failure       403  {string}  string "Admission token missing or expired"
+// @Failure       404  {string}  string "Flash sale product not found"
+// @Failure       409  {string}  string "Out of stock, purchase limit reached or flash sale not active"
+// @Failure       429  {object}  waitingroom.Status "Still waiting in the queue"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/reservation/create [post]
+func (h *Handler) CreateReservation(c *gin.Context) {
+	var req pb.CreateReservationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.ERROR.Println("Failed to bind request:", err)
+		c.JSON(400, gin.H{"message": "Invalid request: " + err.Error()})
+		return
+	}
+	if !h.admitted(c, req.FlashSaleId) {
+		return
+	}
+
+	res, err := h.Clients.Reservation.CreateReservation(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to create reservation:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Get Reservation
+// @Description Get a Reservation by ID
+// @Tags Reservation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} pb.Reservation "Reservation"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/reservation/{id} [get]
+func (h *Handler) GetReservation(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Reservation.GetReservation(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Confirm Reservation
+// @Description Confirm the order of a reservation that has not expired without taking a payment. For admins only, buyers go through checkout.
+// @Tags Reservation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} pb.Reservation "Confirmed reservation"
+// @Failure 403 {string} string "Forbidden"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation expired or already released"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/reservation/{id}/confirm [post]
+func (h *Handler) ConfirmReservation(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Reservation.ConfirmReservation(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to confirm reservation:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Release Reservation
+// @Description Cancel the order of a reservation and return its units to the sale
+// @Tags Reservation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reservation ID"
+// @Success 200 {object} pb.Reservation "Released reservation"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Reservation already confirmed or expired"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/reservation/{id}/release [post]
+func (h *Handler) ReleaseReservation(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Reservation.ReleaseReservation(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to release reservation:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Checkout Reservation
+// @Description Pay for a held reservation and confirm its order, a failed checkout releases the reservation
+// @Tags Reservation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Reservation ID"
+// @Param Checkout body pb.CheckoutReq true "Payment method token"
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Success 200 {object} pb.CheckoutRes "Confirmed reservation and its payment"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Reservation not found"
+// @Failure 409 {string} string "Payment declined, or reservation no longer held"
+// @Failure 422 {string} string "Idempotency key reused with a different request"
+// @Failure 503 {string} string "Payment provider unavailable"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/reservation/{id}/checkout [post]
+func (h *Handler) Checkout(c *gin.Context) {
+	var req pb.CheckoutReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	req.ReservationId = c.Param("id")
+
+	res, err := h.Clients.Reservation.Checkout(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to checkout reservation:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
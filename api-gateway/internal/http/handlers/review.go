@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	pb "flashSale_gateway/internal/pkg/genproto"
 
 	"github.com/gin-gonic/gin"
@@ -27,7 +26,7 @@ func (h *Handler) CreateReview(c *gin.Context) {
 		return
 	}
 
-	_, err := h.Clients.Review.CreateReview(context.Background(), req)
+	_, err := h.Clients.Review.CreateReview(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -54,7 +53,7 @@ func (h *Handler) GetProductRating(c *gin.Context) {
 	req := &pb.GetProductRatingReq{}
 	id := c.Param("productId")
 	req.ProductId = id
-	res, err := h.Clients.Review.GetProductRating(context.Background(), req)
+	res, err := h.Clients.Review.GetProductRating(c.Request.Context(), req)
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"context"
 	pb "flashSale_gateway/internal/pkg/genproto"
 
 	"github.com/gin-gonic/gin"
@@ -27,7 +26,7 @@ func (h *Handler) ShareDeal(c *gin.Context) {
 		return
 	}
 
-	_, err := h.Clients.Social.ShareDeal(context.Background(), &req) // Pass a pointer to req
+	_, err := h.Clients.Social.ShareDeal(c.Request.Context(), &req) // Pass a pointer to req
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -57,7 +56,7 @@ func (h *Handler) GetSharingStats(c *gin.Context) {
 		return
 	}
 
-	res, err := h.Clients.Social.GetSharingStats(context.Background(), &req) // Pass a pointer to req
+	res, err := h.Clients.Social.GetSharingStats(c.Request.Context(), &req) // Pass a pointer to req
 	if err != nil {
 		c.JSON(500, gin.H{"error": err.Error()})
 		return
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	pb "flashSale_gateway/internal/pkg/genproto"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/status"
+)
+
+// CreateTransaction records a debit or credit for a user
+// @Summary       Create Transaction
+// @Description   Record a debit or credit for a user
+// @Tags          Transaction
+// @Accept        json
+// @Produce       json
+// @Security      BearerAuth
+// @Param         Transaction body pb.TransactionCreateReq true "Transaction data"
+// @Success       200  {string}  string "Transaction created successfully"
+// @Failure       400  {string}  string "Invalid request"
+// @Failure       500  {string}  string "Internal server error"
+// @Router        /v1/transaction/create [post]
+func (h *Handler) CreateTransaction(c *gin.Context) {
+	var req pb.TransactionCreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.Logger.ERROR.Println("Failed to bind request:", err)
+		c.JSON(400, gin.H{"message": "Invalid request: " + err.Error()})
+		return
+	}
+
+	_, err := h.Clients.Transaction.CreateTransaction(c.Request.Context(), &req)
+	if err != nil {
+		h.Logger.ERROR.Println("Failed to create transaction:", err)
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Transaction created successfully"})
+}
+
+// @Summary Get Transaction
+// @Description Get a Transaction by ID
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Transaction ID"
+// @Success 200 {object} pb.TransactionGetRes "Transaction data"
+// @Failure 404 {string} string "Transaction not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/transaction/{id} [get]
+func (h *Handler) GetTransaction(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("id")}
+
+	res, err := h.Clients.Transaction.GetTransaction(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary List Transactions
+// @Description List Transactions with filters
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "User ID"
+// @Param product_id query string false "Product ID"
+// @Param flash_sale_id query string false "Flash Sale Product ID"
+// @Param type query string false "debit or credit"
+// @Param amount_from query number false "Amount from"
+// @Param amount_to query number false "Amount to"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} pb.TransactionListRes "List of Transactions"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/transaction/list [get]
+func (h *Handler) ListTransactions(c *gin.Context) {
+	filter := pb.TransactionListReq{
+		UserId:      c.Query("user_id"),
+		ProductId:   c.Query("product_id"),
+		FlashSaleId: c.Query("flash_sale_id"),
+		Type:        c.Query("type"),
+		Filter:      &pb.Pagination{},
+	}
+
+	if amountFrom := c.Query("amount_from"); amountFrom != "" {
+		if value, err := strconv.ParseFloat(amountFrom, 32); err == nil {
+			filter.AmountFrom = float32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if amountTo := c.Query("amount_to"); amountTo != "" {
+		if value, err := strconv.ParseFloat(amountTo, 32); err == nil {
+			filter.AmountTo = float32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		if value, err := strconv.Atoi(limit); err == nil {
+			filter.Filter.Limit = int32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		if value, err := strconv.Atoi(offset); err == nil {
+			filter.Filter.Offset = int32(value)
+		} else {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	res, err := h.Clients.Transaction.ListTransactions(c.Request.Context(), &filter)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
+
+// @Summary Get Balance
+// @Description Get a user's balance computed from debits and credits
+// @Tags Transaction
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} pb.BalanceGetRes "Balance"
+// @Failure 500 {string} string "Internal server error"
+// @Router /v1/transaction/balance/{user_id} [get]
+func (h *Handler) GetBalance(c *gin.Context) {
+	req := pb.GetById{Id: c.Param("user_id")}
+
+	res, err := h.Clients.Transaction.GetBalance(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(httpStatus(err), gin.H{"error": status.Convert(err).Message()})
+		return
+	}
+
+	c.JSON(200, res)
+}
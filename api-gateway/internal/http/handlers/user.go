@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -48,7 +47,8 @@ func (h *Handler) GetProfile(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param profile body auth.EditProfileReqBpdy true "Updated profile details"
-// @Success 200 {object} string "Profile updated successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {object} string "Bad Request"
 // @Failure 500 {object} string "Internal Server Error"
 // @Router /v1/user/profiles [put]
@@ -70,21 +70,14 @@ func (h *Handler) EditProfile(c *gin.Context) {
 		DateOfBirth: body.DateOfBirth,
 	}
 
-	input, err := json.Marshal(req)
-	if err != nil {
-		slog.Error("Error marshaling JSON:", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err})
-		return
-	}
-
-	err = h.Producer.ProduceMessages("update", input)
+	commandID, err := h.sendCommand(c, "update", req, 1)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err})
 		return
 	}
 
-	slog.Info("Updated profile")
-	c.JSON(http.StatusOK, "Profile updated successfully")
+	slog.Info("Profile update accepted", "command_id", commandID)
+	accepted(c, commandID)
 }
 
 // ChangePassword godoc
@@ -124,14 +117,7 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 		NewPassword:     body.NewPassword,
 	}
 
-	input, err := json.Marshal(req)
-	if err != nil {
-		slog.Error("Error marshaling JSON:", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "internal server error"})
-		return
-	}
-
-	err = h.Producer.ProduceMessages("upd-pass", input)
+	err = h.Producer.ProduceMessages("upd-pass", req, 1)
 	if err != nil {
 		slog.Error("Error producing message:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -179,7 +165,8 @@ func (h *Handler) GetSetting(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param setting body auth.Setting true "Updated setting details"
-// @Success 200 {object} string "Setting updated successfully"
+// @Param Reply-To header string false "Kafka topic to publish the outcome to, must start with replies."
+// @Success 202 {object} map[string]string "command_id to look up at /v1/commands/{id}"
 // @Failure 400 {object} string "Bad Request"
 // @Failure 500 {object} string "Internal Server Error"
 // @Router /v1/user/setting [put]
@@ -201,22 +188,15 @@ func (h *Handler) EditSetting(c *gin.Context) {
 		Theme:        body.Theme,
 	}
 
-	input, err := json.Marshal(req)
-	if err != nil {
-		slog.Error("Error marshaling JSON:", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "internal server error"})
-		return
-	}
-
-	err = h.Producer.ProduceMessages("edit", input)
+	commandID, err := h.sendCommand(c, "edit", req, 1)
 	if err != nil {
 		slog.Error("Error producing message:", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
-	slog.Info("Updated setting")
-	c.JSON(http.StatusOK, gin.H{"message": "Setting updated successfully"})
+	slog.Info("Setting update accepted", "command_id", commandID)
+	accepted(c, commandID)
 }
 
 // DeleteUser godoc
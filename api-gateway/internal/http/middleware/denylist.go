@@ -0,0 +1,91 @@
+package middlerware
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	tokens "flashSale_gateway/internal/pkg/token"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+)
+
+// Denylist keeps access tokens that were revoked before they expired in
+// Redis, for JWTMiddleware to refuse. Single tokens are listed by jti. A user
+// logged out of all sessions gets a cutoff instead: tokens of the user issued
+// up to then are refused. Either entry expires with the last token it covers.
+type Denylist struct {
+	rdb *redis.Client
+}
+
+func NewDenylist(rdb *redis.Client) *Denylist {
+	return &Denylist{rdb: rdb}
+}
+
+// RevokeToken lists the access token the claims came from.
+func (d *Denylist) RevokeToken(ctx context.Context, claims jwt.MapClaims) error {
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		// issued before tokens had an id, only RevokeUser covers it
+		return nil
+	}
+
+	ttl := tokens.AccessTokenTTL
+	if exp, ok := claims["exp"].(float64); ok {
+		ttl = time.Until(time.Unix(int64(exp), 0))
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	return d.rdb.Set(ctx, "revoked-token:"+jti, 1, ttl).Err()
+}
+
+// RevokeUser refuses every token issued to the user so far. The cutoff is kept
+// in milliseconds, so a login right after it is let through, and for as long as
+// a refresh token lives, the longest any token it covers stays valid.
+func (d *Denylist) RevokeUser(ctx context.Context, userID string) error {
+	return d.rdb.Set(ctx, "revoked-user:"+userID, time.Now().UnixMilli(), tokens.RefreshTokenTTL).Err()
+}
+
+// Revoked reports whether the access token the claims came from was revoked.
+func (d *Denylist) Revoked(ctx context.Context, claims jwt.MapClaims) (bool, error) {
+	if jti, _ := claims["jti"].(string); jti != "" {
+		n, err := d.rdb.Exists(ctx, "revoked-token:"+jti).Result()
+		if err != nil {
+			return false, err
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	userID, _ := claims["user_id"].(string)
+	cutoff, err := d.rdb.Get(ctx, "revoked-user:"+userID).Result()
+	if err == redis.Nil {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	revokedAt, err := strconv.ParseInt(cutoff, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	if revokedAt < secondCutoffs {
+		revokedAt *= 1000
+	}
+	return issuedAt(claims) <= revokedAt, nil
+}
+
+// secondCutoffs bounds the cutoffs written in seconds, before they were kept
+// in milliseconds. In milliseconds it lies in 1973.
+const secondCutoffs = 100_000_000_000
+
+// issuedAt is when the token was issued in milliseconds. Tokens issued before
+// they carried iat_ms count from the start of their second.
+func issuedAt(claims jwt.MapClaims) int64 {
+	if iat, ok := claims["iat_ms"].(float64); ok {
+		return int64(iat)
+	}
+	iat, _ := claims["iat"].(float64)
+	return int64(iat) * 1000
+}
@@ -0,0 +1,209 @@
+package middlerware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	m "flashSale_gateway/internal/http/middleware"
+	pb "flashSale_gateway/internal/pkg/genproto"
+	tokens "flashSale_gateway/internal/pkg/token"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+)
+
+func newDenylist(t *testing.T) (*m.Denylist, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return m.NewDenylist(rdb), mr
+}
+
+func claimsOf(t *testing.T, token string) jwt.MapClaims {
+	claims, err := tokens.ExtractClaim(token)
+	if err != nil {
+		t.Fatalf("error was not expected while reading the token: %s", err)
+	}
+	return claims
+}
+
+func revoked(t *testing.T, d *m.Denylist, claims jwt.MapClaims) bool {
+	revoked, err := d.Revoked(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("error was not expected while checking the denylist: %s", err)
+	}
+	return revoked
+}
+
+func TestRevokedToken(t *testing.T) {
+	d, mr := newDenylist(t)
+	user := &pb.User{Id: "user-1", Role: "user"}
+	loggedOut := claimsOf(t, tokens.GenerateAccessToken(user))
+	other := claimsOf(t, tokens.GenerateAccessToken(user))
+
+	if err := d.RevokeToken(context.Background(), loggedOut); err != nil {
+		t.Fatalf("error was not expected while revoking the token: %s", err)
+	}
+	if !revoked(t, d, loggedOut) {
+		t.Errorf("expected the revoked token to be refused")
+	}
+	if revoked(t, d, other) {
+		t.Errorf("expected another token of the user to be let through")
+	}
+
+	// the entry goes with the token it lists
+	ttl := mr.TTL("revoked-token:" + loggedOut["jti"].(string))
+	if ttl <= tokens.AccessTokenTTL-time.Minute || ttl > tokens.AccessTokenTTL {
+		t.Errorf("expected the entry to expire with the token, got %s", ttl)
+	}
+	mr.FastForward(tokens.AccessTokenTTL)
+	if revoked(t, d, loggedOut) {
+		t.Errorf("expected the entry to be gone once the token expired")
+	}
+}
+
+func TestRevokeExpiredToken(t *testing.T) {
+	d, mr := newDenylist(t)
+	claims := jwt.MapClaims{"jti": "jti-1", "user_id": "user-1", "exp": float64(time.Now().Add(-time.Minute).Unix())}
+
+	if err := d.RevokeToken(context.Background(), claims); err != nil {
+		t.Fatalf("error was not expected while revoking the token: %s", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("expected nothing listed for an expired token, got %v", keys)
+	}
+}
+
+func TestRevokedUser(t *testing.T) {
+	d, _ := newDenylist(t)
+	user := &pb.User{Id: "user-1", Role: "user"}
+	before := claimsOf(t, tokens.GenerateAccessToken(user))
+	legacy := jwt.MapClaims{"user_id": "user-1", "iat": float64(time.Now().Unix())}
+
+	if err := d.RevokeUser(context.Background(), "user-1"); err != nil {
+		t.Fatalf("error was not expected while revoking the user: %s", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	// logging in again in the same second
+	after := claimsOf(t, tokens.GenerateAccessToken(user))
+
+	if !revoked(t, d, before) {
+		t.Errorf("expected a token issued before the cutoff to be refused")
+	}
+	if !revoked(t, d, legacy) {
+		t.Errorf("expected a token without iat_ms from the second of the cutoff to be refused")
+	}
+	if revoked(t, d, after) {
+		t.Errorf("expected a token issued after the cutoff to be let through")
+	}
+	if revoked(t, d, jwt.MapClaims{"user_id": "user-2", "iat_ms": before["iat_ms"]}) {
+		t.Errorf("expected the tokens of another user to be let through")
+	}
+}
+
+func TestRevokedUserOutlivesAccessTokens(t *testing.T) {
+	d, mr := newDenylist(t)
+	if err := d.RevokeUser(context.Background(), "user-1"); err != nil {
+		t.Fatalf("error was not expected while revoking the user: %s", err)
+	}
+
+	// the cutoff stays as long as the refresh tokens it covers
+	if ttl := mr.TTL("revoked-user:user-1"); ttl != tokens.RefreshTokenTTL {
+		t.Errorf("expected the cutoff to live %s, got %s", tokens.RefreshTokenTTL, ttl)
+	}
+	mr.FastForward(tokens.AccessTokenTTL)
+	if !revoked(t, d, jwt.MapClaims{"user_id": "user-1", "iat_ms": float64(time.Now().Add(-time.Hour).UnixMilli())}) {
+		t.Errorf("expected the cutoff to outlive the access tokens")
+	}
+}
+
+func TestRevokedUserBySecondCutoff(t *testing.T) {
+	d, mr := newDenylist(t)
+	now := time.Now()
+	// written before cutoffs were kept in milliseconds
+	mr.Set("revoked-user:user-1", strconv.FormatInt(now.Unix(), 10))
+
+	if !revoked(t, d, jwt.MapClaims{"user_id": "user-1", "iat_ms": float64(now.Add(-time.Second).UnixMilli())}) {
+		t.Errorf("expected a token issued before the cutoff to be refused")
+	}
+	if revoked(t, d, jwt.MapClaims{"user_id": "user-1", "iat_ms": float64(now.Add(time.Second).UnixMilli())}) {
+		t.Errorf("expected a token issued after the cutoff to be let through")
+	}
+}
+
+func TestJWTMiddlewareRefusesRevokedTokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	d, mr := newDenylist(t)
+	router := gin.New()
+	router.GET("/", m.JWTMiddleware(d), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	token := tokens.GenerateAccessToken(&pb.User{Id: "user-1", Role: "user"})
+	if code := get(token); code != http.StatusOK {
+		t.Errorf("expected a valid token to be let through, got %d", code)
+	}
+	if code := get(""); code != http.StatusUnauthorized {
+		t.Errorf("expected a request without a token to be refused, got %d", code)
+	}
+	if code := get(tokens.GenerateRefreshToken("user-1")); code != http.StatusUnauthorized {
+		t.Errorf("expected a refresh token to be refused, got %d", code)
+	}
+
+	if err := d.RevokeToken(context.Background(), claimsOf(t, token)); err != nil {
+		t.Fatalf("error was not expected while revoking the token: %s", err)
+	}
+	if code := get(token); code != http.StatusUnauthorized {
+		t.Errorf("expected a revoked token to be refused, got %d", code)
+	}
+
+	mr.Close()
+	if code := get(tokens.GenerateAccessToken(&pb.User{Id: "user-1", Role: "user"})); code != http.StatusInternalServerError {
+		t.Errorf("expected an error when the denylist is down, got %d", code)
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		claims interface{}
+		want   int
+	}{
+		{"admin", jwt.MapClaims{"user_id": "user-1", "role": "admin"}, http.StatusOK},
+		{"other role", jwt.MapClaims{"user_id": "user-1", "role": "user"}, http.StatusForbidden},
+		{"no role", jwt.MapClaims{"user_id": "user-1"}, http.StatusForbidden},
+		{"no claims", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if tt.claims != nil {
+					c.Set("claims", tt.claims)
+				}
+			}, m.RequireRole("admin"), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
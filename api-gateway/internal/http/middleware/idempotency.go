@@ -0,0 +1,147 @@
+package middlerware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+)
+
+const IdempotencyHeader = "Idempotency-Key"
+
+// idempotencyLockTTL is how long a request holds its key while it is handled,
+// longer than the slowest call to flash_service takes. A gateway that dies
+// meanwhile frees the key for a retry once it runs out.
+const idempotencyLockTTL = time.Minute
+
+// idempotencyRecord is what is kept in Redis for every Idempotency-Key. Done is
+// false while the first request is still being handled.
+type idempotencyRecord struct {
+	Hash   string `json:"hash"`
+	Done   bool   `json:"done"`
+	Status int    `json:"status,omitempty"`
+	Body   []byte `json:"body,omitempty"`
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays the stored response when a request is retried with the same
+// Idempotency-Key header and rejects the key when it comes back with a different
+// request. Keys are scoped to the user and the route, and responses are kept for
+// ttl. Requests without the header pass through untouched. It goes after
+// JWTMiddleware.
+func Idempotency(rdb *redis.Client, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(append([]byte(c.Request.Method+" "+c.Request.URL.Path+"\n"), body...))
+		record := idempotencyRecord{Hash: hex.EncodeToString(sum[:])}
+		redisKey := idempotencyKey(c, key)
+
+		data, _ := json.Marshal(record)
+		ok, err := rdb.SetNX(c.Request.Context(), redisKey, data, idempotencyLockTTL).Result()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error()})
+			return
+		}
+		if !ok {
+			replay(c, rdb, redisKey, record.Hash)
+			return
+		}
+
+		w := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+
+		// server errors and refusals to let the request in yet are not final,
+		// the client may retry them with the same key
+		if w.Status() >= http.StatusInternalServerError || w.Status() == http.StatusForbidden || w.Status() == http.StatusTooManyRequests {
+			if err := rdb.Del(c.Request.Context(), redisKey).Err(); err != nil {
+				log.Printf("Error while releasing Idempotency-Key %s, it is free again in %s: %v", key, idempotencyLockTTL, err)
+			}
+			return
+		}
+
+		record.Done = true
+		record.Status = w.Status()
+		record.Body = w.body.Bytes()
+		data, _ = json.Marshal(record)
+		if err := rdb.Set(c.Request.Context(), redisKey, data, ttl).Err(); err != nil {
+			// the response is sent, a retry after the lock ran out runs again
+			log.Printf("Error while storing the response for Idempotency-Key %s: %v", key, err)
+		}
+	}
+}
+
+// idempotencyKey is where the record of key is kept for the user of the
+// request and the route it was sent to, so that neither another user nor
+// another endpoint can run into it.
+func idempotencyKey(c *gin.Context, key string) string {
+	var userID string
+	if claims, ok := c.Get("claims"); ok {
+		if mc, ok := claims.(jwt.MapClaims); ok {
+			userID, _ = mc["user_id"].(string)
+		}
+	}
+	return "idempotency:" + userID + ":" + c.Request.Method + " " + c.FullPath() + ":" + key
+}
+
+func replay(c *gin.Context, rdb *redis.Client, redisKey, hash string) {
+	data, err := rdb.Get(c.Request.Context(), redisKey).Bytes()
+	if err == redis.Nil {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is in progress"})
+		return
+	} else if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error()})
+		return
+	}
+
+	var stored idempotencyRecord
+	if err := json.Unmarshal(data, &stored); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error()})
+		return
+	}
+
+	switch {
+	case stored.Hash != hash:
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key was already used with a different request"})
+	case !stored.Done:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "A request with this Idempotency-Key is in progress"})
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(stored.Status, "application/json; charset=utf-8", stored.Body)
+		c.Abort()
+	}
+}
@@ -0,0 +1,134 @@
+package middlerware_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	m "flashSale_gateway/internal/http/middleware"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+)
+
+// idempotentServer counts the requests its handlers actually run and notes
+// how long the key is held while they do. A request whose body is "fail"
+// fails with 500.
+type idempotentServer struct {
+	router  *gin.Engine
+	mr      *miniredis.Miniredis
+	runs    int
+	lockTTL time.Duration
+}
+
+func newIdempotentServer(t *testing.T) *idempotentServer {
+	gin.SetMode(gin.TestMode)
+	s := &idempotentServer{mr: miniredis.RunT(t), router: gin.New()}
+	rdb := redis.NewClient(&redis.Options{Addr: s.mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+
+	// stands in for JWTMiddleware
+	auth := func(c *gin.Context) {
+		c.Set("claims", jwt.MapClaims{"user_id": c.GetHeader("X-User")})
+	}
+	handler := func(c *gin.Context) {
+		s.runs++
+		if keys := s.mr.Keys(); len(keys) == 1 {
+			s.lockTTL = s.mr.TTL(keys[0])
+		}
+		body, _ := c.GetRawData()
+		if string(body) == "fail" {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "flash_service is down"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"run": s.runs})
+	}
+	idempotent := m.Idempotency(rdb, 24*time.Hour)
+	s.router.POST("/orders", auth, idempotent, handler)
+	s.router.POST("/refunds", auth, idempotent, handler)
+	return s
+}
+
+func (s *idempotentServer) post(path, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("X-User", user)
+	req.Header.Set(m.IdempotencyHeader, key)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplays(t *testing.T) {
+	s := newIdempotentServer(t)
+
+	first := s.post("/orders", "user-1", "key-1", "order")
+	retry := s.post("/orders", "user-1", "key-1", "order")
+	if s.runs != 1 || retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("expected the retry to replay %d %s, got %d %s after %d runs", first.Code, first.Body, retry.Code, retry.Body, s.runs)
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the replay to be marked")
+	}
+
+	if w := s.post("/orders", "user-1", "key-1", "another order"); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected the key to be refused for another request, got %d", w.Code)
+	}
+}
+
+func TestIdempotencyScopesKeys(t *testing.T) {
+	s := newIdempotentServer(t)
+
+	s.post("/orders", "user-1", "key-1", "order")
+	if w := s.post("/orders", "user-2", "key-1", "order"); w.Code != http.StatusCreated || s.runs != 2 {
+		t.Errorf("expected the key of another user to run, got %d after %d runs", w.Code, s.runs)
+	}
+	if w := s.post("/refunds", "user-1", "key-1", "order"); w.Code != http.StatusCreated || s.runs != 3 {
+		t.Errorf("expected the key on another route to run, got %d after %d runs", w.Code, s.runs)
+	}
+}
+
+func TestIdempotencyReleasesFailures(t *testing.T) {
+	s := newIdempotentServer(t)
+
+	if w := s.post("/orders", "user-1", "key-1", "fail"); w.Code != http.StatusInternalServerError {
+		t.Fatalf("expected the request to fail, got %d", w.Code)
+	}
+	if keys := s.mr.Keys(); len(keys) != 0 {
+		t.Errorf("expected the key to be released, got %v", keys)
+	}
+	if s.post("/orders", "user-1", "key-1", "fail"); s.runs != 2 {
+		t.Errorf("expected the retry of a failure to run again, got %d runs", s.runs)
+	}
+}
+
+func TestIdempotencyLockExpires(t *testing.T) {
+	s := newIdempotentServer(t)
+	key := "idempotency:user-1:POST /orders:key-1"
+
+	if s.post("/orders", "user-1", "key-1", "order"); s.lockTTL != time.Minute {
+		t.Errorf("expected the key to be held for a minute while the request runs, got %s", s.lockTTL)
+	}
+	// a finished request keeps its response for the full ttl
+	if ttl := s.mr.TTL(key); ttl != 24*time.Hour {
+		t.Errorf("expected the response to be kept for 24h, got %s", ttl)
+	}
+
+	// a request in progress on a gateway that went down
+	sum := sha256.Sum256([]byte("POST /orders\norder"))
+	s.mr.Set(key, `{"hash":"`+hex.EncodeToString(sum[:])+`"}`)
+	s.mr.SetTTL(key, time.Minute)
+	if w := s.post("/orders", "user-1", "key-1", "order"); w.Code != http.StatusConflict {
+		t.Errorf("expected the key to be held, got %d", w.Code)
+	}
+
+	s.mr.FastForward(time.Minute)
+	if w := s.post("/orders", "user-1", "key-1", "order"); w.Code != http.StatusCreated || s.runs != 2 {
+		t.Errorf("expected the request to run once the lock ran out, got %d after %d runs", w.Code, s.runs)
+	}
+}
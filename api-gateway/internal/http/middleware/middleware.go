@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	tokens "flashSale_gateway/internal/pkg/token"
 	t "github.com/Mubinabd/project_control/api/token"
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
@@ -24,7 +25,10 @@ func Middleware() gin.HandlerFunc {
 	}
 }
 
-func JWTMiddleware() gin.HandlerFunc {
+// JWTMiddleware lets requests with a valid access token through that the
+// denylist does not refuse, and leaves the token's claims under "claims".
+// Refresh tokens, and tokens issued before tokens had a type, are refused.
+func JWTMiddleware(denylist *Denylist) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -35,10 +39,14 @@ func JWTMiddleware() gin.HandlerFunc {
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 		valid, err := t.ValidateToken(tokenString)
-		if err != nil || !valid {
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token", "details": err.Error()})
 			c.Abort()
 			return
+		} else if !valid {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Abort()
+			return
 		}
 
 		claims, err := t.ExtractClaim(tokenString)
@@ -47,12 +55,39 @@ func JWTMiddleware() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		// refresh tokens live longer and are only good at /auth/refresh
+		if claims["typ"] != tokens.TypeAccess {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Not an access token"})
+			return
+		}
+
+		revoked, err := denylist.Revoked(c.Request.Context(), claims)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error()})
+			return
+		} else if revoked {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token revoked"})
+			return
+		}
 
 		c.Set("claims", claims)
 		c.Next()
 	}
 }
 
+// RequireRole lets only users with the given role through. It goes after
+// JWTMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, _ := c.Get("claims")
+		if mc, ok := claims.(jwt.MapClaims); !ok || mc["role"] != role {
+			RequirePermission(c)
+			return
+		}
+		c.Next()
+	}
+}
+
 func GetUserId(r *http.Request) (string, error) {
 	jwtToken := r.Header.Get("Authorization")
 
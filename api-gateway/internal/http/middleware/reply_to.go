@@ -0,0 +1,32 @@
+package middlerware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReplyToHeader names a kafka topic the outcome of a command is published to.
+const ReplyToHeader = "Reply-To"
+
+// ReplyTopicPrefix is the prefix every reply topic must carry, so a client
+// can not have flash_service publish to the topics it consumes itself.
+const ReplyTopicPrefix = "replies."
+
+// replyTopic is a reply topic kafka accepts: legal characters only and at
+// most 249 of them.
+var replyTopic = regexp.MustCompile(`^replies\.[A-Za-z0-9._-]{1,241}$`)
+
+// ReplyTo rejects requests whose Reply-To header is not a reply topic.
+// Requests without the header pass through untouched.
+func ReplyTo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		topic := c.GetHeader(ReplyToHeader)
+		if topic != "" && !replyTopic.MatchString(topic) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Reply-To must be a topic starting with " + ReplyTopicPrefix})
+			return
+		}
+		c.Next()
+	}
+}
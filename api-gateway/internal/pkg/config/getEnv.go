@@ -2,7 +2,10 @@ package config
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -23,6 +26,19 @@ type Config struct {
 
 	DefaultOffset string
 	DefaultLimit  string
+
+	WaitingRoomEnabled  bool
+	WaitingRoomRate     float64
+	WaitingRoomTokenTTL time.Duration
+
+	// RPCTimeout bounds a call to the flash sale service that has no deadline
+	// of its own; RPCTimeouts overrides it per method, keyed like
+	// "ReservationService/Checkout".
+	RPCTimeout  time.Duration
+	RPCTimeouts map[string]time.Duration
+
+	// ShutdownTimeout bounds draining requests on SIGTERM.
+	ShutdownTimeout time.Duration
 }
 
 func Load() Config {
@@ -46,6 +62,19 @@ func Load() Config {
 	config.DefaultOffset = cast.ToString(getOrReturnDefaultValue("DEFAULT_OFFSET", "0"))
 	config.DefaultLimit = cast.ToString(getOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
 
+	config.WaitingRoomEnabled = cast.ToBool(getOrReturnDefaultValue("WAITING_ROOM_ENABLED", false))
+	config.WaitingRoomRate = cast.ToFloat64(getOrReturnDefaultValue("WAITING_ROOM_RATE", 50))
+	config.WaitingRoomTokenTTL = cast.ToDuration(getOrReturnDefaultValue("WAITING_ROOM_TOKEN_TTL", "30m"))
+	if config.WaitingRoomEnabled && config.WaitingRoomRate <= 0 {
+		// a room that admits nobody would hold every buyer until the token expires
+		log.Fatalf("WAITING_ROOM_RATE must be above 0, got %v", config.WaitingRoomRate)
+	}
+
+	config.RPCTimeout = cast.ToDuration(getOrReturnDefaultValue("RPC_TIMEOUT", "5s"))
+	config.RPCTimeouts = parseTimeouts(cast.ToString(getOrReturnDefaultValue("RPC_TIMEOUTS", "ReservationService/Checkout=30s,FlashSaleService/CancelFlashSale=30s")))
+
+	config.ShutdownTimeout = cast.ToDuration(getOrReturnDefaultValue("SHUTDOWN_TIMEOUT", "30s"))
+
 	return config
 }
 
@@ -58,3 +87,21 @@ func getOrReturnDefaultValue(key string, defaultValue interface{}) interface{} {
 
 	return defaultValue
 }
+
+// parseTimeouts reads a comma separated list of method=duration pairs.
+func parseTimeouts(s string) map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(pair, "=")
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if !ok || err != nil {
+			fmt.Printf("Ignoring RPC timeout %q\n", pair)
+			continue
+		}
+		timeouts[strings.TrimSpace(method)] = timeout
+	}
+	return timeouts
+}
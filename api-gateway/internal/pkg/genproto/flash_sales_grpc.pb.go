@@ -28,6 +28,7 @@ const (
 	FlashSaleService_RemoveProductFromFlashSale_FullMethodName = "/proto.FlashSaleService/RemoveProductFromFlashSale"
 	FlashSaleService_CancelFlashSale_FullMethodName            = "/proto.FlashSaleService/CancelFlashSale"
 	FlashSaleService_GetStoreLocation_FullMethodName           = "/proto.FlashSaleService/GetStoreLocation"
+	FlashSaleService_WatchFlashSale_FullMethodName             = "/proto.FlashSaleService/WatchFlashSale"
 )
 
 // FlashSaleServiceClient is the client API for FlashSaleService service.
@@ -43,6 +44,9 @@ type FlashSaleServiceClient interface {
 	RemoveProductFromFlashSale(ctx context.Context, in *RemoveProductReq, opts ...grpc.CallOption) (*Void, error)
 	CancelFlashSale(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*CancelFlashSaleRes, error)
 	GetStoreLocation(ctx context.Context, in *GetStoreLocationReq, opts ...grpc.CallOption) (*StoreLocation, error)
+	// WatchFlashSale sends the current status and stock of a sale, then every
+	// change to them until the sale ends or the client goes away.
+	WatchFlashSale(ctx context.Context, in *GetById, opts ...grpc.CallOption) (FlashSaleService_WatchFlashSaleClient, error)
 }
 
 type flashSaleServiceClient struct {
@@ -143,6 +147,39 @@ func (c *flashSaleServiceClient) GetStoreLocation(ctx context.Context, in *GetSt
 	return out, nil
 }
 
+func (c *flashSaleServiceClient) WatchFlashSale(ctx context.Context, in *GetById, opts ...grpc.CallOption) (FlashSaleService_WatchFlashSaleClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FlashSaleService_ServiceDesc.Streams[0], FlashSaleService_WatchFlashSale_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &flashSaleServiceWatchFlashSaleClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FlashSaleService_WatchFlashSaleClient interface {
+	Recv() (*FlashSaleUpdate, error)
+	grpc.ClientStream
+}
+
+type flashSaleServiceWatchFlashSaleClient struct {
+	grpc.ClientStream
+}
+
+func (x *flashSaleServiceWatchFlashSaleClient) Recv() (*FlashSaleUpdate, error) {
+	m := new(FlashSaleUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // FlashSaleServiceServer is the server API for FlashSaleService service.
 // All implementations must embed UnimplementedFlashSaleServiceServer
 // for forward compatibility
@@ -156,6 +193,9 @@ type FlashSaleServiceServer interface {
 	RemoveProductFromFlashSale(context.Context, *RemoveProductReq) (*Void, error)
 	CancelFlashSale(context.Context, *GetById) (*CancelFlashSaleRes, error)
 	GetStoreLocation(context.Context, *GetStoreLocationReq) (*StoreLocation, error)
+	// WatchFlashSale sends the current status and stock of a sale, then every
+	// change to them until the sale ends or the client goes away.
+	WatchFlashSale(*GetById, FlashSaleService_WatchFlashSaleServer) error
 	mustEmbedUnimplementedFlashSaleServiceServer()
 }
 
@@ -190,6 +230,9 @@ func (UnimplementedFlashSaleServiceServer) CancelFlashSale(context.Context, *Get
 func (UnimplementedFlashSaleServiceServer) GetStoreLocation(context.Context, *GetStoreLocationReq) (*StoreLocation, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStoreLocation not implemented")
 }
+func (UnimplementedFlashSaleServiceServer) WatchFlashSale(*GetById, FlashSaleService_WatchFlashSaleServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFlashSale not implemented")
+}
 func (UnimplementedFlashSaleServiceServer) mustEmbedUnimplementedFlashSaleServiceServer() {}
 
 // UnsafeFlashSaleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -365,6 +408,27 @@ func _FlashSaleService_GetStoreLocation_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlashSaleService_WatchFlashSale_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetById)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FlashSaleServiceServer).WatchFlashSale(m, &flashSaleServiceWatchFlashSaleServer{ServerStream: stream})
+}
+
+type FlashSaleService_WatchFlashSaleServer interface {
+	Send(*FlashSaleUpdate) error
+	grpc.ServerStream
+}
+
+type flashSaleServiceWatchFlashSaleServer struct {
+	grpc.ServerStream
+}
+
+func (x *flashSaleServiceWatchFlashSaleServer) Send(m *FlashSaleUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // FlashSaleService_ServiceDesc is the grpc.ServiceDesc for FlashSaleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -409,6 +473,12 @@ var FlashSaleService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _FlashSaleService_GetStoreLocation_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFlashSale",
+			Handler:       _FlashSaleService_WatchFlashSale_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "flash_sale_submodule/flash_sales.proto",
 }
@@ -29,6 +29,7 @@ type CreateFlashSaleProductReq struct {
 	ProductId         string  `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
 	DiscountedPrice   float32 `protobuf:"fixed32,3,opt,name=discounted_price,json=discountedPrice,proto3" json:"discounted_price,omitempty"`
 	AvailableQuantity int32   `protobuf:"varint,4,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	PerUserLimit      int32   `protobuf:"varint,5,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"` // 0 means no limit
 }
 
 func (x *CreateFlashSaleProductReq) Reset() {
@@ -91,6 +92,13 @@ func (x *CreateFlashSaleProductReq) GetAvailableQuantity() int32 {
 	return 0
 }
 
+func (x *CreateFlashSaleProductReq) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
 type UpdateFlashSaleProductReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -155,6 +163,7 @@ type UpdateFlashSaleProduct struct {
 	ProductId         string  `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
 	DiscountedPrice   float32 `protobuf:"fixed32,3,opt,name=discounted_price,json=discountedPrice,proto3" json:"discounted_price,omitempty"`
 	AvailableQuantity int32   `protobuf:"varint,4,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	PerUserLimit      int32   `protobuf:"varint,5,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"` // 0 keeps the current limit, -1 removes it
 }
 
 func (x *UpdateFlashSaleProduct) Reset() {
@@ -217,25 +226,89 @@ func (x *UpdateFlashSaleProduct) GetAvailableQuantity() int32 {
 	return 0
 }
 
+func (x *UpdateFlashSaleProduct) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+type GetFlashSaleProductReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // when set, remaining_allowance is computed for this user
+}
+
+func (x *GetFlashSaleProductReq) Reset() {
+	*x = GetFlashSaleProductReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFlashSaleProductReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFlashSaleProductReq) ProtoMessage() {}
+
+func (x *GetFlashSaleProductReq) ProtoReflect() protoreflect.Message {
+	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFlashSaleProductReq.ProtoReflect.Descriptor instead.
+func (*GetFlashSaleProductReq) Descriptor() ([]byte, []int) {
+	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetFlashSaleProductReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetFlashSaleProductReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type FlashSaleProduct struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id                string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FlashSale         *FlashSale `protobuf:"bytes,2,opt,name=flashSale,proto3" json:"flashSale,omitempty"`
-	Product           *Products  `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
-	DiscountedPrice   float32    `protobuf:"fixed32,4,opt,name=discounted_price,json=discountedPrice,proto3" json:"discounted_price,omitempty"`
-	AvailableQuantity int32      `protobuf:"varint,5,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
-	CreatedAt         string     `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt         string     `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	DeletedAt         string     `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Id                 string     `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FlashSale          *FlashSale `protobuf:"bytes,2,opt,name=flashSale,proto3" json:"flashSale,omitempty"`
+	Product            *Products  `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	DiscountedPrice    float32    `protobuf:"fixed32,4,opt,name=discounted_price,json=discountedPrice,proto3" json:"discounted_price,omitempty"`
+	AvailableQuantity  int32      `protobuf:"varint,5,opt,name=available_quantity,json=availableQuantity,proto3" json:"available_quantity,omitempty"`
+	CreatedAt          string     `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          string     `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeletedAt          string     `protobuf:"bytes,8,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	PerUserLimit       int32      `protobuf:"varint,9,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"`
+	RemainingAllowance int32      `protobuf:"varint,10,opt,name=remaining_allowance,json=remainingAllowance,proto3" json:"remaining_allowance,omitempty"` // -1 when neither the product nor the sale has a limit
 }
 
 func (x *FlashSaleProduct) Reset() {
 	*x = FlashSaleProduct{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[3]
+		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -248,7 +321,7 @@ func (x *FlashSaleProduct) String() string {
 func (*FlashSaleProduct) ProtoMessage() {}
 
 func (x *FlashSaleProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[3]
+	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +334,7 @@ func (x *FlashSaleProduct) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlashSaleProduct.ProtoReflect.Descriptor instead.
 func (*FlashSaleProduct) Descriptor() ([]byte, []int) {
-	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{3}
+	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *FlashSaleProduct) GetId() string {
@@ -320,6 +393,20 @@ func (x *FlashSaleProduct) GetDeletedAt() string {
 	return ""
 }
 
+func (x *FlashSaleProduct) GetPerUserLimit() int32 {
+	if x != nil {
+		return x.PerUserLimit
+	}
+	return 0
+}
+
+func (x *FlashSaleProduct) GetRemainingAllowance() int32 {
+	if x != nil {
+		return x.RemainingAllowance
+	}
+	return 0
+}
+
 type ListAllFlashSaleProductsReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -332,7 +419,7 @@ type ListAllFlashSaleProductsReq struct {
 func (x *ListAllFlashSaleProductsReq) Reset() {
 	*x = ListAllFlashSaleProductsReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[4]
+		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -345,7 +432,7 @@ func (x *ListAllFlashSaleProductsReq) String() string {
 func (*ListAllFlashSaleProductsReq) ProtoMessage() {}
 
 func (x *ListAllFlashSaleProductsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[4]
+	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +445,7 @@ func (x *ListAllFlashSaleProductsReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllFlashSaleProductsReq.ProtoReflect.Descriptor instead.
 func (*ListAllFlashSaleProductsReq) Descriptor() ([]byte, []int) {
-	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{4}
+	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ListAllFlashSaleProductsReq) GetFilter() *Pagination {
@@ -389,7 +476,7 @@ type ListAllFlashSaleProductsRes struct {
 func (x *ListAllFlashSaleProductsRes) Reset() {
 	*x = ListAllFlashSaleProductsRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[5]
+		mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -402,7 +489,7 @@ func (x *ListAllFlashSaleProductsRes) String() string {
 func (*ListAllFlashSaleProductsRes) ProtoMessage() {}
 
 func (x *ListAllFlashSaleProductsRes) ProtoReflect() protoreflect.Message {
-	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[5]
+	mi := &file_flash_sale_submodule_flash_sales_products_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,7 +502,7 @@ func (x *ListAllFlashSaleProductsRes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAllFlashSaleProductsRes.ProtoReflect.Descriptor instead.
 func (*ListAllFlashSaleProductsRes) Descriptor() ([]byte, []int) {
-	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{5}
+	return file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListAllFlashSaleProductsRes) GetFlashSaleProducts() []*FlashSaleProduct {
@@ -459,7 +546,7 @@ var file_flash_sale_submodule_flash_sales_products_proto_rawDesc = []byte{
 	0x75, 0x6c, 0x65, 0x2f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x73, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c,
 	0x65, 0x5f, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d,
-	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb5, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdb, 0x01, 0x0a, 0x19, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64,
 	0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53,
 	0x61, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x6c, 0x61,
@@ -471,89 +558,104 @@ var file_flash_sale_submodule_flash_sales_products_proto_rawDesc = []byte{
 	0x65, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x71,
 	0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61,
 	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x22, 0x5e, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53,
-	0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x31, 0x0a,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53,
-	0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
-	0x22, 0xb2, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68,
-	0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66,
-	0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x64,
-	0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
-	0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
-	0x62, 0x6c, 0x65, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x51, 0x75, 0x61,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0xb4, 0x02, 0x0a, 0x10, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53,
-	0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c,
-	0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x52,
-	0x09, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x07, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65,
-	0x12, 0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x71, 0x75,
-	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x76,
-	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12,
-	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d,
-	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x73, 0x0a, 0x1b,
-	0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65,
-	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x29, 0x0a, 0x06, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02,
-	0x52, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x63,
-	0x65, 0x22, 0xb5, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61,
+	0x12, 0x24, 0x0a, 0x0e, 0x70, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x55, 0x73, 0x65,
+	0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x5e, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xd8, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c,
+	0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49,
+	0x64, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x12,
+	0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x24, 0x0a, 0x0e, 0x70,
+	0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0x41, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c,
+	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x8b, 0x03, 0x0a, 0x10, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61,
+	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a, 0x09, 0x66, 0x6c, 0x61,
+	0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x52, 0x09,
+	0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12,
+	0x2d, 0x0a, 0x12, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x71, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x70,
+	0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x70, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e,
+	0x63, 0x65, 0x22, 0x73, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61,
 	0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65,
-	0x73, 0x12, 0x47, 0x0a, 0x13, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x5f,
-	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65,
-	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x11, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61,
-	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x32, 0x86, 0x03, 0x0a, 0x17, 0x46, 0x6c,
-	0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46,
-	0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12,
-	0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x6c,
-	0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65,
-	0x71, 0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x69, 0x64, 0x12, 0x47,
-	0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c,
-	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65,
-	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x69, 0x64, 0x12, 0x62, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x41,
-	0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75,
-	0x63, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x71, 0x12, 0x29, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x22, 0xb5, 0x01, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74,
 	0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64,
-	0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65,
-	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x13, 0x47,
-	0x65, 0x74, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75,
-	0x63, 0x74, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79,
-	0x49, 0x64, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61, 0x73, 0x68,
-	0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x35, 0x0a, 0x16, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65,
-	0x74, 0x42, 0x79, 0x49, 0x64, 0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f,
-	0x69, 0x64, 0x42, 0x17, 0x5a, 0x15, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70,
-	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x13, 0x66, 0x6c, 0x61, 0x73, 0x68,
+	0x5f, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x11, 0x66,
+	0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x32,
+	0x95, 0x03, 0x0a, 0x17, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x47, 0x0a, 0x16, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x56, 0x6f, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c,
+	0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x20,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x69, 0x64, 0x12, 0x62, 0x0a,
+	0x18, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c,
+	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61,
+	0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x46, 0x6c, 0x61,
+	0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x12, 0x4d, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c,
+	0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x6c, 0x61, 0x73, 0x68, 0x53, 0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x12, 0x35, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x6c, 0x61, 0x73, 0x68, 0x53,
+	0x61, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x1a, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x69, 0x64, 0x42, 0x17, 0x5a, 0x15, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -568,36 +670,37 @@ func file_flash_sale_submodule_flash_sales_products_proto_rawDescGZIP() []byte {
 	return file_flash_sale_submodule_flash_sales_products_proto_rawDescData
 }
 
-var file_flash_sale_submodule_flash_sales_products_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_flash_sale_submodule_flash_sales_products_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_flash_sale_submodule_flash_sales_products_proto_goTypes = []any{
 	(*CreateFlashSaleProductReq)(nil),   // 0: proto.CreateFlashSaleProductReq
 	(*UpdateFlashSaleProductReq)(nil),   // 1: proto.UpdateFlashSaleProductReq
 	(*UpdateFlashSaleProduct)(nil),      // 2: proto.UpdateFlashSaleProduct
-	(*FlashSaleProduct)(nil),            // 3: proto.FlashSaleProduct
-	(*ListAllFlashSaleProductsReq)(nil), // 4: proto.ListAllFlashSaleProductsReq
-	(*ListAllFlashSaleProductsRes)(nil), // 5: proto.ListAllFlashSaleProductsRes
-	(*FlashSale)(nil),                   // 6: proto.FlashSale
-	(*Products)(nil),                    // 7: proto.Products
-	(*Pagination)(nil),                  // 8: proto.Pagination
-	(*GetById)(nil),                     // 9: proto.GetById
-	(*Void)(nil),                        // 10: proto.Void
+	(*GetFlashSaleProductReq)(nil),      // 3: proto.GetFlashSaleProductReq
+	(*FlashSaleProduct)(nil),            // 4: proto.FlashSaleProduct
+	(*ListAllFlashSaleProductsReq)(nil), // 5: proto.ListAllFlashSaleProductsReq
+	(*ListAllFlashSaleProductsRes)(nil), // 6: proto.ListAllFlashSaleProductsRes
+	(*FlashSale)(nil),                   // 7: proto.FlashSale
+	(*Products)(nil),                    // 8: proto.Products
+	(*Pagination)(nil),                  // 9: proto.Pagination
+	(*GetById)(nil),                     // 10: proto.GetById
+	(*Void)(nil),                        // 11: proto.Void
 }
 var file_flash_sale_submodule_flash_sales_products_proto_depIdxs = []int32{
 	2,  // 0: proto.UpdateFlashSaleProductReq.body:type_name -> proto.UpdateFlashSaleProduct
-	6,  // 1: proto.FlashSaleProduct.flashSale:type_name -> proto.FlashSale
-	7,  // 2: proto.FlashSaleProduct.product:type_name -> proto.Products
-	8,  // 3: proto.ListAllFlashSaleProductsReq.Filter:type_name -> proto.Pagination
-	3,  // 4: proto.ListAllFlashSaleProductsRes.flash_sale_products:type_name -> proto.FlashSaleProduct
+	7,  // 1: proto.FlashSaleProduct.flashSale:type_name -> proto.FlashSale
+	8,  // 2: proto.FlashSaleProduct.product:type_name -> proto.Products
+	9,  // 3: proto.ListAllFlashSaleProductsReq.Filter:type_name -> proto.Pagination
+	4,  // 4: proto.ListAllFlashSaleProductsRes.flash_sale_products:type_name -> proto.FlashSaleProduct
 	0,  // 5: proto.FlashSaleProductService.CreateFlashSaleProduct:input_type -> proto.CreateFlashSaleProductReq
 	1,  // 6: proto.FlashSaleProductService.UpdateFlashSaleProduct:input_type -> proto.UpdateFlashSaleProductReq
-	4,  // 7: proto.FlashSaleProductService.ListAllFlashSaleProducts:input_type -> proto.ListAllFlashSaleProductsReq
-	9,  // 8: proto.FlashSaleProductService.GetFlashSaleProduct:input_type -> proto.GetById
-	9,  // 9: proto.FlashSaleProductService.DeleteFlashSaleProduct:input_type -> proto.GetById
-	10, // 10: proto.FlashSaleProductService.CreateFlashSaleProduct:output_type -> proto.Void
-	10, // 11: proto.FlashSaleProductService.UpdateFlashSaleProduct:output_type -> proto.Void
-	5,  // 12: proto.FlashSaleProductService.ListAllFlashSaleProducts:output_type -> proto.ListAllFlashSaleProductsRes
-	3,  // 13: proto.FlashSaleProductService.GetFlashSaleProduct:output_type -> proto.FlashSaleProduct
-	10, // 14: proto.FlashSaleProductService.DeleteFlashSaleProduct:output_type -> proto.Void
+	5,  // 7: proto.FlashSaleProductService.ListAllFlashSaleProducts:input_type -> proto.ListAllFlashSaleProductsReq
+	3,  // 8: proto.FlashSaleProductService.GetFlashSaleProduct:input_type -> proto.GetFlashSaleProductReq
+	10, // 9: proto.FlashSaleProductService.DeleteFlashSaleProduct:input_type -> proto.GetById
+	11, // 10: proto.FlashSaleProductService.CreateFlashSaleProduct:output_type -> proto.Void
+	11, // 11: proto.FlashSaleProductService.UpdateFlashSaleProduct:output_type -> proto.Void
+	6,  // 12: proto.FlashSaleProductService.ListAllFlashSaleProducts:output_type -> proto.ListAllFlashSaleProductsRes
+	4,  // 13: proto.FlashSaleProductService.GetFlashSaleProduct:output_type -> proto.FlashSaleProduct
+	11, // 14: proto.FlashSaleProductService.DeleteFlashSaleProduct:output_type -> proto.Void
 	10, // [10:15] is the sub-list for method output_type
 	5,  // [5:10] is the sub-list for method input_type
 	5,  // [5:5] is the sub-list for extension type_name
@@ -651,7 +754,7 @@ func file_flash_sale_submodule_flash_sales_products_proto_init() {
 			}
 		}
 		file_flash_sale_submodule_flash_sales_products_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*FlashSaleProduct); i {
+			switch v := v.(*GetFlashSaleProductReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -663,7 +766,7 @@ func file_flash_sale_submodule_flash_sales_products_proto_init() {
 			}
 		}
 		file_flash_sale_submodule_flash_sales_products_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*ListAllFlashSaleProductsReq); i {
+			switch v := v.(*FlashSaleProduct); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -675,6 +778,18 @@ func file_flash_sale_submodule_flash_sales_products_proto_init() {
 			}
 		}
 		file_flash_sale_submodule_flash_sales_products_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAllFlashSaleProductsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flash_sale_submodule_flash_sales_products_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*ListAllFlashSaleProductsRes); i {
 			case 0:
 				return &v.state
@@ -693,7 +808,7 @@ func file_flash_sale_submodule_flash_sales_products_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_flash_sale_submodule_flash_sales_products_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
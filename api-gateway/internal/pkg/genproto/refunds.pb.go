@@ -0,0 +1,448 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.12.4
+// source: flash_sale_submodule/refunds.proto
+
+package genproto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// amount 0 asks for everything that was paid for the order and not refunded yet.
+type RefundCreateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string  `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Amount  float32 `protobuf:"fixed32,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason  string  `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RefundCreateReq) Reset() {
+	*x = RefundCreateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flash_sale_submodule_refunds_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundCreateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundCreateReq) ProtoMessage() {}
+
+func (x *RefundCreateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_flash_sale_submodule_refunds_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundCreateReq.ProtoReflect.Descriptor instead.
+func (*RefundCreateReq) Descriptor() ([]byte, []int) {
+	return file_flash_sale_submodule_refunds_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RefundCreateReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundCreateReq) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundCreateReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundReviewReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RefundReviewReq) Reset() {
+	*x = RefundReviewReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flash_sale_submodule_refunds_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundReviewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundReviewReq) ProtoMessage() {}
+
+func (x *RefundReviewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_flash_sale_submodule_refunds_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundReviewReq.ProtoReflect.Descriptor instead.
+func (*RefundReviewReq) Descriptor() ([]byte, []int) {
+	return file_flash_sale_submodule_refunds_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RefundReviewReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RefundReviewReq) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RefundListReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RefundStatus string      `protobuf:"bytes,1,opt,name=refund_status,json=refundStatus,proto3" json:"refund_status,omitempty"`
+	OrderId      string      `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId       string      `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filter       *Pagination `protobuf:"bytes,4,opt,name=Filter,proto3" json:"Filter,omitempty"`
+}
+
+func (x *RefundListReq) Reset() {
+	*x = RefundListReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flash_sale_submodule_refunds_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundListReq) ProtoMessage() {}
+
+func (x *RefundListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_flash_sale_submodule_refunds_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundListReq.ProtoReflect.Descriptor instead.
+func (*RefundListReq) Descriptor() ([]byte, []int) {
+	return file_flash_sale_submodule_refunds_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RefundListReq) GetRefundStatus() string {
+	if x != nil {
+		return x.RefundStatus
+	}
+	return ""
+}
+
+func (x *RefundListReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundListReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RefundListReq) GetFilter() *Pagination {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type RefundListRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Refunds    []*Refund `protobuf:"bytes,1,rep,name=refunds,proto3" json:"refunds,omitempty"`
+	TotalCount int64     `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *RefundListRes) Reset() {
+	*x = RefundListRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_flash_sale_submodule_refunds_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RefundListRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundListRes) ProtoMessage() {}
+
+func (x *RefundListRes) ProtoReflect() protoreflect.Message {
+	mi := &file_flash_sale_submodule_refunds_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundListRes.ProtoReflect.Descriptor instead.
+func (*RefundListRes) Descriptor() ([]byte, []int) {
+	return file_flash_sale_submodule_refunds_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RefundListRes) GetRefunds() []*Refund {
+	if x != nil {
+		return x.Refunds
+	}
+	return nil
+}
+
+func (x *RefundListRes) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+var File_flash_sale_submodule_refunds_proto protoreflect.FileDescriptor
+
+var file_flash_sale_submodule_refunds_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x62,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x2f, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x66, 0x6c, 0x61,
+	0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6d, 0x6f, 0x64, 0x75, 0x6c,
+	0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x26,
+	0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x6d, 0x6f,
+	0x64, 0x75, 0x6c, 0x65, 0x2f, 0x66, 0x6c, 0x61, 0x73, 0x68, 0x5f, 0x73, 0x61, 0x6c, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x5c, 0x0a, 0x0f, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x0f, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x93, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x06, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x59, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x07, 0x72, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x32, 0xcd, 0x02, 0x0a, 0x0d, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x36, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x66,
+	0x75, 0x6e, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x64, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x39, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x12, 0x36, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x66, 0x75,
+	0x6e, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x0c, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x12, 0x2e, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x66, 0x75, 0x6e,
+	0x64, 0x12, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49,
+	0x64, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64,
+	0x42, 0x17, 0x5a, 0x15, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x67, 0x65, 0x6e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_flash_sale_submodule_refunds_proto_rawDescOnce sync.Once
+	file_flash_sale_submodule_refunds_proto_rawDescData = file_flash_sale_submodule_refunds_proto_rawDesc
+)
+
+func file_flash_sale_submodule_refunds_proto_rawDescGZIP() []byte {
+	file_flash_sale_submodule_refunds_proto_rawDescOnce.Do(func() {
+		file_flash_sale_submodule_refunds_proto_rawDescData = protoimpl.X.CompressGZIP(file_flash_sale_submodule_refunds_proto_rawDescData)
+	})
+	return file_flash_sale_submodule_refunds_proto_rawDescData
+}
+
+var file_flash_sale_submodule_refunds_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_flash_sale_submodule_refunds_proto_goTypes = []any{
+	(*RefundCreateReq)(nil), // 0: proto.RefundCreateReq
+	(*RefundReviewReq)(nil), // 1: proto.RefundReviewReq
+	(*RefundListReq)(nil),   // 2: proto.RefundListReq
+	(*RefundListRes)(nil),   // 3: proto.RefundListRes
+	(*Pagination)(nil),      // 4: proto.Pagination
+	(*Refund)(nil),          // 5: proto.Refund
+	(*GetById)(nil),         // 6: proto.GetById
+}
+var file_flash_sale_submodule_refunds_proto_depIdxs = []int32{
+	4, // 0: proto.RefundListReq.Filter:type_name -> proto.Pagination
+	5, // 1: proto.RefundListRes.refunds:type_name -> proto.Refund
+	0, // 2: proto.RefundService.RequestRefund:input_type -> proto.RefundCreateReq
+	6, // 3: proto.RefundService.GetRefund:input_type -> proto.GetById
+	2, // 4: proto.RefundService.ListRefunds:input_type -> proto.RefundListReq
+	1, // 5: proto.RefundService.ApproveRefund:input_type -> proto.RefundReviewReq
+	1, // 6: proto.RefundService.RejectRefund:input_type -> proto.RefundReviewReq
+	6, // 7: proto.RefundService.ProcessRefund:input_type -> proto.GetById
+	5, // 8: proto.RefundService.RequestRefund:output_type -> proto.Refund
+	5, // 9: proto.RefundService.GetRefund:output_type -> proto.Refund
+	3, // 10: proto.RefundService.ListRefunds:output_type -> proto.RefundListRes
+	5, // 11: proto.RefundService.ApproveRefund:output_type -> proto.Refund
+	5, // 12: proto.RefundService.RejectRefund:output_type -> proto.Refund
+	5, // 13: proto.RefundService.ProcessRefund:output_type -> proto.Refund
+	8, // [8:14] is the sub-list for method output_type
+	2, // [2:8] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_flash_sale_submodule_refunds_proto_init() }
+func file_flash_sale_submodule_refunds_proto_init() {
+	if File_flash_sale_submodule_refunds_proto != nil {
+		return
+	}
+	file_flash_sale_submodule_common_proto_init()
+	file_flash_sale_submodule_flash_sales_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_flash_sale_submodule_refunds_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundCreateReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flash_sale_submodule_refunds_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundReviewReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flash_sale_submodule_refunds_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundListReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_flash_sale_submodule_refunds_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*RefundListRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_flash_sale_submodule_refunds_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_flash_sale_submodule_refunds_proto_goTypes,
+		DependencyIndexes: file_flash_sale_submodule_refunds_proto_depIdxs,
+		MessageInfos:      file_flash_sale_submodule_refunds_proto_msgTypes,
+	}.Build()
+	File_flash_sale_submodule_refunds_proto = out.File
+	file_flash_sale_submodule_refunds_proto_rawDesc = nil
+	file_flash_sale_submodule_refunds_proto_goTypes = nil
+	file_flash_sale_submodule_refunds_proto_depIdxs = nil
+}
@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Broker is what the producer publishes on: kafka itself, or one a test
+// hands in to see what the gateway sends. It has the shape of the Broker of
+// flash_service, so the messages can be fed to its consumers as they are.
+type Broker interface {
+	// Publish appends msgs to the topics they name.
+	Publish(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type kafkaBroker struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaBroker returns a Broker on the given kafka brokers.
+func NewKafkaBroker(brokers []string) Broker {
+	return &kafkaBroker{
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (b *kafkaBroker) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	return b.writer.WriteMessages(ctx, msgs...)
+}
+
+// Close flushes what is still buffered.
+func (b *kafkaBroker) Close() error {
+	return b.writer.Close()
+}
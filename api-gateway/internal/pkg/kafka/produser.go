@@ -2,35 +2,102 @@ package kafka
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	pb "flashSale_gateway/internal/pkg/genproto"
 
 	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
+// ProducerName is what the gateway signs its envelopes with.
+const ProducerName = "api-gateway"
+
 type KafkaProducer interface {
-	ProduceMessages(topic string, message []byte) error
+	// ProduceMessages publishes payload to topic in an Envelope; version is
+	// that of the payload's schema.
+	ProduceMessages(topic string, payload proto.Message, version int32) error
+	// ProduceCommand publishes a write for flash_service and returns the ID
+	// its outcome is tracked under. A non-empty replyTo asks for the outcome
+	// on that topic as well. issuedBy is the user sending the command, only
+	// they can look its status up; it is empty for registrations.
+	ProduceCommand(topic string, payload proto.Message, version int32, replyTo, issuedBy string) (string, error)
 	Close() error
 }
 
 type Producer struct {
-	writer *kafka.Writer
+	broker Broker
 }
 
 func NewKafkaProducer(brokers []string) (KafkaProducer, error) {
-	writer := &kafka.Writer{
-		Addr:                   kafka.TCP(brokers...),
-		AllowAutoTopicCreation: true,
+	return NewProducer(NewKafkaBroker(brokers)), nil
+}
+
+// NewProducer returns a producer that publishes on broker and closes it on
+// Close.
+func NewProducer(broker Broker) KafkaProducer {
+	return &Producer{broker: broker}
+}
+
+func (p *Producer) ProduceMessages(topic string, payload proto.Message, version int32) error {
+	env, err := newEnvelope(payload, version)
+	if err != nil {
+		return err
 	}
-	return &Producer{writer: writer}, nil
+	return p.produce(topic, env)
 }
 
-func (p *Producer) ProduceMessages(topic string, message []byte) error {
-	return p.writer.WriteMessages(context.Background(), kafka.Message{
+func (p *Producer) ProduceCommand(topic string, payload proto.Message, version int32, replyTo, issuedBy string) (string, error) {
+	env, err := newEnvelope(payload, version)
+	if err != nil {
+		return "", err
+	}
+	env.CorrelationId = env.Id
+	env.ReplyTo = replyTo
+	env.IssuedBy = issuedBy
+
+	if err := p.produce(topic, env); err != nil {
+		return "", err
+	}
+	return env.CorrelationId, nil
+}
+
+func (p *Producer) produce(topic string, env *pb.Envelope) error {
+	message, err := protojson.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return p.broker.Publish(context.Background(), kafka.Message{
 		Topic: topic,
 		Value: message,
 	})
 }
 
 func (p *Producer) Close() error {
-	return p.writer.Close()
+	return p.broker.Close()
 }
 
+// newEnvelope wraps payload, typed by its protobuf name.
+func newEnvelope(payload proto.Message, version int32) (*pb.Envelope, error) {
+	data, err := protojson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return &pb.Envelope{
+		Id:         hex.EncodeToString(b),
+		Type:       string(proto.MessageName(payload)),
+		Version:    version,
+		Producer:   ProducerName,
+		ProducedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		Payload:    data,
+	}, nil
+}
@@ -0,0 +1,169 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "flashSale_gateway/internal/pkg/genproto"
+	"flashSale_gateway/internal/pkg/kafka"
+
+	segkafka "github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var update = flag.Bool("update", false, "rewrite the commands flash_service's end-to-end tests replay")
+
+// commandsDir holds the commands as the gateway sends them, which the
+// end-to-end tests of flash_service feed to its consumers.
+const commandsDir = "../../../../flash_service/internal/usecase/test/testdata/gateway"
+
+// broker keeps what was published.
+type broker struct {
+	published []segkafka.Message
+	closed    bool
+}
+
+func (b *broker) Publish(ctx context.Context, msgs ...segkafka.Message) error {
+	b.published = append(b.published, msgs...)
+	return nil
+}
+
+func (b *broker) Close() error {
+	b.closed = true
+	return nil
+}
+
+// recorded is a command as it went out.
+type recorded struct {
+	Topic string          `json:"topic"`
+	Value json.RawMessage `json:"value"`
+}
+
+func open(t *testing.T, value []byte) *pb.Envelope {
+	var env pb.Envelope
+	if err := protojson.Unmarshal(value, &env); err != nil {
+		t.Fatalf("error was not expected while reading the envelope: %s", err)
+	}
+	return &env
+}
+
+// payloadOf decodes the payload of env into a message of the type of like.
+func payloadOf(t *testing.T, env *pb.Envelope, like proto.Message) proto.Message {
+	payload := like.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(env.Payload, payload); err != nil {
+		t.Fatalf("error was not expected while reading the payload: %s", err)
+	}
+	return payload
+}
+
+func TestProduceCommand(t *testing.T) {
+	b := &broker{}
+	producer := kafka.NewProducer(b)
+	req := &pb.UpdateOrderReq{Id: "order-1", Body: &pb.UpdateOrder{OrderStatus: "shipped"}}
+
+	id, err := producer.ProduceCommand("update-order", req, 1, "replies.gateway", "user-1")
+	if err != nil {
+		t.Fatalf("error was not expected while producing the command: %s", err)
+	}
+	if len(b.published) != 1 || b.published[0].Topic != "update-order" {
+		t.Fatalf("expected one message on update-order, got %v", b.published)
+	}
+
+	env := open(t, b.published[0].Value)
+	if env.Id != id || env.CorrelationId != id || env.ReplyTo != "replies.gateway" || env.IssuedBy != "user-1" {
+		t.Errorf("expected command %s of user-1 replying on replies.gateway, got %+v", id, env)
+	}
+	if env.Producer != kafka.ProducerName || env.Type != string(proto.MessageName(req)) || env.Version != 1 {
+		t.Errorf("unexpected envelope %+v", env)
+	}
+	if payload := payloadOf(t, env, req); !proto.Equal(payload, req) {
+		t.Errorf("expected payload %v, got %v", req, payload)
+	}
+
+	if err := producer.Close(); err != nil || !b.closed {
+		t.Errorf("expected the broker to be closed, got %v", err)
+	}
+}
+
+func TestProduceMessages(t *testing.T) {
+	b := &broker{}
+	req := &pb.ChangePasswordReq{Id: "user-1"}
+
+	if err := kafka.NewProducer(b).ProduceMessages("upd-pass", req, 1); err != nil {
+		t.Fatalf("error was not expected while producing the message: %s", err)
+	}
+	if len(b.published) != 1 {
+		t.Fatalf("expected one message, got %v", b.published)
+	}
+	// not a command, nobody waits for its outcome
+	if env := open(t, b.published[0].Value); env.Id == "" || env.CorrelationId != "" || env.ReplyTo != "" {
+		t.Errorf("unexpected envelope %+v", env)
+	}
+}
+
+// TestRecordedCommands checks the commands flash_service replays are the ones
+// the gateway sends. Run with -update to record them again.
+func TestRecordedCommands(t *testing.T) {
+	commands := []struct {
+		name     string
+		topic    string
+		payload  proto.Message
+		issuedBy string
+	}{
+		{"register", "create", &pb.RegisterReq{Username: "mubina", Email: "mubina@example.com", Password: "hash", FullName: "Mubina", DateOfBirth: "2000-01-01"}, ""},
+		{"create-product", "create-product", &pb.CreateProductReq{Name: "Phone", Description: "64GB", Price: 199.9, ImageUrl: "phone.png", StockQuantity: 10}, "admin-1"},
+		{"ship-order", "update-order", &pb.UpdateOrderReq{Id: "order-1", Body: &pb.UpdateOrder{OrderStatus: "shipped"}}, "admin-1"},
+		{"reopen-order", "update-order", &pb.UpdateOrderReq{Id: "order-1", Body: &pb.UpdateOrder{OrderStatus: "pending"}}, "admin-1"},
+	}
+
+	for _, cmd := range commands {
+		t.Run(cmd.name, func(t *testing.T) {
+			b := &broker{}
+			if _, err := kafka.NewProducer(b).ProduceCommand(cmd.topic, cmd.payload, 1, "replies.gateway", cmd.issuedBy); err != nil {
+				t.Fatalf("error was not expected while producing the command: %s", err)
+			}
+			got := recorded{Topic: b.published[0].Topic, Value: b.published[0].Value}
+			path := filepath.Join(commandsDir, cmd.name+".json")
+
+			if *update {
+				data, err := json.MarshalIndent(got, "", "  ")
+				if err != nil {
+					t.Fatalf("error was not expected while recording the command: %s", err)
+				}
+				if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+					t.Fatalf("error was not expected while recording the command: %s", err)
+				}
+				return
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%s is not recorded, run the test with -update: %s", cmd.name, err)
+			}
+			var want recorded
+			if err := json.Unmarshal(data, &want); err != nil {
+				t.Fatalf("error was not expected while reading %s: %s", path, err)
+			}
+			if want.Topic != got.Topic {
+				t.Errorf("expected topic %s, got %s", got.Topic, want.Topic)
+			}
+
+			// IDs and times differ from send to send
+			wantEnv, gotEnv := open(t, want.Value), open(t, got.Value)
+			if payload := payloadOf(t, wantEnv, cmd.payload); !proto.Equal(payload, cmd.payload) {
+				t.Errorf("recorded payload %v, the gateway sends %v", payload, cmd.payload)
+			}
+			for _, env := range []*pb.Envelope{wantEnv, gotEnv} {
+				env.Id, env.CorrelationId, env.ProducedAt, env.Payload = "", "", "", nil
+			}
+			if !proto.Equal(wantEnv, gotEnv) {
+				t.Errorf("recorded envelope %v, the gateway sends %v; run the test with -update", wantEnv, gotEnv)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package tokens
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -12,6 +14,26 @@ import (
 	pb "flashSale_gateway/internal/pkg/genproto"
 )
 const signingKey = "secret_key"
+
+// AccessTokenTTL is how long an access token is valid, and so how long a
+// revoked one has to stay on the denylist.
+const AccessTokenTTL = 180 * time.Minute
+
+// RefreshTokenTTL is how long a refresh token is valid.
+const RefreshTokenTTL = 48 * time.Hour
+
+// CommandTokenTTL is how long the status of a registration can be looked up.
+const CommandTokenTTL = 24 * time.Hour
+
+// Token types, kept in the typ claim. Only access tokens authorize requests,
+// refresh tokens are only traded for new tokens and command tokens only look
+// up the status of the command they name.
+const (
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+	TypeCommand = "command"
+)
+
 func VerifyToken(tokenString string) error {
 	secretKey := []byte("secret_key")
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
@@ -52,32 +74,79 @@ func ExtractClaims(tokenStr, key string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 func GenerateJWTToken(user *pb.User) (string, string) {
+	return GenerateAccessToken(user), GenerateRefreshToken(user.Id)
+}
+
+// GenerateAccessToken issues an access token for the user. Its jti names the
+// token on the denylist once the user logs out. iat_ms tells it apart from
+// tokens issued earlier in the same second, which a logout of all sessions
+// refuses.
+func GenerateAccessToken(user *pb.User) string {
 	accessToken := jwt.New(jwt.SigningMethodHS256)
-	refreshToken := jwt.New(jwt.SigningMethodHS256)
 
+	now := time.Now()
 	claims := accessToken.Claims.(jwt.MapClaims)
 	claims["user_id"] = user.Id
 	claims["email"] = user.Email
 	claims["role"] = user.Role
-	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().Add(180 * time.Minute).Unix()
+	claims["typ"] = TypeAccess
+	claims["jti"] = newJTI()
+	claims["iat"] = now.Unix()
+	claims["iat_ms"] = now.UnixMilli()
+	claims["exp"] = now.Add(AccessTokenTTL).Unix()
 	access, err := accessToken.SignedString([]byte(signingKey))
 	if err != nil {
 		log.Fatal("error while generating access token: ", err)
 	}
 
+	return access
+}
+
+// GenerateRefreshToken issues a refresh token for the user. The jti makes
+// every token unique, even two issued in the same second, so that each one
+// can be rotated on its own.
+func GenerateRefreshToken(userID string) string {
+	refreshToken := jwt.New(jwt.SigningMethodHS256)
+
 	rftClaims := refreshToken.Claims.(jwt.MapClaims)
-	rftClaims["user_id"] = user.Id
-	claims["email"] = user.Email
-	claims["role"] = user.Role
+	rftClaims["user_id"] = userID
+	rftClaims["typ"] = TypeRefresh
+	rftClaims["jti"] = newJTI()
 	rftClaims["iat"] = time.Now().Unix()
-	rftClaims["exp"] = time.Now().Add(48 * time.Hour).Unix()
+	rftClaims["exp"] = time.Now().Add(RefreshTokenTTL).Unix()
 	refresh, err := refreshToken.SignedString([]byte(signingKey))
 	if err != nil {
 		log.Fatal("error while generating refresh token: ", err)
 	}
 
-	return access, refresh
+	return refresh
+}
+
+// GenerateCommandToken issues the token the status of a command sent without
+// logging in, a registration, is looked up with.
+func GenerateCommandToken(commandID string) string {
+	commandToken := jwt.New(jwt.SigningMethodHS256)
+
+	now := time.Now()
+	claims := commandToken.Claims.(jwt.MapClaims)
+	claims["command_id"] = commandID
+	claims["typ"] = TypeCommand
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(CommandTokenTTL).Unix()
+	token, err := commandToken.SignedString([]byte(signingKey))
+	if err != nil {
+		log.Fatal("error while generating command token: ", err)
+	}
+
+	return token
+}
+
+func newJTI() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal("error while generating token id: ", err)
+	}
+	return hex.EncodeToString(b)
 }
 
 func ValidateToken(tokenStr string) (bool, error) {
@@ -0,0 +1,130 @@
+// Package waitingroom queues buyers of a flash sale in Redis and lets them through
+// at a fixed rate, so the start of a hot sale does not hit flash_service at once.
+package waitingroom
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrUnknownToken is returned for tokens that were never issued for the sale or
+// have expired.
+var ErrUnknownToken = errors.New("unknown or expired queue token")
+
+// advance moves the admission frontier of a sale forward by rate admissions per
+// second since it was last moved, and returns how many tickets are admitted.
+// Capacity is not saved up while nobody is waiting.
+var advance = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local issued = tonumber(redis.call('GET', KEYS[2]) or '0')
+local state = redis.call('HMGET', KEYS[1], 'count', 'ts')
+local count = tonumber(state[1]) or 0
+local ts = tonumber(state[2]) or now
+local grant = math.floor((now - ts) * rate / 1000)
+if grant > 0 then
+	count = count + grant
+	ts = ts + grant * 1000 / rate
+end
+if count >= issued then
+	count = issued
+	ts = now
+end
+redis.call('HSET', KEYS[1], 'count', count, 'ts', string.format('%.3f', ts))
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return count
+`)
+
+// Status is what a buyer sees while waiting. Position is the place in line, 1
+// being next; it is 0 once Admitted is true.
+type Status struct {
+	Token                string `json:"token"`
+	Position             int64  `json:"position"`
+	Admitted             bool   `json:"admitted"`
+	EstimatedWaitSeconds int64  `json:"estimated_wait_seconds"`
+}
+
+type Room struct {
+	rdb  *redis.Client
+	rate float64
+	ttl  time.Duration
+}
+
+// New returns a room that admits rate buyers per second; rate must be above 0.
+// Queue tokens, and the admission they turn into, are valid for ttl after
+// joining.
+func New(rdb *redis.Client, rate float64, ttl time.Duration) *Room {
+	return &Room{
+		rdb:  rdb,
+		rate: rate,
+		ttl:  ttl,
+	}
+}
+
+// Join puts a new buyer at the end of the queue of a sale.
+func (r *Room) Join(ctx context.Context, flashSaleID string) (*Status, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(b)
+
+	seq, err := r.rdb.Incr(ctx, issuedKey(flashSaleID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.rdb.Expire(ctx, issuedKey(flashSaleID), r.ttl).Err(); err != nil {
+		return nil, err
+	}
+	if err := r.rdb.Set(ctx, ticketKey(flashSaleID, token), seq, r.ttl).Err(); err != nil {
+		return nil, err
+	}
+
+	return r.status(ctx, flashSaleID, token, seq)
+}
+
+// Status reports where the holder of token stands in the queue of a sale.
+func (r *Room) Status(ctx context.Context, flashSaleID, token string) (*Status, error) {
+	seq, err := r.rdb.Get(ctx, ticketKey(flashSaleID, token)).Int64()
+	if err == redis.Nil {
+		return nil, ErrUnknownToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.status(ctx, flashSaleID, token, seq)
+}
+
+func (r *Room) status(ctx context.Context, flashSaleID, token string, seq int64) (*Status, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	admitted, err := advance.Run(ctx, r.rdb, []string{admittedKey(flashSaleID), issuedKey(flashSaleID)}, now, r.rate, r.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Status{Token: token, Admitted: seq <= admitted}
+	if !res.Admitted {
+		res.Position = seq - admitted
+		res.EstimatedWaitSeconds = int64(math.Ceil(float64(res.Position) / r.rate))
+	}
+	return res, nil
+}
+
+func issuedKey(flashSaleID string) string {
+	return "waitingroom:" + flashSaleID + ":issued"
+}
+
+func admittedKey(flashSaleID string) string {
+	return "waitingroom:" + flashSaleID + ":admitted"
+}
+
+func ticketKey(flashSaleID, token string) string {
+	return "waitingroom:" + flashSaleID + ":ticket:" + token
+}
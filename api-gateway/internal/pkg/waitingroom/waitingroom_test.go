@@ -0,0 +1,108 @@
+package waitingroom_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"flashSale_gateway/internal/pkg/waitingroom"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newRoom(t *testing.T, rate float64) (*waitingroom.Room, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return waitingroom.New(rdb, rate, 30*time.Minute), mr
+}
+
+func join(t *testing.T, room *waitingroom.Room, flashSaleID string) *waitingroom.Status {
+	status, err := room.Join(context.Background(), flashSaleID)
+	if err != nil {
+		t.Fatalf("error was not expected while joining the queue: %s", err)
+	}
+	return status
+}
+
+func status(t *testing.T, room *waitingroom.Room, flashSaleID, token string) *waitingroom.Status {
+	status, err := room.Status(context.Background(), flashSaleID, token)
+	if err != nil {
+		t.Fatalf("error was not expected while checking the queue: %s", err)
+	}
+	return status
+}
+
+func TestJoinQueuesInOrder(t *testing.T) {
+	room, _ := newRoom(t, 2)
+
+	for i, want := range []struct{ position, wait int64 }{{1, 1}, {2, 1}, {3, 2}} {
+		res := join(t, room, "sale-1")
+		if res.Token == "" || res.Admitted || res.Position != want.position || res.EstimatedWaitSeconds != want.wait {
+			t.Errorf("buyer %d: expected position %d and a wait of %ds, got %+v", i+1, want.position, want.wait, res)
+		}
+	}
+
+	// every sale has a queue of its own
+	if res := join(t, room, "sale-2"); res.Position != 1 {
+		t.Errorf("expected the first buyer of another sale to be next, got %+v", res)
+	}
+}
+
+func TestStatusAdmitsAtRate(t *testing.T) {
+	room, _ := newRoom(t, 10)
+	first := join(t, room, "sale-1")
+	second := join(t, room, "sale-1")
+	third := join(t, room, "sale-1")
+
+	// two admissions go by in 200ms
+	time.Sleep(250 * time.Millisecond)
+	if res := status(t, room, "sale-1", first.Token); !res.Admitted || res.Position != 0 || res.EstimatedWaitSeconds != 0 {
+		t.Errorf("expected the first buyer to be admitted, got %+v", res)
+	}
+	if res := status(t, room, "sale-1", second.Token); !res.Admitted {
+		t.Errorf("expected the second buyer to be admitted, got %+v", res)
+	}
+	if res := status(t, room, "sale-1", third.Token); res.Admitted || res.Position != 1 {
+		t.Errorf("expected the third buyer to be next, got %+v", res)
+	}
+}
+
+func TestStatusDoesNotSaveCapacity(t *testing.T) {
+	room, _ := newRoom(t, 10)
+	first := join(t, room, "sale-1")
+	time.Sleep(250 * time.Millisecond)
+	if res := status(t, room, "sale-1", first.Token); !res.Admitted {
+		t.Fatalf("expected the first buyer to be admitted, got %+v", res)
+	}
+
+	// nobody waits while the room is idle, the rush after it still queues
+	time.Sleep(250 * time.Millisecond)
+	if res := join(t, room, "sale-1"); !res.Admitted {
+		t.Errorf("expected a buyer of an idle room to be admitted, got %+v", res)
+	}
+	for i, want := range []int64{1, 2, 3} {
+		if res := join(t, room, "sale-1"); res.Admitted || res.Position != want {
+			t.Errorf("buyer %d of the rush: expected position %d, got %+v", i+1, want, res)
+		}
+	}
+}
+
+func TestStatusUnknownToken(t *testing.T) {
+	room, mr := newRoom(t, 1)
+	res := join(t, room, "sale-1")
+
+	if _, err := room.Status(context.Background(), "sale-1", "forged"); !errors.Is(err, waitingroom.ErrUnknownToken) {
+		t.Errorf("expected ErrUnknownToken for a token never issued, got %v", err)
+	}
+	if _, err := room.Status(context.Background(), "sale-2", res.Token); !errors.Is(err, waitingroom.ErrUnknownToken) {
+		t.Errorf("expected ErrUnknownToken for a token of another sale, got %v", err)
+	}
+
+	mr.FastForward(30 * time.Minute)
+	if _, err := room.Status(context.Background(), "sale-1", res.Token); !errors.Is(err, waitingroom.ErrUnknownToken) {
+		t.Errorf("expected ErrUnknownToken once the token expired, got %v", err)
+	}
+}
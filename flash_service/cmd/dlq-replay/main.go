@@ -0,0 +1,45 @@
+// Command dlq-replay moves dead-lettered messages back to the topic they
+// failed on, once whatever made them fail has been fixed:
+//
+//	go run ./cmd/dlq-replay -topic update-order
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/config"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+)
+
+func main() {
+	cfg := config.Load()
+
+	brokers := flag.String("brokers", cfg.KafkaUrl, "comma separated kafka brokers")
+	topic := flag.String("topic", "", "topic whose dead letters to replay")
+	limit := flag.Int("limit", 0, "replay at most this many messages, 0 for all")
+	idle := flag.Duration("idle", 5*time.Second, "stop once no message arrives for this long")
+	flag.Parse()
+
+	if *topic == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	broker := kafka.NewKafkaBroker(strings.Split(*brokers, ","))
+	defer broker.Close()
+
+	n, err := kafka.ReplayDeadLetters(ctx, broker, *topic, *limit, *idle)
+	if err != nil {
+		log.Fatalf("Error while replaying %s after %d messages: %v", kafka.DeadLetterTopic(*topic), n, err)
+	}
+	log.Printf("Replayed %d messages from %s to %s", n, kafka.DeadLetterTopic(*topic), *topic)
+}
@@ -1,48 +1,87 @@
 package app
 
 import (
+	"context"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/Mubinabd/flash_sale/internal/pkg/config"
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
 	"github.com/Mubinabd/flash_sale/internal/pkg/postgres"
 	"github.com/Mubinabd/flash_sale/internal/storage/repository"
 	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"github.com/Mubinabd/flash_sale/internal/usecase/saga"
+	"github.com/Mubinabd/flash_sale/internal/usecase/scheduler"
 	"github.com/Mubinabd/flash_sale/internal/usecase/service"
+	"github.com/Mubinabd/flash_sale/internal/usecase/watcher"
 	"google.golang.org/grpc"
 )
 
 func Run(cf *config.Config) {
+	// background work stops on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	var workers sync.WaitGroup
+	background := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
+
 	// connect to postgres
 	pgm, err := postgres.New(cf)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer pgm.Close()
-	// connect to kafka producer
-	kf, err := kafka.NewKafkaProducer([]string{cf.KafkaUrl})
+	// kafka, or an in-memory broker when BROKER=memory
+	broker, err := kafka.NewBroker(cf.Broker, []string{cf.KafkaUrl})
 	if err != nil {
 		log.Fatal(err)
 	}
+	kf := kafka.NewProducer(broker)
 
 	// repo
 	db := repository.NewStorage(pgm.DB)
 
-	k_handler := KafkaHandler{
-		auth:             service.NewAuthService(db, kf),
-		user:             service.NewUserService(db, kf),
-		order:            service.NewOrderService(db, kf),
-		product:          service.NewProductService(db, kf),
-		notification:     service.NewNotificationService(db, kf),
-		flashSaleProduct: service.NewFlashSaleProductService(db, kf),
-		flashSale:        service.NewFlashSaleService(db, kf),
+	payments, err := payment.New(cf.PaymentProvider, cf.PaymentFakeMode, cf.PaymentWebhookSecret)
+	if err != nil {
+		log.Fatal(err)
 	}
+	checkout := saga.NewCheckout(db, payments, cf.PaymentTimeout, cf.SagaMaxAttempts)
+
+	// fan out stock and status changes to WatchFlashSale streams
+	hub := watcher.NewHub()
+	background(func(ctx context.Context) {
+		if err := hub.Listen(ctx, postgres.ConnString(cf)); err != nil && ctx.Err() == nil {
+			log.Println("Flash sale updates are off:", err)
+		}
+	})
 
-	if err := Register(&k_handler, cf); err != nil {
+	// outcome of the writes the gateway hands over through kafka
+	commands := service.NewCommandService(db, kf)
+	kcm, err := Register(NewKafkaHandler(db, kf), broker, commands, cf)
+	if err != nil {
 		log.Fatal(err)
 	}
 
+	// move flash sales through their statuses on time
+	background(scheduler.NewFlashSaleScheduler(db, cf.FlashSaleSchedulerInterval).Run)
+	// give back stock of holds that were not checked out in time
+	background(scheduler.NewReservationSweeper(db, cf.ReservationSweepInterval).Run)
+	// finish or undo sagas a crash left halfway
+	background(scheduler.NewSagaRecovery(db, cf.SagaRecoveryInterval, cf.SagaStaleAfter, map[string]scheduler.SagaRecoverer{
+		saga.CheckoutKind: checkout,
+	}).Run)
+	// publish the domain events committed to the outbox
+	background(scheduler.NewOutboxRelay(db, kf, cf.OutboxRelayInterval, cf.OutboxRetryBackoff, cf.OutboxMaxBackoff).Run)
+
 	lis, err := net.Listen("tcp", cf.GRPCPort)
 	if err != nil {
 		log.Fatal("Failed to listen: ", err)
@@ -53,17 +92,76 @@ func Run(cf *config.Config) {
 	pb.RegisterAuthServiceServer(server, service.NewAuthService(db, kf))
 	pb.RegisterUserServiceServer(server, service.NewUserService(db, kf))
 	pb.RegisterFlashSaleProductServiceServer(server, service.NewFlashSaleProductService(db, kf))
-	pb.RegisterFlashSaleServiceServer(server, service.NewFlashSaleService(db, kf))
+	pb.RegisterFlashSaleServiceServer(server, service.NewFlashSaleService(db, kf, hub))
 	pb.RegisterNotificationServiceServer(server, service.NewNotificationService(db, kf))
 	pb.RegisterOrderServiceServer(server, service.NewOrderService(db, kf))
+	pb.RegisterTransactionServiceServer(server, service.NewTransactionService(db, kf))
+	pb.RegisterReservationServiceServer(server, service.NewReservationService(db, kf, cf.ReservationHoldDuration, checkout))
+	pb.RegisterRefundServiceServer(server, service.NewRefundService(db, kf, payments, cf.PaymentTimeout))
 	pb.RegisterProductServiceServer(server, service.NewProductService(db, kf))
 	pb.RegisterReviewServiceServer(server, service.NewReviewService(db, kf))
 	pb.RegisterSocialSharingServiceServer(server, service.NewSocialService(db, kf))
+	pb.RegisterCommandServiceServer(server, commands)
 
 	// start server
-	log.Println("Server started on", cf.GRPCPort)
-	if err = server.Serve(lis); err != nil {
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Println("Server started on", cf.GRPCPort)
+		serveErr <- server.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
 		log.Fatalf("failed to start server: %v", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Println("Shutting down, waiting up to", cf.ShutdownTimeout)
+	shutdown, cancel := context.WithTimeout(context.Background(), cf.ShutdownTimeout)
+	defer cancel()
+
+	// let running calls and streams finish, then cut the rest off
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdown.Done():
+		log.Println("gRPC calls did not finish in time, stopping")
+		server.Stop()
+	}
+
+	if err := kcm.Shutdown(shutdown); err != nil {
+		log.Println("Error while closing Kafka consumers:", err)
+	}
+	if !wait(shutdown, &workers) {
+		log.Println("Background workers did not finish in time")
+	}
+	// closes the broker, which flushes what is still buffered
+	if err := kf.Close(); err != nil {
+		log.Println("Error while closing Kafka producer:", err)
+	}
+	if err := pgm.Close(); err != nil {
+		log.Println("Error while closing postgres:", err)
+	}
+	log.Println("Server stopped")
+}
+
+// wait waits for wg until ctx is done and reports whether wg finished.
+func wait(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
 	}
-	defer lis.Close()
 }
@@ -2,11 +2,16 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"log"
 
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
 	"github.com/Mubinabd/flash_sale/internal/usecase/service"
-	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type KafkaHandler struct {
@@ -17,189 +22,249 @@ type KafkaHandler struct {
 	flashSale *service.FlashSaleService
 	flashSaleProduct *service.FlashSaleProductService
 	notification *service.NotificationService
+
+	storage  st.StorageI
+	producer kafka.KafkaProducer
+}
+
+// NewKafkaHandler returns handlers whose services write through storage.
+func NewKafkaHandler(storage st.StorageI, producer kafka.KafkaProducer) *KafkaHandler {
+	return &KafkaHandler{
+		auth:             service.NewAuthService(storage, producer),
+		user:             service.NewUserService(storage, producer),
+		order:            service.NewOrderService(storage, producer),
+		product:          service.NewProductService(storage, producer),
+		notification:     service.NewNotificationService(storage, producer),
+		flashSaleProduct: service.NewFlashSaleProductService(storage, producer),
+		flashSale:        service.NewFlashSaleService(storage, producer, nil),
+		storage:          storage,
+		producer:         producer,
+	}
+}
+
+// route is a payload type at a version of its schema.
+type route struct {
+	typ     string
+	version int32
+}
+
+// routes lists the payloads the consumers understand. A new version of a
+// schema gets a route of its own, so messages of the old one that are still
+// on the topics keep working.
+var routes = map[route]func(h *KafkaHandler) kafka.Handler{
+	{envelope.TypeOf(&pb.RegisterReq{}), 1}:               (*KafkaHandler).Register,
+	{envelope.TypeOf(&pb.UserRes{}), 1}:                   (*KafkaHandler).EditProfile,
+	{envelope.TypeOf(&pb.SettingReq{}), 1}:                (*KafkaHandler).EditSetting,
+	{envelope.TypeOf(&pb.UpdateFlashSalesReq{}), 1}:       (*KafkaHandler).UpdateFlashSale,
+	{envelope.TypeOf(&pb.CreateFlashSaleProductReq{}), 1}: (*KafkaHandler).CreateFlashSaleProduct,
+	{envelope.TypeOf(&pb.UpdateFlashSaleProductReq{}), 1}: (*KafkaHandler).UpdateFlashSaleProduct,
+	{envelope.TypeOf(&pb.NotificationCreate{}), 1}:        (*KafkaHandler).CreateNotification,
+	{envelope.TypeOf(&pb.UpdateOrderReq{}), 1}:            (*KafkaHandler).UpdateOrder,
+	{envelope.TypeOf(&pb.CreateProductReq{}), 1}:          (*KafkaHandler).CreateProduct,
+	{envelope.TypeOf(&pb.UpdateProductReq{}), 1}:          (*KafkaHandler).UpdateProduct,
 }
 
-func (h *KafkaHandler) Register() func(message []byte) {
-	return func(message []byte) {
+// Dispatch hands env to the handler of its payload type and version.
+func (h *KafkaHandler) Dispatch(ctx context.Context, env *pb.Envelope) error {
+	handler, ok := routes[route{env.Type, env.Version}]
+	if !ok {
+		return kafka.Permanent(fmt.Errorf("no handler for %s version %d", env.Type, env.Version))
+	}
+	return handler(h)(ctx, env)
+}
+
+// exactlyOnce dispatches messages in one transaction with marking them
+// processed. A redelivered message is skipped, and a message whose handler
+// failed is not marked, so its retry runs the handler again.
+func (h *KafkaHandler) exactlyOnce() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
+		return h.storage.WithTx(ctx, func(tx st.StorageI) error {
+			first, err := tx.ProcessedMessage().MarkProcessed(ctx, env.Id)
+			if err != nil {
+				return err
+			}
+			if !first {
+				log.Printf("Skipping message %s, it was processed before", env.Id)
+				return nil
+			}
+
+			return NewKafkaHandler(tx, h.producer).Dispatch(ctx, env)
+		})
+	}
+}
+
+func (h *KafkaHandler) Register() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.RegisterReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.auth.Register(context.Background(), &cer)
+		res, err := h.auth.Register(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("Register User: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) EditProfile() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) EditProfile() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.UserRes
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.user.EditProfile(context.Background(), &cer)
+		res, err := h.user.EditProfile(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("Edit profile: %+v", res)
+		return nil
 	}
 }
 
-func (h *KafkaHandler) EditSetting() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) EditSetting() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.SettingReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.user.EditSetting(context.Background(), &cer)
+		res, err := h.user.EditSetting(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("Edit Setting: %+v", res)
+		return nil
 	}
 }
 
-func (h *KafkaHandler) UpdateFlashSale() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) UpdateFlashSale() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.UpdateFlashSalesReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.flashSale.UpdateFlashSale(context.Background(), &cer)
+		res, err := h.flashSale.UpdateFlashSale(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("Update Flash Sale: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) CreateFlashSaleProduct() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) CreateFlashSaleProduct() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.CreateFlashSaleProductReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.flashSaleProduct.CreateFlashSaleProduct(context.Background(), &cer)
+		res, err := h.flashSaleProduct.CreateFlashSaleProduct(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("create flash sale product: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) UpdateFlashSaleProduct() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) UpdateFlashSaleProduct() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.UpdateFlashSaleProductReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.flashSaleProduct.UpdateFlashSaleProduct(context.Background(), &cer)
+		res, err := h.flashSaleProduct.UpdateFlashSaleProduct(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("flash sale product: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) CreateNotification() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) CreateNotification() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.NotificationCreate
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.notification.CreateNotification(context.Background(), &cer)
+		res, err := h.notification.CreateNotification(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("Create Notification: %+v", res)
+		return nil
 	}
 
 }
 
-func (h *KafkaHandler) UpdateOrder() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) UpdateOrder() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.UpdateOrderReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.order.UpdateOrder(context.Background(), &cer)
+		res, err := h.order.UpdateOrder(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			// illegal status transitions are client errors, they go straight to the dead-letter topic
+			return handlerError(err)
 		}
 		log.Printf("update order: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) CreateProduct() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) CreateProduct() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.CreateProductReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.product.CreateProduct(context.Background(), &cer)
+		res, err := h.product.CreateProduct(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("create product: %+v", res)
+		return nil
 	}
 }
-func (h *KafkaHandler) UpdateProduct() func(message []byte) {
-	return func(message []byte) {
+func (h *KafkaHandler) UpdateProduct() kafka.Handler {
+	return func(ctx context.Context, env *pb.Envelope) error {
 
-		//unmarshal the message
 		var cer pb.UpdateProductReq
-		if err := protojson.Unmarshal(message, &cer); err != nil {
-			log.Fatalf("Failed to unmarshal JSON to Protobuf message: %v", err)
-			return
+		if err := envelope.Open(env, &cer); err != nil {
+			return kafka.Permanent(err)
 		}
 
-		res, err := h.product.UpdateProduct(context.Background(), &cer)
+		res, err := h.product.UpdateProduct(ctx, &cer)
 		if err != nil {
-			log.Fatal(err)
-			return
+			return handlerError(err)
 		}
 		log.Printf("update product: %+v", res)
+		return nil
 	}
-}
\ No newline at end of file
+}
+
+// handlerError marks errors that would fail the same way on every retry as
+// permanent, so the message goes to the dead-letter topic right away.
+func handlerError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition, codes.PermissionDenied:
+		return kafka.Permanent(err)
+	}
+	return err
+}
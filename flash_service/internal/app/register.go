@@ -7,82 +7,41 @@ import (
 	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
 )
 
-func Register(h *KafkaHandler, cfg *config.Config) error {
+// consumers lists the topics flash_service consumes and their consumer
+// groups. What runs for a message depends on its payload type, see routes.
+var consumers = []struct {
+	topic   string
+	groupID string
+}{
+	{"create", "create-id"},
+	{"update", "update-id"},
+	{"edit", "edit"},
+	{"update-flash", "update-flash-id"},
+	{"create-flash-sale", "create-flash-sale-id"},
+	{"update-flash-sale", "update-flash-sale-id"},
+	{"notif", "notif-id"},
+	{"update-order", "update-order-id"},
+	{"create-product", "create-product-id"},
+	{"update-product", "update-product-id"},
+}
 
-	brokers := []string{cfg.KafkaUrl}
-	kcm := kafka.NewKafkaConsumerManager()
+func Register(h *KafkaHandler, broker kafka.Broker, commands kafka.CommandRecorder, cfg *config.Config) (*kafka.KafkaConsumerManager, error) {
 
-	if err := kcm.RegisterConsumer(brokers, "create", "create-id", h.Register()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'create' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "update", "update-id", h.EditProfile()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'update' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "edit", "edit", h.EditSetting()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'edit' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	
-	if err := kcm.RegisterConsumer(brokers, "update-flash", "update-flash-id", h.UpdateFlashSale()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'update-flash' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "create-flash-sale", "create-flash-sale-id", h.CreateFlashSaleProduct()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'create-flash-sale' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
+	kcm := kafka.NewKafkaConsumerManager(broker, commands)
+	policy := kafka.RetryPolicy{
+		MaxAttempts:    cfg.KafkaMaxAttempts,
+		InitialBackoff: cfg.KafkaRetryBackoff,
+		MaxBackoff:     cfg.KafkaMaxBackoff,
 	}
-	if err := kcm.RegisterConsumer(brokers, "update-flash-sale", "update-flash-sale-id", h.UpdateFlashSaleProduct()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'update-flash-sale' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "notif", "notif-id", h.CreateNotification()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'notif' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	
-	if err := kcm.RegisterConsumer(brokers, "update-order", "update-order-id", h.UpdateOrder()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'update-order' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "create-product", "create-product-id", h.CreateProduct()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'create-product' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
-		}
-	}
-	if err := kcm.RegisterConsumer(brokers, "update-product", "update-product-id", h.UpdateProduct()); err != nil {
-		if err == kafka.ErrConsumerAlreadyExists {
-			return errors.New("consumer for topic 'update-product' already exists")
-		} else {
-			return errors.New("error registering consumer:" + err.Error())
+
+	for _, c := range consumers {
+		if err := kcm.RegisterConsumer(c.topic, c.groupID, h.exactlyOnce(), policy); err != nil {
+			if err == kafka.ErrConsumerAlreadyExists {
+				return nil, errors.New("consumer for topic '" + c.topic + "' already exists")
+			} else {
+				return nil, errors.New("error registering consumer:" + err.Error())
+			}
 		}
 	}
-	return nil
+	return kcm, nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -28,6 +29,30 @@ type Config struct {
 
 	DefaultOffset string
 	DefaultLimit  string
+
+	FlashSaleSchedulerInterval time.Duration
+	ReservationHoldDuration    time.Duration
+	ReservationSweepInterval   time.Duration
+
+	PaymentProvider      string
+	PaymentFakeMode      string
+	PaymentWebhookSecret string
+	PaymentTimeout       time.Duration
+
+	SagaRecoveryInterval time.Duration
+	SagaStaleAfter       time.Duration
+	SagaMaxAttempts      int
+
+	OutboxRelayInterval time.Duration
+	OutboxRetryBackoff  time.Duration
+	OutboxMaxBackoff    time.Duration
+
+	Broker            string
+	KafkaMaxAttempts  int
+	KafkaRetryBackoff time.Duration
+	KafkaMaxBackoff   time.Duration
+
+	ShutdownTimeout time.Duration
 }
 
 func Load() Config {
@@ -58,6 +83,31 @@ func Load() Config {
 	config.DefaultOffset = cast.ToString(getOrReturnDefaultValue("DEFAULT_OFFSET", "0"))
 	config.DefaultLimit = cast.ToString(getOrReturnDefaultValue("DEFAULT_LIMIT", "10"))
 
+	config.FlashSaleSchedulerInterval = cast.ToDuration(getOrReturnDefaultValue("FLASH_SALE_SCHEDULER_INTERVAL", "5s"))
+	config.ReservationHoldDuration = cast.ToDuration(getOrReturnDefaultValue("RESERVATION_HOLD_DURATION", "10m"))
+	config.ReservationSweepInterval = cast.ToDuration(getOrReturnDefaultValue("RESERVATION_SWEEP_INTERVAL", "30s"))
+
+	config.PaymentProvider = cast.ToString(getOrReturnDefaultValue("PAYMENT_PROVIDER", "fake"))
+	config.PaymentFakeMode = cast.ToString(getOrReturnDefaultValue("PAYMENT_FAKE_MODE", "succeed"))
+	// nothing receives webhooks yet, the secret is only handed to the provider
+	config.PaymentWebhookSecret = cast.ToString(getOrReturnDefaultValue("PAYMENT_WEBHOOK_SECRET", ""))
+	config.PaymentTimeout = cast.ToDuration(getOrReturnDefaultValue("PAYMENT_TIMEOUT", "10s"))
+
+	config.SagaRecoveryInterval = cast.ToDuration(getOrReturnDefaultValue("SAGA_RECOVERY_INTERVAL", "30s"))
+	config.SagaStaleAfter = cast.ToDuration(getOrReturnDefaultValue("SAGA_STALE_AFTER", "2m"))
+	config.SagaMaxAttempts = cast.ToInt(getOrReturnDefaultValue("SAGA_MAX_ATTEMPTS", 5))
+
+	config.OutboxRelayInterval = cast.ToDuration(getOrReturnDefaultValue("OUTBOX_RELAY_INTERVAL", "1s"))
+	config.OutboxRetryBackoff = cast.ToDuration(getOrReturnDefaultValue("OUTBOX_RETRY_BACKOFF", "1s"))
+	config.OutboxMaxBackoff = cast.ToDuration(getOrReturnDefaultValue("OUTBOX_MAX_BACKOFF", "5m"))
+
+	config.Broker = cast.ToString(getOrReturnDefaultValue("BROKER", "kafka"))
+	config.KafkaMaxAttempts = cast.ToInt(getOrReturnDefaultValue("KAFKA_MAX_ATTEMPTS", 5))
+	config.KafkaRetryBackoff = cast.ToDuration(getOrReturnDefaultValue("KAFKA_RETRY_BACKOFF", "200ms"))
+	config.KafkaMaxBackoff = cast.ToDuration(getOrReturnDefaultValue("KAFKA_MAX_BACKOFF", "10s"))
+
+	config.ShutdownTimeout = cast.ToDuration(getOrReturnDefaultValue("SHUTDOWN_TIMEOUT", "30s"))
+
 	return config
 }
 
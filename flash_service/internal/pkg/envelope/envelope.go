@@ -0,0 +1,62 @@
+// Package envelope builds and opens the Envelope every kafka message travels in.
+package envelope
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Producer is the name flash_service signs its envelopes with.
+const Producer = "flash_service"
+
+// TypeOf is the envelope type of payload.
+func TypeOf(payload proto.Message) string {
+	return string(proto.MessageName(payload))
+}
+
+// New wraps payload, which follows the given version of its schema.
+func New(payload proto.Message, version int32) (*pb.Envelope, error) {
+	data, err := protojson.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Envelope{
+		Id:         uuid.NewString(),
+		Type:       TypeOf(payload),
+		Version:    version,
+		Producer:   Producer,
+		ProducedAt: time.Now().UTC().Format(time.RFC3339Nano),
+		Payload:    data,
+	}, nil
+}
+
+// Marshal encodes env for the wire.
+func Marshal(env *pb.Envelope) ([]byte, error) {
+	return protojson.Marshal(env)
+}
+
+// Unmarshal decodes an envelope read off the wire.
+func Unmarshal(data []byte) (*pb.Envelope, error) {
+	var env pb.Envelope
+	if err := protojson.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+	if env.Type == "" {
+		return nil, fmt.Errorf("envelope has no type")
+	}
+	return &env, nil
+}
+
+// Open decodes the payload of env into payload, which must be of env's type.
+func Open(env *pb.Envelope, payload proto.Message) error {
+	if TypeOf(payload) != env.Type {
+		return fmt.Errorf("envelope holds %s, not %s", env.Type, TypeOf(payload))
+	}
+	return protojson.Unmarshal(env.Payload, payload)
+}
@@ -19,13 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	AuthService_Register_FullMethodName         = "/proto.AuthService/Register"
-	AuthService_Login_FullMethodName            = "/proto.AuthService/Login"
-	AuthService_ForgotPassword_FullMethodName   = "/proto.AuthService/ForgotPassword"
-	AuthService_ResetPassword_FullMethodName    = "/proto.AuthService/ResetPassword"
-	AuthService_SaveRefreshToken_FullMethodName = "/proto.AuthService/SaveRefreshToken"
-	AuthService_GetAllUsers_FullMethodName      = "/proto.AuthService/GetAllUsers"
-	AuthService_GEtUserById_FullMethodName      = "/proto.AuthService/GEtUserById"
+	AuthService_Register_FullMethodName           = "/proto.AuthService/Register"
+	AuthService_Login_FullMethodName              = "/proto.AuthService/Login"
+	AuthService_ForgotPassword_FullMethodName     = "/proto.AuthService/ForgotPassword"
+	AuthService_ResetPassword_FullMethodName      = "/proto.AuthService/ResetPassword"
+	AuthService_SaveRefreshToken_FullMethodName   = "/proto.AuthService/SaveRefreshToken"
+	AuthService_RotateRefreshToken_FullMethodName = "/proto.AuthService/RotateRefreshToken"
+	AuthService_RevokeRefreshToken_FullMethodName = "/proto.AuthService/RevokeRefreshToken"
+	AuthService_RevokeUserTokens_FullMethodName   = "/proto.AuthService/RevokeUserTokens"
+	AuthService_GetAllUsers_FullMethodName        = "/proto.AuthService/GetAllUsers"
+	AuthService_GEtUserById_FullMethodName        = "/proto.AuthService/GEtUserById"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -37,6 +40,9 @@ type AuthServiceClient interface {
 	ForgotPassword(ctx context.Context, in *GetByEmail, opts ...grpc.CallOption) (*Void, error)
 	ResetPassword(ctx context.Context, in *ResetPassReq, opts ...grpc.CallOption) (*Void, error)
 	SaveRefreshToken(ctx context.Context, in *RefToken, opts ...grpc.CallOption) (*Void, error)
+	RotateRefreshToken(ctx context.Context, in *RotateTokenReq, opts ...grpc.CallOption) (*User, error)
+	RevokeRefreshToken(ctx context.Context, in *RefreshReq, opts ...grpc.CallOption) (*Void, error)
+	RevokeUserTokens(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Void, error)
 	GetAllUsers(ctx context.Context, in *ListUserReq, opts ...grpc.CallOption) (*ListUserRes, error)
 	GEtUserById(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*UserRes, error)
 }
@@ -99,6 +105,36 @@ func (c *authServiceClient) SaveRefreshToken(ctx context.Context, in *RefToken,
 	return out, nil
 }
 
+func (c *authServiceClient) RotateRefreshToken(ctx context.Context, in *RotateTokenReq, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, AuthService_RotateRefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeRefreshToken(ctx context.Context, in *RefreshReq, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, AuthService_RevokeRefreshToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeUserTokens(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Void, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Void)
+	err := c.cc.Invoke(ctx, AuthService_RevokeUserTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) GetAllUsers(ctx context.Context, in *ListUserReq, opts ...grpc.CallOption) (*ListUserRes, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUserRes)
@@ -128,6 +164,9 @@ type AuthServiceServer interface {
 	ForgotPassword(context.Context, *GetByEmail) (*Void, error)
 	ResetPassword(context.Context, *ResetPassReq) (*Void, error)
 	SaveRefreshToken(context.Context, *RefToken) (*Void, error)
+	RotateRefreshToken(context.Context, *RotateTokenReq) (*User, error)
+	RevokeRefreshToken(context.Context, *RefreshReq) (*Void, error)
+	RevokeUserTokens(context.Context, *GetById) (*Void, error)
 	GetAllUsers(context.Context, *ListUserReq) (*ListUserRes, error)
 	GEtUserById(context.Context, *GetById) (*UserRes, error)
 	mustEmbedUnimplementedAuthServiceServer()
@@ -152,6 +191,15 @@ func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPassR
 func (UnimplementedAuthServiceServer) SaveRefreshToken(context.Context, *RefToken) (*Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveRefreshToken not implemented")
 }
+func (UnimplementedAuthServiceServer) RotateRefreshToken(context.Context, *RotateTokenReq) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateRefreshToken not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeRefreshToken(context.Context, *RefreshReq) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRefreshToken not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeUserTokens(context.Context, *GetById) (*Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
 func (UnimplementedAuthServiceServer) GetAllUsers(context.Context, *ListUserReq) (*ListUserRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllUsers not implemented")
 }
@@ -261,6 +309,60 @@ func _AuthService_SaveRefreshToken_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_RotateRefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RotateRefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RotateRefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RotateRefreshToken(ctx, req.(*RotateTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeRefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeRefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeRefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeRefreshToken(ctx, req.(*RefreshReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeUserTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeUserTokens(ctx, req.(*GetById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_GetAllUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUserReq)
 	if err := dec(in); err != nil {
@@ -324,6 +426,18 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SaveRefreshToken",
 			Handler:    _AuthService_SaveRefreshToken_Handler,
 		},
+		{
+			MethodName: "RotateRefreshToken",
+			Handler:    _AuthService_RotateRefreshToken_Handler,
+		},
+		{
+			MethodName: "RevokeRefreshToken",
+			Handler:    _AuthService_RevokeRefreshToken_Handler,
+		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _AuthService_RevokeUserTokens_Handler,
+		},
 		{
 			MethodName: "GetAllUsers",
 			Handler:    _AuthService_GetAllUsers_Handler,
@@ -33,7 +33,7 @@ type FlashSaleProductServiceClient interface {
 	CreateFlashSaleProduct(ctx context.Context, in *CreateFlashSaleProductReq, opts ...grpc.CallOption) (*Void, error)
 	UpdateFlashSaleProduct(ctx context.Context, in *UpdateFlashSaleProductReq, opts ...grpc.CallOption) (*Void, error)
 	ListAllFlashSaleProducts(ctx context.Context, in *ListAllFlashSaleProductsReq, opts ...grpc.CallOption) (*ListAllFlashSaleProductsRes, error)
-	GetFlashSaleProduct(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*FlashSaleProduct, error)
+	GetFlashSaleProduct(ctx context.Context, in *GetFlashSaleProductReq, opts ...grpc.CallOption) (*FlashSaleProduct, error)
 	DeleteFlashSaleProduct(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Void, error)
 }
 
@@ -75,7 +75,7 @@ func (c *flashSaleProductServiceClient) ListAllFlashSaleProducts(ctx context.Con
 	return out, nil
 }
 
-func (c *flashSaleProductServiceClient) GetFlashSaleProduct(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*FlashSaleProduct, error) {
+func (c *flashSaleProductServiceClient) GetFlashSaleProduct(ctx context.Context, in *GetFlashSaleProductReq, opts ...grpc.CallOption) (*FlashSaleProduct, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FlashSaleProduct)
 	err := c.cc.Invoke(ctx, FlashSaleProductService_GetFlashSaleProduct_FullMethodName, in, out, cOpts...)
@@ -102,7 +102,7 @@ type FlashSaleProductServiceServer interface {
 	CreateFlashSaleProduct(context.Context, *CreateFlashSaleProductReq) (*Void, error)
 	UpdateFlashSaleProduct(context.Context, *UpdateFlashSaleProductReq) (*Void, error)
 	ListAllFlashSaleProducts(context.Context, *ListAllFlashSaleProductsReq) (*ListAllFlashSaleProductsRes, error)
-	GetFlashSaleProduct(context.Context, *GetById) (*FlashSaleProduct, error)
+	GetFlashSaleProduct(context.Context, *GetFlashSaleProductReq) (*FlashSaleProduct, error)
 	DeleteFlashSaleProduct(context.Context, *GetById) (*Void, error)
 	mustEmbedUnimplementedFlashSaleProductServiceServer()
 }
@@ -120,7 +120,7 @@ func (UnimplementedFlashSaleProductServiceServer) UpdateFlashSaleProduct(context
 func (UnimplementedFlashSaleProductServiceServer) ListAllFlashSaleProducts(context.Context, *ListAllFlashSaleProductsReq) (*ListAllFlashSaleProductsRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAllFlashSaleProducts not implemented")
 }
-func (UnimplementedFlashSaleProductServiceServer) GetFlashSaleProduct(context.Context, *GetById) (*FlashSaleProduct, error) {
+func (UnimplementedFlashSaleProductServiceServer) GetFlashSaleProduct(context.Context, *GetFlashSaleProductReq) (*FlashSaleProduct, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFlashSaleProduct not implemented")
 }
 func (UnimplementedFlashSaleProductServiceServer) DeleteFlashSaleProduct(context.Context, *GetById) (*Void, error) {
@@ -195,7 +195,7 @@ func _FlashSaleProductService_ListAllFlashSaleProducts_Handler(srv interface{},
 }
 
 func _FlashSaleProductService_GetFlashSaleProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetById)
+	in := new(GetFlashSaleProductReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -207,7 +207,7 @@ func _FlashSaleProductService_GetFlashSaleProduct_Handler(srv interface{}, ctx c
 		FullMethod: FlashSaleProductService_GetFlashSaleProduct_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FlashSaleProductServiceServer).GetFlashSaleProduct(ctx, req.(*GetById))
+		return srv.(FlashSaleProductServiceServer).GetFlashSaleProduct(ctx, req.(*GetFlashSaleProductReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -19,13 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	OrderService_CreateOrder_FullMethodName     = "/proto.OrderService/CreateOrder"
-	OrderService_UpdateOrder_FullMethodName     = "/proto.OrderService/UpdateOrder"
-	OrderService_ListAllOrders_FullMethodName   = "/proto.OrderService/ListAllOrders"
-	OrderService_GetOrder_FullMethodName        = "/proto.OrderService/GetOrder"
-	OrderService_DeleteOrder_FullMethodName     = "/proto.OrderService/DeleteOrder"
-	OrderService_GetOrderHistory_FullMethodName = "/proto.OrderService/GetOrderHistory"
-	OrderService_CancelOrder_FullMethodName     = "/proto.OrderService/CancelOrder"
+	OrderService_CreateOrder_FullMethodName      = "/proto.OrderService/CreateOrder"
+	OrderService_UpdateOrder_FullMethodName      = "/proto.OrderService/UpdateOrder"
+	OrderService_ListAllOrders_FullMethodName    = "/proto.OrderService/ListAllOrders"
+	OrderService_GetOrder_FullMethodName         = "/proto.OrderService/GetOrder"
+	OrderService_DeleteOrder_FullMethodName      = "/proto.OrderService/DeleteOrder"
+	OrderService_GetOrderHistory_FullMethodName  = "/proto.OrderService/GetOrderHistory"
+	OrderService_CancelOrder_FullMethodName      = "/proto.OrderService/CancelOrder"
+	OrderService_GetOrderTimeline_FullMethodName = "/proto.OrderService/GetOrderTimeline"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -39,6 +40,7 @@ type OrderServiceClient interface {
 	DeleteOrder(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Void, error)
 	GetOrderHistory(ctx context.Context, in *OrderHistoryReq, opts ...grpc.CallOption) (*OrderHistoryRes, error)
 	CancelOrder(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*CancelOrderRes, error)
+	GetOrderTimeline(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*OrderTimeline, error)
 }
 
 type orderServiceClient struct {
@@ -119,6 +121,16 @@ func (c *orderServiceClient) CancelOrder(ctx context.Context, in *GetById, opts
 	return out, nil
 }
 
+func (c *orderServiceClient) GetOrderTimeline(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*OrderTimeline, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderTimeline)
+	err := c.cc.Invoke(ctx, OrderService_GetOrderTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility
@@ -130,6 +142,7 @@ type OrderServiceServer interface {
 	DeleteOrder(context.Context, *GetById) (*Void, error)
 	GetOrderHistory(context.Context, *OrderHistoryReq) (*OrderHistoryRes, error)
 	CancelOrder(context.Context, *GetById) (*CancelOrderRes, error)
+	GetOrderTimeline(context.Context, *GetById) (*OrderTimeline, error)
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -158,6 +171,9 @@ func (UnimplementedOrderServiceServer) GetOrderHistory(context.Context, *OrderHi
 func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *GetById) (*CancelOrderRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
 }
+func (UnimplementedOrderServiceServer) GetOrderTimeline(context.Context, *GetById) (*OrderTimeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderTimeline not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 
 // UnsafeOrderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -297,6 +313,24 @@ func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_GetOrderTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).GetOrderTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_GetOrderTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).GetOrderTimeline(ctx, req.(*GetById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -332,6 +366,10 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelOrder",
 			Handler:    _OrderService_CancelOrder_Handler,
 		},
+		{
+			MethodName: "GetOrderTimeline",
+			Handler:    _OrderService_GetOrderTimeline_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "flash_sale_submodule/orders.proto",
@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v3.12.4
+// source: flash_sale_submodule/refunds.proto
+
+package genproto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	RefundService_RequestRefund_FullMethodName = "/proto.RefundService/RequestRefund"
+	RefundService_GetRefund_FullMethodName     = "/proto.RefundService/GetRefund"
+	RefundService_ListRefunds_FullMethodName   = "/proto.RefundService/ListRefunds"
+	RefundService_ApproveRefund_FullMethodName = "/proto.RefundService/ApproveRefund"
+	RefundService_RejectRefund_FullMethodName  = "/proto.RefundService/RejectRefund"
+	RefundService_ProcessRefund_FullMethodName = "/proto.RefundService/ProcessRefund"
+)
+
+// RefundServiceClient is the client API for RefundService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Refunds move from pending to approved to processed, or from pending to
+// rejected. Processing writes a credit to the transaction ledger.
+type RefundServiceClient interface {
+	RequestRefund(ctx context.Context, in *RefundCreateReq, opts ...grpc.CallOption) (*Refund, error)
+	GetRefund(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Refund, error)
+	ListRefunds(ctx context.Context, in *RefundListReq, opts ...grpc.CallOption) (*RefundListRes, error)
+	// admin
+	ApproveRefund(ctx context.Context, in *RefundReviewReq, opts ...grpc.CallOption) (*Refund, error)
+	RejectRefund(ctx context.Context, in *RefundReviewReq, opts ...grpc.CallOption) (*Refund, error)
+	ProcessRefund(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Refund, error)
+}
+
+type refundServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRefundServiceClient(cc grpc.ClientConnInterface) RefundServiceClient {
+	return &refundServiceClient{cc}
+}
+
+func (c *refundServiceClient) RequestRefund(ctx context.Context, in *RefundCreateReq, opts ...grpc.CallOption) (*Refund, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refund)
+	err := c.cc.Invoke(ctx, RefundService_RequestRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) GetRefund(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Refund, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refund)
+	err := c.cc.Invoke(ctx, RefundService_GetRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) ListRefunds(ctx context.Context, in *RefundListReq, opts ...grpc.CallOption) (*RefundListRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundListRes)
+	err := c.cc.Invoke(ctx, RefundService_ListRefunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) ApproveRefund(ctx context.Context, in *RefundReviewReq, opts ...grpc.CallOption) (*Refund, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refund)
+	err := c.cc.Invoke(ctx, RefundService_ApproveRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) RejectRefund(ctx context.Context, in *RefundReviewReq, opts ...grpc.CallOption) (*Refund, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refund)
+	err := c.cc.Invoke(ctx, RefundService_RejectRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *refundServiceClient) ProcessRefund(ctx context.Context, in *GetById, opts ...grpc.CallOption) (*Refund, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Refund)
+	err := c.cc.Invoke(ctx, RefundService_ProcessRefund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RefundServiceServer is the server API for RefundService service.
+// All implementations must embed UnimplementedRefundServiceServer
+// for forward compatibility
+//
+// Refunds move from pending to approved to processed, or from pending to
+// rejected. Processing writes a credit to the transaction ledger.
+type RefundServiceServer interface {
+	RequestRefund(context.Context, *RefundCreateReq) (*Refund, error)
+	GetRefund(context.Context, *GetById) (*Refund, error)
+	ListRefunds(context.Context, *RefundListReq) (*RefundListRes, error)
+	// admin
+	ApproveRefund(context.Context, *RefundReviewReq) (*Refund, error)
+	RejectRefund(context.Context, *RefundReviewReq) (*Refund, error)
+	ProcessRefund(context.Context, *GetById) (*Refund, error)
+	mustEmbedUnimplementedRefundServiceServer()
+}
+
+// UnimplementedRefundServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRefundServiceServer struct {
+}
+
+func (UnimplementedRefundServiceServer) RequestRefund(context.Context, *RefundCreateReq) (*Refund, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestRefund not implemented")
+}
+func (UnimplementedRefundServiceServer) GetRefund(context.Context, *GetById) (*Refund, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefund not implemented")
+}
+func (UnimplementedRefundServiceServer) ListRefunds(context.Context, *RefundListReq) (*RefundListRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefunds not implemented")
+}
+func (UnimplementedRefundServiceServer) ApproveRefund(context.Context, *RefundReviewReq) (*Refund, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveRefund not implemented")
+}
+func (UnimplementedRefundServiceServer) RejectRefund(context.Context, *RefundReviewReq) (*Refund, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectRefund not implemented")
+}
+func (UnimplementedRefundServiceServer) ProcessRefund(context.Context, *GetById) (*Refund, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProcessRefund not implemented")
+}
+func (UnimplementedRefundServiceServer) mustEmbedUnimplementedRefundServiceServer() {}
+
+// UnsafeRefundServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RefundServiceServer will
+// result in compilation errors.
+type UnsafeRefundServiceServer interface {
+	mustEmbedUnimplementedRefundServiceServer()
+}
+
+func RegisterRefundServiceServer(s grpc.ServiceRegistrar, srv RefundServiceServer) {
+	s.RegisterService(&RefundService_ServiceDesc, srv)
+}
+
+func _RefundService_RequestRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundCreateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).RequestRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_RequestRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).RequestRefund(ctx, req.(*RefundCreateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_GetRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).GetRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_GetRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).GetRefund(ctx, req.(*GetById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_ListRefunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).ListRefunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_ListRefunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).ListRefunds(ctx, req.(*RefundListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_ApproveRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundReviewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).ApproveRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_ApproveRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).ApproveRefund(ctx, req.(*RefundReviewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_RejectRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundReviewReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).RejectRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_RejectRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).RejectRefund(ctx, req.(*RefundReviewReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RefundService_ProcessRefund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetById)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RefundServiceServer).ProcessRefund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RefundService_ProcessRefund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RefundServiceServer).ProcessRefund(ctx, req.(*GetById))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RefundService_ServiceDesc is the grpc.ServiceDesc for RefundService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RefundService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.RefundService",
+	HandlerType: (*RefundServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestRefund",
+			Handler:    _RefundService_RequestRefund_Handler,
+		},
+		{
+			MethodName: "GetRefund",
+			Handler:    _RefundService_GetRefund_Handler,
+		},
+		{
+			MethodName: "ListRefunds",
+			Handler:    _RefundService_ListRefunds_Handler,
+		},
+		{
+			MethodName: "ApproveRefund",
+			Handler:    _RefundService_ApproveRefund_Handler,
+		},
+		{
+			MethodName: "RejectRefund",
+			Handler:    _RefundService_RejectRefund_Handler,
+		},
+		{
+			MethodName: "ProcessRefund",
+			Handler:    _RefundService_ProcessRefund_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "flash_sale_submodule/refunds.proto",
+}
@@ -0,0 +1,56 @@
+package orderstate
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Order statuses, matching the order_type enum.
+const (
+	Pending   = "pending"
+	Confirmed = "confirmed"
+	Shipped   = "shipped"
+	Delivered = "delivered"
+	Canceled  = "canceled"
+	Refunded  = "refunded"
+)
+
+// transitions lists the statuses an order may move to from each status.
+// Orders can be canceled only before they ship and refunded only after they
+// were confirmed. Canceled and refunded are final.
+var transitions = map[string][]string{
+	Pending:   {Confirmed, Canceled},
+	Confirmed: {Shipped, Canceled, Refunded},
+	Shipped:   {Delivered, Refunded},
+	Delivered: {Refunded},
+}
+
+// Valid reports whether s is a known order status.
+func Valid(s string) bool {
+	switch s {
+	case Pending, Confirmed, Shipped, Delivered, Canceled, Refunded:
+		return true
+	}
+	return false
+}
+
+// CanTransition reports whether an order may move from one status to another.
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns a FailedPrecondition error when the transition is not allowed.
+func Check(from, to string) error {
+	if !Valid(to) {
+		return status.Errorf(codes.InvalidArgument, "unknown order status %q", to)
+	}
+	if !CanTransition(from, to) {
+		return status.Errorf(codes.FailedPrecondition, "order cannot move from %s to %s", from, to)
+	}
+	return nil
+}
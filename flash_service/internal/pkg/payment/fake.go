@@ -0,0 +1,173 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Modes of the fake provider.
+const (
+	Succeed = "succeed"
+	Decline = "decline"
+	Timeout = "timeout"
+)
+
+// Fake is an in-process provider for tests and offline runs. Depending on its
+// mode every authorization succeeds, is declined, or hangs until the context
+// is done. Webhooks are signed with a hex HMAC-SHA256 of the payload.
+type Fake struct {
+	mu       sync.Mutex
+	mode     string
+	secret   []byte
+	payments map[string]*Payment
+	keys     map[string]string
+}
+
+func NewFake(mode, secret string) (*Fake, error) {
+	f := &Fake{
+		secret:   []byte(secret),
+		payments: make(map[string]*Payment),
+		keys:     make(map[string]string),
+	}
+	if err := f.SetMode(mode); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SetMode switches how the following authorizations behave.
+func (f *Fake) SetMode(mode string) error {
+	switch mode {
+	case Succeed, Decline, Timeout:
+	default:
+		return fmt.Errorf("unknown fake payment mode %q", mode)
+	}
+
+	f.mu.Lock()
+	f.mode = mode
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *Fake) Name() string {
+	return "fake"
+}
+
+func (f *Fake) Authorize(ctx context.Context, req AuthorizeReq) (*Payment, error) {
+	f.mu.Lock()
+	mode := f.mode
+	if id, ok := f.keys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		p := *f.payments[id]
+		f.mu.Unlock()
+		return &p, nil
+	}
+	f.mu.Unlock()
+
+	switch mode {
+	case Decline:
+		return nil, fmt.Errorf("%w: insufficient funds", ErrDeclined)
+	case Timeout:
+		<-ctx.Done()
+		return nil, fmt.Errorf("%w: %v", ErrTimeout, ctx.Err())
+	}
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrDeclined)
+	}
+
+	p := &Payment{ID: uuid.NewString(), Status: StatusAuthorized, Amount: req.Amount}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.payments[p.ID] = p
+	if req.IdempotencyKey != "" {
+		f.keys[req.IdempotencyKey] = p.ID
+	}
+	res := *p
+	return &res, nil
+}
+
+func (f *Fake) Capture(ctx context.Context, paymentID string, amount float32) (*Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("payment %s not found", paymentID)
+	}
+	if p.Status == StatusCaptured && p.Captured == amount {
+		res := *p
+		return &res, nil
+	}
+	if p.Status != StatusAuthorized {
+		return nil, fmt.Errorf("payment %s is %s and can't be captured", paymentID, p.Status)
+	}
+	if amount > p.Amount {
+		return nil, fmt.Errorf("capture of %.2f exceeds the authorized %.2f", amount, p.Amount)
+	}
+
+	p.Status = StatusCaptured
+	p.Captured = amount
+	res := *p
+	return &res, nil
+}
+
+func (f *Fake) Refund(ctx context.Context, paymentID string, amount float32) (*Payment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[paymentID]
+	if !ok {
+		return nil, fmt.Errorf("payment %s not found", paymentID)
+	}
+
+	switch p.Status {
+	case StatusAuthorized:
+		// nothing was charged yet, so the authorization is just dropped
+		p.Status = StatusRefunded
+	case StatusCaptured:
+		if p.Refunded+amount > p.Captured {
+			return nil, fmt.Errorf("refund of %.2f exceeds the %.2f left on payment %s", amount, p.Captured-p.Refunded, paymentID)
+		}
+		p.Refunded += amount
+		if p.Refunded == p.Captured {
+			p.Status = StatusRefunded
+		}
+	default:
+		return nil, fmt.Errorf("payment %s is %s and can't be refunded", paymentID, p.Status)
+	}
+
+	res := *p
+	return &res, nil
+}
+
+func (f *Fake) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, f.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding webhook: %w", err)
+	}
+	return &event, nil
+}
+
+// Sign returns the signature the fake expects on a webhook payload, so tests
+// can send it webhooks.
+func (f *Fake) Sign(payload []byte) string {
+	return hex.EncodeToString(f.sign(payload))
+}
+
+func (f *Fake) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,74 @@
+// Package payment talks to the payment provider that charges buyers at checkout.
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDeclined means the provider refused the payment; retrying won't help.
+	ErrDeclined = errors.New("payment declined")
+	// ErrTimeout means the provider did not answer in time and the outcome is unknown.
+	ErrTimeout = errors.New("payment provider timed out")
+	// ErrInvalidSignature means a webhook did not come from the provider.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Payment statuses, as reported by a provider and stored in payments.status.
+const (
+	StatusAuthorized = "authorized"
+	StatusCaptured   = "captured"
+	StatusRefunded   = "refunded"
+	StatusDeclined   = "declined"
+)
+
+type AuthorizeReq struct {
+	// IdempotencyKey makes a retried authorization return the first one.
+	IdempotencyKey string
+	OrderID        string
+	UserID         string
+	Amount         float32
+	// Token identifies the buyer's payment method at the provider.
+	Token string
+}
+
+// Payment is the provider's view of a payment.
+type Payment struct {
+	ID       string
+	Status   string
+	Amount   float32
+	Captured float32
+	Refunded float32
+}
+
+// WebhookEvent is a payment update the provider pushed to us.
+type WebhookEvent struct {
+	Type      string  `json:"type"`
+	PaymentID string  `json:"payment_id"`
+	Status    string  `json:"status"`
+	Amount    float32 `json:"amount"`
+}
+
+type PaymentProvider interface {
+	// Name identifies the provider in payments.provider.
+	Name() string
+	// Authorize holds amount on the buyer's payment method.
+	Authorize(ctx context.Context, req AuthorizeReq) (*Payment, error)
+	// Capture charges an authorized payment.
+	Capture(ctx context.Context, paymentID string, amount float32) (*Payment, error)
+	// Refund gives amount of a captured payment back, or voids an uncaptured one.
+	Refund(ctx context.Context, paymentID string, amount float32) (*Payment, error)
+	// VerifyWebhook checks the signature of a webhook and decodes it.
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// New returns the provider called name. Only the in-process fake exists so far.
+func New(name, fakeMode, webhookSecret string) (PaymentProvider, error) {
+	switch name {
+	case "fake":
+		return NewFake(fakeMode, webhookSecret)
+	}
+	return nil, fmt.Errorf("unknown payment provider %q", name)
+}
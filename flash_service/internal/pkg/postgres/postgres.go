@@ -12,14 +12,18 @@ type Postgres struct {
 	DB *sql.DB
 }
 
-func New(cfg *config.Config) (*Postgres, error) {
-	dbConn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
+// ConnString returns the connection url of the database in cfg.
+func ConnString(cfg *config.Config) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable",
 		cfg.PostgresUser,
 		cfg.PostgresPassword,
 		cfg.PostgresHost,
 		cfg.PostgresPort,
 		cfg.PostgresDatabase)
-	db, err := sql.Open("postgres", dbConn)
+}
+
+func New(cfg *config.Config) (*Postgres, error) {
+	db, err := sql.Open("postgres", ConnString(cfg))
 	if err != nil {
 		return nil, err
 	}
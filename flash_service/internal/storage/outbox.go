@@ -0,0 +1,22 @@
+package storage
+
+// Domain event topics. Events are written to the outbox in the transaction of
+// the change they describe and relayed to kafka from there.
+const (
+	OrderCreatedTopic       = "order-created"
+	OrderCanceledTopic      = "order-canceled"
+	FlashSaleStartedTopic   = "flash-sale-started"
+	FlashSaleCompletedTopic = "flash-sale-completed"
+	StockDepletedTopic      = "stock-depleted"
+	ReviewPostedTopic       = "review-posted"
+)
+
+// OutboxEvent is a domain event waiting in the outbox to be published.
+// AggregateID is the order, sale or product the event is about.
+type OutboxEvent struct {
+	ID          int64
+	Topic       string
+	AggregateID string
+	Payload     []byte
+	Attempts    int
+}
@@ -1,41 +1,45 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewAuthRepo(db *sql.DB) *AuthRepo {
 	return &AuthRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (r *AuthRepo) Register(req *pb.RegisterReq) (*pb.Void, error) {
+func (r *AuthRepo) Register(ctx context.Context, req *pb.RegisterReq) (*pb.Void, error) {
 	res := &pb.Void{}
 
-	tr, err := r.db.Begin()
+	tr, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	var id string
 	query := `INSERT INTO users (username, email, password, full_name, date_of_birth) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	err = tr.QueryRow(query, req.Username, req.Email, req.Password, req.FullName, req.DateOfBirth).Scan(&id)
+	err = tr.QueryRowContext(ctx, query, req.Username, req.Email, req.Password, req.FullName, req.DateOfBirth).Scan(&id)
 	if err != nil {
 		tr.Rollback()
 		return nil, err
 	}
 
 	query = `INSERT INTO settings (user_id) VALUES ($1)`
-	_, err = tr.Exec(query, id)
+	_, err = tr.ExecContext(ctx, query, id)
 	if err != nil {
 		tr.Rollback()
 		return nil, err
@@ -50,12 +54,12 @@ func (r *AuthRepo) Register(req *pb.RegisterReq) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *AuthRepo) Login(req *pb.LoginReq) (*pb.User, error) {
+func (r *AuthRepo) Login(ctx context.Context, req *pb.LoginReq) (*pb.User, error) {
 	res := &pb.User{}
 
 	var passwordHash string
 	query := `SELECT id, username, email, role, password FROM users WHERE username = $1`
-	err := r.db.QueryRow(query, req.Username).Scan(
+	err := r.db.QueryRowContext(ctx, query, req.Username).Scan(
 		&res.Id,
 		&res.Username,
 		&res.Email,
@@ -75,13 +79,13 @@ func (r *AuthRepo) Login(req *pb.LoginReq) (*pb.User, error) {
 
 	return res, nil
 }
-func (r *AuthRepo) ForgotPassword(req *pb.GetByEmail) (*pb.Void, error) {
+func (r *AuthRepo) ForgotPassword(ctx context.Context, req *pb.GetByEmail) (*pb.Void, error) {
 	res := &pb.Void{}
 
 	query := `SELECT email FROM users WHERE email = $1`
 
 	var email string
-	err := r.db.QueryRow(query, req.Email).Scan(&email)
+	err := r.db.QueryRowContext(ctx, query, req.Email).Scan(&email)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -93,12 +97,12 @@ func (r *AuthRepo) ForgotPassword(req *pb.GetByEmail) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *AuthRepo) ResetPassword(req *pb.ResetPassReq) (*pb.Void, error) {
+func (r *AuthRepo) ResetPassword(ctx context.Context, req *pb.ResetPassReq) (*pb.Void, error) {
 	res := &pb.Void{}
 
 	query := `UPDATE users SET password = $1, updated_at=now() WHERE email = $2`
 
-	_, err := r.db.Exec(query, req.NewPassword, req.Email)
+	_, err := r.db.ExecContext(ctx, query, req.NewPassword, req.Email)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +110,12 @@ func (r *AuthRepo) ResetPassword(req *pb.ResetPassReq) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *AuthRepo) SaveRefreshToken(req *pb.RefToken) (*pb.Void, error) {
+func (r *AuthRepo) SaveRefreshToken(ctx context.Context, req *pb.RefToken) (*pb.Void, error) {
 	res := &pb.Void{}
 
 	query := `INSERT INTO tokens (user_id, token) VALUES ($1, $2)`
 
-	_, err := r.db.Exec(query, req.UserId, req.Token)
+	_, err := r.db.ExecContext(ctx, query, req.UserId, req.Token)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +123,91 @@ func (r *AuthRepo) SaveRefreshToken(req *pb.RefToken) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *AuthRepo) GetAllUsers(req *pb.ListUserReq) (*pb.ListUserRes, error) {
+// RotateRefreshToken swaps req.Token for req.NewToken in the same family and
+// returns the user to issue the new pair for. A token that was rotated
+// before is being reused, by a thief or by its owner after a thief refreshed
+// first; the whole family is revoked then, so both have to log in again.
+func (r *AuthRepo) RotateRefreshToken(ctx context.Context, req *pb.RotateTokenReq) (*pb.User, error) {
+	tr, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tr.Rollback()
+
+	var (
+		id, userID, familyID string
+		rotatedAt            sql.NullTime
+		deletedAt            int64
+	)
+	query := `SELECT id, user_id, family_id, rotated_at, deleted_at FROM tokens WHERE token = $1 FOR UPDATE`
+	err = tr.QueryRowContext(ctx, query, req.Token).Scan(&id, &userID, &familyID, &rotatedAt, &deletedAt)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.Unauthenticated, "refresh token not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if deletedAt != 0 {
+		return nil, status.Error(codes.Unauthenticated, "refresh token revoked")
+	}
+
+	if rotatedAt.Valid {
+		query = `UPDATE tokens SET deleted_at = extract(epoch from now()), updated_at = now() WHERE family_id = $1 AND deleted_at = 0`
+		if _, err = tr.ExecContext(ctx, query, familyID); err != nil {
+			return nil, err
+		}
+		if err = tr.Commit(); err != nil {
+			return nil, err
+		}
+		log.Printf("Refresh token of user %s reused, revoked token family %s", userID, familyID)
+		return nil, status.Error(codes.Unauthenticated, "refresh token reused, all sessions revoked")
+	}
+
+	query = `UPDATE tokens SET rotated_at = now(), updated_at = now() WHERE id = $1`
+	if _, err = tr.ExecContext(ctx, query, id); err != nil {
+		return nil, err
+	}
+
+	query = `INSERT INTO tokens (user_id, token, family_id) VALUES ($1, $2, $3)`
+	if _, err = tr.ExecContext(ctx, query, userID, req.NewToken, familyID); err != nil {
+		return nil, err
+	}
+
+	res := &pb.User{}
+	query = `SELECT id, username, email, role FROM users WHERE id = $1 AND deleted_at = 0`
+	err = tr.QueryRowContext(ctx, query, userID).Scan(&res.Id, &res.Username, &res.Email, &res.Role)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.Unauthenticated, "user not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = tr.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RevokeRefreshToken logs out the session req.RefreshToken belongs to by
+// revoking its family. Unknown and revoked tokens are left as they are.
+func (r *AuthRepo) RevokeRefreshToken(ctx context.Context, req *pb.RefreshReq) (*pb.Void, error) {
+	query := `UPDATE tokens SET deleted_at = extract(epoch from now()), updated_at = now()
+		WHERE family_id = (SELECT family_id FROM tokens WHERE token = $1) AND deleted_at = 0`
+	if _, err := r.db.ExecContext(ctx, query, req.RefreshToken); err != nil {
+		return nil, err
+	}
+	return &pb.Void{}, nil
+}
+
+// RevokeUserTokens logs the user out of every session.
+func (r *AuthRepo) RevokeUserTokens(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
+	query := `UPDATE tokens SET deleted_at = extract(epoch from now()), updated_at = now() WHERE user_id = $1 AND deleted_at = 0`
+	if _, err := r.db.ExecContext(ctx, query, req.Id); err != nil {
+		return nil, err
+	}
+	return &pb.Void{}, nil
+}
+
+func (r *AuthRepo) GetAllUsers(ctx context.Context, req *pb.ListUserReq) (*pb.ListUserRes, error) {
 	res := &pb.ListUserRes{}
 
 	query := `SELECT 
@@ -149,7 +237,7 @@ func (r *AuthRepo) GetAllUsers(req *pb.ListUserReq) (*pb.ListUserRes, error) {
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
 	args = append(args, req.Pagination.Limit, req.Pagination.Offset)
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,11 +262,10 @@ func (r *AuthRepo) GetAllUsers(req *pb.ListUserReq) (*pb.ListUserRes, error) {
 	return res, nil
 }
 
-
-func (r *AuthRepo) GetUserById(req *pb.GetById) (*pb.UserRes, error) {
+func (r *AuthRepo) GetUserById(ctx context.Context, req *pb.GetById) (*pb.UserRes, error) {
 	res := &pb.UserRes{}
 
-    query := `SELECT 
+	query := `SELECT 
                 id, 
                 username, 
                 full_name,
@@ -190,19 +277,19 @@ func (r *AuthRepo) GetUserById(req *pb.GetById) (*pb.UserRes, error) {
             WHERE 
                 id = $1 AND deleted_at=0`
 
-    err := r.db.QueryRow(query, req.Id).Scan(
-        &res.Id,
-        &res.Username,
-        &res.FullName,
-        &res.Email,
-        &res.DateOfBirth,
-        &res.Role,
-    )
-    if err == sql.ErrNoRows {
-        return nil, fmt.Errorf("user not found")
-    } else if err!= nil {
-        return nil, err
-    }
-
-    return res, nil
-}
\ No newline at end of file
+	err := r.db.QueryRowContext(ctx, query, req.Id).Scan(
+		&res.Id,
+		&res.Username,
+		&res.FullName,
+		&res.Email,
+		&res.DateOfBirth,
+		&res.Role,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("user not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
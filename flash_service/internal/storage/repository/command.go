@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CommandRepo struct {
+	db *conn
+}
+
+func NewCommandRepo(db *sql.DB) *CommandRepo {
+	return &CommandRepo{
+		db: newConn(db),
+	}
+}
+
+const commandColumns = `
+			id,
+			topic,
+			status,
+			error,
+			attempts,
+			created_at,
+			updated_at,
+			user_id`
+
+// RecordCommand saves the outcome of one attempt at handling a command and
+// fills in the attempts counted so far and the timestamps.
+func (r *CommandRepo) RecordCommand(ctx context.Context, cmd *pb.CommandStatus) error {
+	res, err := scanCommand(r.db.QueryRowContext(ctx, `
+		INSERT INTO
+			commands
+			(id,
+			topic,
+			status,
+			error,
+			attempts,
+			user_id)
+			VALUES
+			($1, $2, $3, $4, 1, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			error = EXCLUDED.error,
+			attempts = commands.attempts + 1,
+			updated_at = NOW()
+		RETURNING`+commandColumns, cmd.Id, cmd.Topic, cmd.Status, nullString(cmd.Error), nullString(cmd.UserId)))
+	if err != nil {
+		return err
+	}
+
+	cmd.Attempts = res.Attempts
+	cmd.CreatedAt = res.CreatedAt
+	cmd.UpdatedAt = res.UpdatedAt
+	return nil
+}
+
+// GetCommandStatus finds a command req.UserId sent, commands of anybody else
+// are not found.
+func (r *CommandRepo) GetCommandStatus(ctx context.Context, req *pb.CommandStatusReq) (*pb.CommandStatus, error) {
+	cmd, err := scanCommand(r.db.QueryRowContext(ctx, `SELECT`+commandColumns+`
+		FROM
+			commands
+		WHERE
+			id = $1
+		AND
+			COALESCE(user_id, '') = $2`, req.Id, req.UserId))
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "command not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func scanCommand(row rowScanner) (*pb.CommandStatus, error) {
+	var (
+		cmd      pb.CommandStatus
+		errorMsg sql.NullString
+		userID   sql.NullString
+	)
+	err := row.Scan(
+		&cmd.Id,
+		&cmd.Topic,
+		&cmd.Status,
+		&errorMsg,
+		&cmd.Attempts,
+		&cmd.CreatedAt,
+		&cmd.UpdatedAt,
+		&userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.Error = errorMsg.String
+	cmd.UserId = userID.String
+	return &cmd, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -8,25 +9,34 @@ import (
 	"time"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/orderstate"
+	"github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// flashSaleSchedulerLock is the advisory lock key that lets only one replica
+// advance flash sale statuses at a time.
+const flashSaleSchedulerLock = 7270031
+
+// updateTimeLayout matches the timestamps the flash_sale_updates triggers send.
+const updateTimeLayout = "2006-01-02T15:04:05"
+
 type FlashSaleRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewFlashSaleRepo(db *sql.DB) *FlashSaleRepo {
 	return &FlashSaleRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (r *FlashSaleRepo) CreateFlashSale(req *pb.CreateFlashSalesReq) (*pb.Void, error) {
-    id := uuid.NewString()
+func (r *FlashSaleRepo) CreateFlashSale(ctx context.Context, req *pb.CreateFlashSalesReq) (*pb.Void, error) {
+	id := uuid.NewString()
 
-    query := `INSERT INTO flash_sales 
+	query := `INSERT INTO flash_sales 
               (id, 
               name, 
               start_time, 
@@ -34,21 +44,20 @@ func (r *FlashSaleRepo) CreateFlashSale(req *pb.CreateFlashSalesReq) (*pb.Void,
               status, 
               latitude, 
               longitude, 
-              address) 
+              address,
+              per_user_limit) 
               VALUES 
-              ($1, $2, $3, $4, $5, $6, $7, $8)`
+              ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-  
-    _, err := r.db.Exec(query, id, req.Name,req.StartTime, req.EndTime, req.Status, nil, nil, nil)
-    if err != nil {
-        return nil, err
-    }
+	_, err := r.db.ExecContext(ctx, query, id, req.Name, req.StartTime, req.EndTime, req.Status, nil, nil, nil, req.PerUserLimit)
+	if err != nil {
+		return nil, err
+	}
 
-    return &pb.Void{}, nil
+	return &pb.Void{}, nil
 }
 
-
-func (r *FlashSaleRepo) UpdateFlashSale(req *pb.UpdateFlashSalesReq) (*pb.Void, error) {
+func (r *FlashSaleRepo) UpdateFlashSale(ctx context.Context, req *pb.UpdateFlashSalesReq) (*pb.Void, error) {
 	var args []interface{}
 	var conditions []string
 
@@ -72,13 +81,18 @@ func (r *FlashSaleRepo) UpdateFlashSale(req *pb.UpdateFlashSalesReq) (*pb.Void,
 		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
 	}
 
+	if req.Body.PerUserLimit != 0 {
+		args = append(args, max(req.Body.PerUserLimit, 0))
+		conditions = append(conditions, fmt.Sprintf("per_user_limit = $%d", len(args)))
+	}
+
 	if len(conditions) > 0 {
 		args = append(args, time.Now())
 		conditions = append(conditions, fmt.Sprintf("updated_at = $%d", len(args)))
 		args = append(args, req.Id)
 
 		query := `UPDATE flash_sales SET ` + strings.Join(conditions, ", ") + ` WHERE id = $` + fmt.Sprintf("%d", len(args))
-		_, err := r.db.Exec(query, args...)
+		_, err := r.db.ExecContext(ctx, query, args...)
 		if err != nil {
 			log.Println("Error while updating flash_sales", err)
 			return nil, err
@@ -88,7 +102,7 @@ func (r *FlashSaleRepo) UpdateFlashSale(req *pb.UpdateFlashSalesReq) (*pb.Void,
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleRepo) GetFlashSale(req *pb.GetById) (*pb.FlashSale, error) {
+func (r *FlashSaleRepo) GetFlashSale(ctx context.Context, req *pb.GetById) (*pb.FlashSale, error) {
 	query := `
 			SELECT 
 				id,
@@ -96,7 +110,8 @@ func (r *FlashSaleRepo) GetFlashSale(req *pb.GetById) (*pb.FlashSale, error) {
 				start_time,
 				end_time,
 				status,
-				created_at
+				created_at,
+				per_user_limit
 			FROM
 				flash_sales
 			WHERE
@@ -104,7 +119,7 @@ func (r *FlashSaleRepo) GetFlashSale(req *pb.GetById) (*pb.FlashSale, error) {
 			AND 
 				deleted_at = 0`
 
-	row := r.db.QueryRow(query, req.Id)
+	row := r.db.QueryRowContext(ctx, query, req.Id)
 
 	res := &pb.FlashSale{}
 	err := row.Scan(
@@ -114,6 +129,7 @@ func (r *FlashSaleRepo) GetFlashSale(req *pb.GetById) (*pb.FlashSale, error) {
 		&res.EndTime,
 		&res.Status,
 		&res.CreatedAt,
+		&res.PerUserLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -122,7 +138,7 @@ func (r *FlashSaleRepo) GetFlashSale(req *pb.GetById) (*pb.FlashSale, error) {
 	return res, nil
 }
 
-func (r *FlashSaleRepo) DeleteFlashSale(req *pb.GetById) (*pb.Void, error) {
+func (r *FlashSaleRepo) DeleteFlashSale(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
 	query := `
 			UPDATE
 				flash_sales
@@ -131,14 +147,14 @@ func (r *FlashSaleRepo) DeleteFlashSale(req *pb.GetById) (*pb.Void, error) {
 			WHERE
 				id = $1`
 
-	_, err := r.db.Exec(query, req.Id)
+	_, err := r.db.ExecContext(ctx, query, req.Id)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleRepo) ListAllFlashSales(req *pb.ListAllFlashSalesReq) (*pb.ListAllFlashSalesRes, error) {
+func (r *FlashSaleRepo) ListAllFlashSales(ctx context.Context, req *pb.ListAllFlashSalesReq) (*pb.ListAllFlashSalesRes, error) {
 	query := `
 			SELECT 
 				id,
@@ -162,7 +178,7 @@ func (r *FlashSaleRepo) ListAllFlashSales(req *pb.ListAllFlashSalesReq) (*pb.Lis
 		query += ` AND status = $2`
 	}
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -194,8 +210,8 @@ func (r *FlashSaleRepo) ListAllFlashSales(req *pb.ListAllFlashSalesReq) (*pb.Lis
 	}, nil
 }
 
-func (r *FlashSaleRepo) AddProductToFlashSale(req *pb.AddProductReq) (*pb.Void, error) {
-	_, err := r.db.Exec(`INSERT INTO flash_sale_products (flash_sale_id, product_id, added_at) VALUES ($1, $2, NOW())`,
+func (r *FlashSaleRepo) AddProductToFlashSale(ctx context.Context, req *pb.AddProductReq) (*pb.Void, error) {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO flash_sale_products (flash_sale_id, product_id, added_at) VALUES ($1, $2, NOW())`,
 		req.FlashSaleId, req.Product.Id)
 	if err != nil {
 		return nil, err
@@ -203,8 +219,8 @@ func (r *FlashSaleRepo) AddProductToFlashSale(req *pb.AddProductReq) (*pb.Void,
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleRepo) RemoveProductFromFlashSale(req *pb.RemoveProductReq) (*pb.Void, error) {
-	_, err := r.db.Exec(`DELETE FROM flash_sale_products WHERE flash_sale_id = $1 AND product_id = $2`,
+func (r *FlashSaleRepo) RemoveProductFromFlashSale(ctx context.Context, req *pb.RemoveProductReq) (*pb.Void, error) {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM flash_sale_products WHERE flash_sale_id = $1 AND product_id = $2`,
 		req.FlashSaleId, req.ProductId)
 	if err != nil {
 		return nil, err
@@ -212,36 +228,153 @@ func (r *FlashSaleRepo) RemoveProductFromFlashSale(req *pb.RemoveProductReq) (*p
 	return &pb.Void{}, nil
 }
 
-	func (r *FlashSaleRepo) CancelFlashSale(req *pb.GetById) (*pb.CancelFlashSaleRes, error) {
-		tx, err := r.db.Begin()
-		if err != nil {
+// CancelFlashSale cancels a sale together with everything hanging off it: its
+// open orders are canceled and refunded, the sale stops offering any stock and
+// every affected buyer gets a notification. The products' own stock is left
+// alone: allocating stock to a sale never took it from there.
+// It runs inside the caller's unit of work, see Storage.WithTx.
+func (r *FlashSaleRepo) CancelFlashSale(ctx context.Context, req *pb.GetById) (*pb.CancelFlashSaleRes, error) {
+	if err := r.db.unitOfWork("CancelFlashSale"); err != nil {
+		return nil, err
+	}
+
+	var (
+		name          string
+		currentStatus string
+	)
+	err := r.db.QueryRowContext(ctx, `SELECT name, status FROM flash_sales WHERE id = $1 AND deleted_at = 0 FOR UPDATE`, req.Id).Scan(&name, &currentStatus)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "flash sale not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch currentStatus {
+	case "canceled":
+		// a retried cancellation gets the outcome of the first one
+		res := &pb.CancelFlashSaleRes{}
+		err = r.db.QueryRowContext(ctx, `SELECT id FROM flash_sale_cancellations WHERE flash_sale_id = $1 ORDER BY created_at DESC LIMIT 1`, req.Id).Scan(&res.CancellationStatus)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if res.RefundInfo, err = flashSaleRefunds(ctx, r.db, req.Id); err != nil {
 			return nil, err
 		}
-		defer tx.Rollback()
+		return res, nil
+	case "completed":
+		return nil, status.Errorf(codes.FailedPrecondition, "flash sale is already completed")
+	}
 
-		_, err = tx.Exec(`UPDATE flash_sales SET status = 'canceled', updated_at = NOW() WHERE id = $1`, req.Id)
+	_, err = r.db.ExecContext(ctx, `UPDATE flash_sales SET status = 'canceled', updated_at = NOW() WHERE id = $1`, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := openFlashSaleOrders(ctx, r.db, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &pb.CancelFlashSaleRes{RefundInfo: make([]*pb.Refund, 0)}
+	canceled := make(map[string][]string)
+	for _, order := range orders {
+		_, err = r.db.ExecContext(ctx, `UPDATE orders SET status = 'canceled', updated_at = NOW() WHERE id = $1`, order.id)
+		if err != nil {
+			return nil, err
+		}
+		if err = releaseOrderStock(ctx, r.db, order.id); err != nil {
+			return nil, err
+		}
+		_, err = r.db.ExecContext(ctx, `UPDATE reservations SET status = 'released', updated_at = NOW() WHERE order_id = $1 AND status = 'held'`, order.id)
 		if err != nil {
 			return nil, err
 		}
+		if err = trackOrderStatus(ctx, r.db, order.id, orderstate.Canceled, "", ""); err != nil {
+			return nil, err
+		}
+		if err = orderCanceled(ctx, r.db, order.id, "flash sale canceled"); err != nil {
+			return nil, err
+		}
 
-		var cancellationID string
-		err = tx.QueryRow(`INSERT INTO flash_sale_cancellations (flash_sale_id, cancellation_status, created_at) VALUES ($1, 'canceled', NOW()) RETURNING id`,
-			req.Id).Scan(&cancellationID)
+		refund, err := createRefund(ctx, r.db, order.id, 0, "flash sale canceled")
 		if err != nil {
 			return nil, err
 		}
+		if refund != nil {
+			res.RefundInfo = append(res.RefundInfo, refund)
+		}
+		canceled[order.userID] = append(canceled[order.userID], order.id)
+	}
+
+	// the released order stock and whatever was never sold leave the sale
+	_, err = r.db.ExecContext(ctx, `UPDATE flash_sales_products SET available_quantity = 0, updated_at = NOW() WHERE flash_sale_id = $1 AND deleted_at = 0`, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, order := range orders {
+		orderIDs, ok := canceled[order.userID]
+		if !ok {
+			continue
+		}
+		delete(canceled, order.userID)
 
-		if err = tx.Commit(); err != nil {
+		content := fmt.Sprintf("Flash sale %q was canceled. Your orders %s were canceled and anything you paid will be refunded.", name, strings.Join(orderIDs, ", "))
+		if _, err = queueNotification(ctx, r.db, order.userID, "email", content); err != nil {
 			return nil, err
 		}
+	}
 
-		return &pb.CancelFlashSaleRes{CancellationStatus: cancellationID}, nil
+	err = r.db.QueryRowContext(ctx, `INSERT INTO flash_sale_cancellations (flash_sale_id, cancellation_status, created_at) VALUES ($1, 'canceled', NOW()) RETURNING id`,
+		req.Id).Scan(&res.CancellationStatus)
+	if err != nil {
+		return nil, err
 	}
 
+	return res, nil
+}
+
+type saleOrder struct {
+	id     string
+	userID string
+}
 
-func (s *FlashSaleRepo) GetStoreLocation(req *pb.GetStoreLocationReq) (*pb.StoreLocation, error) {
+// openFlashSaleOrders locks the orders of a sale that can still be canceled.
+func openFlashSaleOrders(ctx context.Context, tx dbtx, flashSaleID string) ([]saleOrder, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			user_id
+		FROM
+			orders
+		WHERE
+			flash_sale_id = $1
+		AND
+			status IN ('pending', 'confirmed')
+		AND
+			deleted_at = 0
+		ORDER BY
+			id
+		FOR UPDATE`, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []saleOrder
+	for rows.Next() {
+		var order saleOrder
+		if err := rows.Scan(&order.id, &order.userID); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (s *FlashSaleRepo) GetStoreLocation(ctx context.Context, req *pb.GetStoreLocationReq) (*pb.StoreLocation, error) {
 	var store pb.StoreLocation
-	err := s.db.QueryRow(`
+	err := s.db.QueryRowContext(ctx, `
         SELECT store_id, name, address, latitude, longitude
         FROM stores
         WHERE store_id = $1`, req.StoreId).Scan(
@@ -255,3 +388,184 @@ func (s *FlashSaleRepo) GetStoreLocation(req *pb.GetStoreLocationReq) (*pb.Store
 
 	return &store, nil
 }
+
+// AdvanceFlashSales activates pending sales whose start_time has passed and
+// completes sales whose end_time has passed, writing a FlashSaleStarted or
+// FlashSaleCompleted to the outbox for each. It returns the changes made, none
+// when another replica is already doing the same work.
+func (r *FlashSaleRepo) AdvanceFlashSales(ctx context.Context, now time.Time) ([]*pb.FlashSaleStatusEvent, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1)`, flashSaleSchedulerLock).Scan(&locked); err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	events := make([]*pb.FlashSaleStatusEvent, 0)
+
+	activate := `
+		UPDATE
+			flash_sales
+		SET
+			status = 'active',
+			updated_at = $1
+		WHERE
+			status = 'pending'
+		AND
+			start_time <= $1
+		AND
+			end_time > $1
+		AND
+			deleted_at = 0
+		RETURNING
+			id,
+			name`
+
+	rows, err := tx.QueryContext(ctx, activate, now)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		event := pb.FlashSaleStatusEvent{FromStatus: "pending", ToStatus: "active", ChangedAt: now.Format(time.RFC3339)}
+		if err := rows.Scan(&event.FlashSaleId, &event.Name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		err = addEvent(ctx, tx, storage.FlashSaleStartedTopic, event.FlashSaleId, &pb.FlashSaleStarted{
+			FlashSaleId: event.FlashSaleId,
+			Name:        event.Name,
+			StartedAt:   event.ChangedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	complete := `
+		WITH due AS (
+			SELECT
+				id,
+				status
+			FROM
+				flash_sales
+			WHERE
+				status IN ('pending', 'active')
+			AND
+				end_time <= $1
+			AND
+				deleted_at = 0
+			FOR UPDATE
+		)
+		UPDATE
+			flash_sales f
+		SET
+			status = 'completed',
+			updated_at = $1
+		FROM
+			due
+		WHERE
+			f.id = due.id
+		RETURNING
+			f.id,
+			f.name,
+			due.status`
+
+	completed := make([]*pb.FlashSaleStatusEvent, 0)
+	rows, err = tx.QueryContext(ctx, complete, now)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		event := pb.FlashSaleStatusEvent{ToStatus: "completed", ChangedAt: now.Format(time.RFC3339)}
+		if err := rows.Scan(&event.FlashSaleId, &event.Name, &event.FromStatus); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		completed = append(completed, &event)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, event := range completed {
+		err = addEvent(ctx, tx, storage.FlashSaleCompletedTopic, event.FlashSaleId, &pb.FlashSaleCompleted{
+			FlashSaleId: event.FlashSaleId,
+			Name:        event.Name,
+			FromStatus:  event.FromStatus,
+			CompletedAt: event.ChangedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return append(events, completed...), nil
+}
+
+// GetFlashSaleSnapshot returns the current status of a sale followed by the
+// available quantity of each of its products, in the shape WatchFlashSale sends.
+func (r *FlashSaleRepo) GetFlashSaleSnapshot(ctx context.Context, req *pb.GetById) ([]*pb.FlashSaleUpdate, error) {
+	var startTime, endTime time.Time
+	sale := &pb.FlashSaleUpdate{FlashSaleId: req.Id, Type: "status", ChangedAt: time.Now().Format(updateTimeLayout)}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			status,
+			start_time,
+			end_time
+		FROM
+			flash_sales
+		WHERE
+			id = $1
+		AND
+			deleted_at = 0`, req.Id).Scan(&sale.Status, &startTime, &endTime)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "flash sale not found")
+	} else if err != nil {
+		return nil, err
+	}
+	sale.StartTime = startTime.Format(updateTimeLayout)
+	sale.EndTime = endTime.Format(updateTimeLayout)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			id,
+			COALESCE(available_quantity, 0)
+		FROM
+			flash_sales_products
+		WHERE
+			flash_sale_id = $1
+		AND
+			deleted_at = 0`, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	updates := []*pb.FlashSaleUpdate{sale}
+	for rows.Next() {
+		stock := &pb.FlashSaleUpdate{FlashSaleId: req.Id, Type: "stock", ChangedAt: sale.ChangedAt}
+		if err := rows.Scan(&stock.FlashSaleProductId, &stock.AvailableQuantity); err != nil {
+			return nil, err
+		}
+		updates = append(updates, stock)
+	}
+
+	return updates, rows.Err()
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -9,19 +10,21 @@ import (
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FlashSaleProductsRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewFlashSaleProductsRepo(db *sql.DB) *FlashSaleProductsRepo {
 	return &FlashSaleProductsRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (r *FlashSaleProductsRepo) CreateFlashSaleProduct(req *pb.CreateFlashSaleProductReq) (*pb.Void, error) {
+func (r *FlashSaleProductsRepo) CreateFlashSaleProduct(ctx context.Context, req *pb.CreateFlashSaleProductReq) (*pb.Void, error) {
 
 	id := uuid.NewString()
 
@@ -31,11 +34,12 @@ func (r *FlashSaleProductsRepo) CreateFlashSaleProduct(req *pb.CreateFlashSalePr
 		flash_sale_id, 
 		product_id,
 		discounted_price,
-		available_quantity) 
+		available_quantity,
+		per_user_limit) 
 		VALUES 
-		($1, $2, $3, $4, $5)`
+		($1, $2, $3, $4, $5, $6)`
 
-	_, err := r.db.Exec(query, id, req.FlashSaleId, req.ProductId, req.AvailableQuantity, req.DiscountedPrice)
+	_, err := r.db.ExecContext(ctx, query, id, req.FlashSaleId, req.ProductId, req.DiscountedPrice, req.AvailableQuantity, req.PerUserLimit)
 
 	if err != nil {
 		return nil, err
@@ -43,7 +47,7 @@ func (r *FlashSaleProductsRepo) CreateFlashSaleProduct(req *pb.CreateFlashSalePr
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleProductsRepo) UpdateFlashSaleProduct(req *pb.UpdateFlashSaleProductReq) (*pb.Void, error) {
+func (r *FlashSaleProductsRepo) UpdateFlashSaleProduct(ctx context.Context, req *pb.UpdateFlashSaleProductReq) (*pb.Void, error) {
 
 	var args []interface{}
 	var conditions []string
@@ -66,6 +70,10 @@ func (r *FlashSaleProductsRepo) UpdateFlashSaleProduct(req *pb.UpdateFlashSalePr
 		args = append(args, req.Body.DiscountedPrice)
 		conditions = append(conditions, fmt.Sprintf("discounted_price = $%d", len(args)))
 	}
+	if req.Body.PerUserLimit != 0 {
+		args = append(args, max(req.Body.PerUserLimit, 0))
+		conditions = append(conditions, fmt.Sprintf("per_user_limit = $%d", len(args)))
+	}
 
 	args = append(args, time.Now())
 	conditions = append(conditions, fmt.Sprintf("updated_at = $%d", len(args)))
@@ -74,7 +82,7 @@ func (r *FlashSaleProductsRepo) UpdateFlashSaleProduct(req *pb.UpdateFlashSalePr
 
 	args = append(args, req.Id)
 
-	_, err := r.db.Exec(query, args...)
+	_, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -82,7 +90,7 @@ func (r *FlashSaleProductsRepo) UpdateFlashSaleProduct(req *pb.UpdateFlashSalePr
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleProductsRepo) DeleteFlashSaleProduct(req *pb.GetById) (*pb.Void, error) {
+func (r *FlashSaleProductsRepo) DeleteFlashSaleProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
 
 	query := `UPDATE
 		flash_sales_products
@@ -91,7 +99,7 @@ func (r *FlashSaleProductsRepo) DeleteFlashSaleProduct(req *pb.GetById) (*pb.Voi
 		WHERE
 		id = $1`
 
-	_, err := r.db.Exec(query, req.Id)
+	_, err := r.db.ExecContext(ctx, query, req.Id)
 
 	if err != nil {
 		return nil, err
@@ -99,31 +107,33 @@ func (r *FlashSaleProductsRepo) DeleteFlashSaleProduct(req *pb.GetById) (*pb.Voi
 	return &pb.Void{}, nil
 }
 
-func (r *FlashSaleProductsRepo) GetFlashSaleProduct(req *pb.GetById) (*pb.FlashSaleProduct, error) {
+func (r *FlashSaleProductsRepo) GetFlashSaleProduct(ctx context.Context, req *pb.GetFlashSaleProductReq) (*pb.FlashSaleProduct, error) {
 
 	query := `
 		SELECT 
 			f.id,
 			f.available_quantity,
 			f.discounted_price,
+			f.per_user_limit,
 			s.id,
 			s.name,
 			s.start_time,
 			s.end_time,
 			s.status,
+			s.per_user_limit,
 			p.id,
 			p.name,
 			p.price,
 			p.description,
 			p.image_url,
-			p.stock_quantity,
+			p.stock_quantity
 		FROM
 			flash_sales_products f
-		LEFT JOIN
+		JOIN
 			flash_sales s
 		ON
 			f.flash_sale_id = s.id
-		LEFT JOIN
+		JOIN
 			products p
 		ON
 			f.product_id = p.id
@@ -132,18 +142,23 @@ func (r *FlashSaleProductsRepo) GetFlashSaleProduct(req *pb.GetById) (*pb.FlashS
 		AND 
 			f.deleted_at = 0`
 
-	row := r.db.QueryRow(query, req.Id)
+	row := r.db.QueryRowContext(ctx, query, req.Id)
 
-	res := &pb.FlashSaleProduct{}
+	res := &pb.FlashSaleProduct{
+		FlashSale: &pb.FlashSale{},
+		Product:   &pb.Products{},
+	}
 	err := row.Scan(
 		&res.Id,
 		&res.AvailableQuantity,
 		&res.DiscountedPrice,
+		&res.PerUserLimit,
 		&res.FlashSale.Id,
 		&res.FlashSale.Name,
 		&res.FlashSale.StartTime,
 		&res.FlashSale.EndTime,
 		&res.FlashSale.Status,
+		&res.FlashSale.PerUserLimit,
 		&res.Product.Id,
 		&res.Product.Name,
 		&res.Product.Price,
@@ -151,15 +166,49 @@ func (r *FlashSaleProductsRepo) GetFlashSaleProduct(req *pb.GetById) (*pb.FlashS
 		&res.Product.ImageUrl,
 		&res.Product.StockQuantity,
 	)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "flash sale product not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	res.RemainingAllowance, err = r.remainingAllowance(ctx, res, req.UserId)
 	if err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (r *FlashSaleProductsRepo) ListAllFlashSaleProducts(req *pb.ListAllFlashSaleProductsReq) (*pb.ListAllFlashSaleProductsRes, error) {
+// remainingAllowance is the smaller of what is left under the product limit and
+// under the sale-wide limit for userID.
+func (r *FlashSaleProductsRepo) remainingAllowance(ctx context.Context, product *pb.FlashSaleProduct, userID string) (int32, error) {
+	var productBought, saleBought int32
+	if userID != "" {
+		var err error
+		if product.PerUserLimit > 0 {
+			productBought, err = purchasedUnits(ctx, r.db, userID, product.FlashSale.Id, product.Id)
+			if err != nil {
+				return 0, err
+			}
+		}
+		if product.FlashSale.PerUserLimit > 0 {
+			saleBought, err = purchasedUnits(ctx, r.db, userID, product.FlashSale.Id, "")
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	left := remainingAllowance(product.PerUserLimit, productBought)
+	if saleLeft := remainingAllowance(product.FlashSale.PerUserLimit, saleBought); left < 0 || (saleLeft >= 0 && saleLeft < left) {
+		left = saleLeft
+	}
+	return left, nil
+}
 
-    query := `
+func (r *FlashSaleProductsRepo) ListAllFlashSaleProducts(ctx context.Context, req *pb.ListAllFlashSaleProductsReq) (*pb.ListAllFlashSaleProductsRes, error) {
+
+	query := `
     SELECT 
         f.id,
         f.available_quantity,
@@ -188,20 +237,20 @@ func (r *FlashSaleProductsRepo) ListAllFlashSaleProducts(req *pb.ListAllFlashSal
     WHERE
         f.deleted_at = 0`
 
-    rows, err := r.db.Query(query)
-    if err != nil {
-        return nil, fmt.Errorf("failed to execute query: %v", err)
-    }
-    defer rows.Close()
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %v", err)
+	}
+	defer rows.Close()
 
-    res := &pb.ListAllFlashSaleProductsRes{
-		FlashSaleProducts: make([]*pb.FlashSaleProduct, 0), 
+	res := &pb.ListAllFlashSaleProductsRes{
+		FlashSaleProducts: make([]*pb.FlashSaleProduct, 0),
 	}
-	
+
 	for rows.Next() {
 		product := pb.FlashSaleProduct{
 			FlashSale: &pb.FlashSale{},
-			Product:  &pb.Products{},
+			Product:   &pb.Products{},
 		}
 		err := rows.Scan(
 			&product.Id,
@@ -222,16 +271,15 @@ func (r *FlashSaleProductsRepo) ListAllFlashSaleProducts(req *pb.ListAllFlashSal
 		if err != nil {
 			return nil, fmt.Errorf("error scanning row: %v", err)
 		}
-	
+
 		res.FlashSaleProducts = append(res.FlashSaleProducts, &product)
 	}
-	
+
 	res.TotalCount = int64(len(res.FlashSaleProducts))
 
-    if err = rows.Err(); err != nil {
-        return nil, fmt.Errorf("rows iteration error: %v", err)
-    }
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %v", err)
+	}
 
-    return res, nil
+	return res, nil
 }
-
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// claimIdempotencyKey records key of userID for operation inside tx. It reports
+// false when the same request was already applied under that key, so the caller
+// can return without repeating it, and fails when the key was used for a
+// different request. Keys of different users never collide.
+func claimIdempotencyKey(ctx context.Context, tx dbtx, operation, userID, key string, req proto.Message) (bool, error) {
+	hash, err := requestHash(req)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO
+			idempotency_keys
+			(operation,
+			user_id,
+			key,
+			request_hash)
+			VALUES
+			($1, $2, $3, $4)
+		ON CONFLICT (operation, user_id, key) DO NOTHING`, operation, userID, key, hash)
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 1 {
+		return true, nil
+	}
+
+	var stored string
+	err = tx.QueryRowContext(ctx, `SELECT request_hash FROM idempotency_keys WHERE operation = $1 AND user_id = $2 AND key = $3`, operation, userID, key).Scan(&stored)
+	if err != nil {
+		return false, err
+	}
+	if stored != hash {
+		return false, status.Errorf(codes.InvalidArgument, "idempotency key %q was already used with a different request", key)
+	}
+	return false, nil
+}
+
+func requestHash(req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
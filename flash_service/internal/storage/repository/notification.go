@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -13,15 +14,15 @@ import (
 )
 
 type NotificationRepo struct {
-	db *sql.DB
+	db *conn
 	cf *config.Config
 }
 
 func NewNotificationRepo(db *sql.DB, cf *config.Config) *NotificationRepo {
-	return &NotificationRepo{db: db, cf: cf}
+	return &NotificationRepo{db: newConn(db), cf: cf}
 }
-func (r *NotificationRepo) CreateNotification(req *pb.NotificationCreate) (*pb.Void, error) {
-	tr, err := r.db.Begin()
+func (r *NotificationRepo) CreateNotification(ctx context.Context, req *pb.NotificationCreate) (*pb.Void, error) {
+	tr, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +34,7 @@ func (r *NotificationRepo) CreateNotification(req *pb.NotificationCreate) (*pb.V
 
 	if req.UserId == "" {
 		query := `select id from users where role = 'admin' and deleted_at = 0 limit 1`
-		row := tr.QueryRow(query)
+		row := tr.QueryRowContext(ctx, query)
 		err := row.Scan(&user_id)
 		if err == sql.ErrNoRows {
 			tr.Rollback()
@@ -48,7 +49,7 @@ func (r *NotificationRepo) CreateNotification(req *pb.NotificationCreate) (*pb.V
 
 	//geting the email
 	user_query := `select email,username from users where id = $1 and deleted_at = 0`
-	row := tr.QueryRow(user_query, req.UserId)
+	row := tr.QueryRowContext(ctx, user_query, req.UserId)
 	err = row.Scan(&user_email, &user_name)
 	if err == sql.ErrNoRows {
 		tr.Rollback()
@@ -79,7 +80,7 @@ func (r *NotificationRepo) CreateNotification(req *pb.NotificationCreate) (*pb.V
 										content,
 										status)
 						values($1,$2,$3,$4,$5)`
-	_, err = tr.Exec(query,
+	_, err = tr.ExecContext(ctx, query,
 		uuid.NewString(),
 		req.Type,
 		user_id,
@@ -97,16 +98,16 @@ func (r *NotificationRepo) CreateNotification(req *pb.NotificationCreate) (*pb.V
 	}
 	return &pb.Void{}, nil
 }
-func (r *NotificationRepo) DeleteNotification(id *pb.GetById) (*pb.Void, error) {
+func (r *NotificationRepo) DeleteNotification(ctx context.Context, id *pb.GetById) (*pb.Void, error) {
 	query := `update notifications set deleted_at = EXTRACT(EPOCH FROM now()) 
 				where id = $1 and deleted_at = 0`
-	_, err := r.db.Exec(query, id.Id)
+	_, err := r.db.ExecContext(ctx, query, id.Id)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.Void{}, nil
 }
-func (r *NotificationRepo) UpdateNotification(req *pb.NotificationUpdate) (*pb.Void, error) {
+func (r *NotificationRepo) UpdateNotification(ctx context.Context, req *pb.NotificationUpdate) (*pb.Void, error) {
 	query := "UPDATE notifications SET "
 	var cons []string
 	var args []interface{}
@@ -131,14 +132,14 @@ func (r *NotificationRepo) UpdateNotification(req *pb.NotificationUpdate) (*pb.V
 	args = append(args, req.NotificationId)
 
 	// Execute the query
-	_, err := r.db.Exec(query, args...)
+	_, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.Void{}, nil
 }
-func (r *NotificationRepo) GetNotifications(req *pb.NotifFilter) (*pb.NotificationList, error) {
+func (r *NotificationRepo) GetNotifications(ctx context.Context, req *pb.NotifFilter) (*pb.NotificationList, error) {
 
 	query := `SELECT id, 
 					type, 
@@ -175,7 +176,7 @@ func (r *NotificationRepo) GetNotifications(req *pb.NotifFilter) (*pb.Notificati
 	args = append(args, req.Filter.Limit, req.Filter.Offset)
 
 	// Execute the query
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error executing query: %w", err)
 	}
@@ -202,7 +203,7 @@ func (r *NotificationRepo) GetNotifications(req *pb.NotifFilter) (*pb.Notificati
 	for _, n := range notificationList.Notifications {
 		query := `update notifications set status = read 
 					where deleted_at = 0 and id = $1`
-		_, err := r.db.Exec(query, n.Id)
+		_, err := r.db.ExecContext(ctx, query, n.Id)
 		if err != nil {
 			return nil, err
 		}
@@ -210,7 +211,7 @@ func (r *NotificationRepo) GetNotifications(req *pb.NotifFilter) (*pb.Notificati
 
 	return &notificationList, nil
 }
-func (r *NotificationRepo) GetNotification(id *pb.GetById) (*pb.NotificationGet, error) {
+func (r *NotificationRepo) GetNotification(ctx context.Context, id *pb.GetById) (*pb.NotificationGet, error) {
 	query := `select id,
 					content,
 					type,
@@ -218,7 +219,7 @@ func (r *NotificationRepo) GetNotification(id *pb.GetById) (*pb.NotificationGet,
 					created_at,
 					user_id
 			from notifications where deleted_at = 0 and id = $1`
-	row := r.db.QueryRow(query, id.Id)
+	row := r.db.QueryRowContext(ctx, query, id.Id)
 
 	var notif pb.NotificationGet
 	err := row.Scan(&notif.Id,
@@ -235,3 +236,26 @@ func (r *NotificationRepo) GetNotification(id *pb.GetById) (*pb.NotificationGet,
 
 	return &notif, nil
 }
+
+// QueueNotification stores a pending notification for the sender to deliver,
+// without sending it right away like CreateNotification does.
+func (r *NotificationRepo) QueueNotification(ctx context.Context, req *pb.NotificationCreate) (string, error) {
+	return queueNotification(ctx, r.db, req.UserId, req.Type, req.Content)
+}
+
+// DropNotification removes a queued notification that was not sent yet.
+func (r *NotificationRepo) DropNotification(ctx context.Context, req *pb.GetById) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM notification WHERE id = $1 AND status = 'pending'`, req.Id)
+	return err
+}
+
+func queueNotification(ctx context.Context, q queryRower, userID, notificationType, content string) (string, error) {
+	if notificationType == "" {
+		notificationType = "email"
+	}
+
+	var id string
+	err := q.QueryRowContext(ctx, `INSERT INTO notification (user_id, type, content, status) VALUES ($1, $2, $3, 'pending') RETURNING id`,
+		userID, notificationType, content).Scan(&id)
+	return id, err
+}
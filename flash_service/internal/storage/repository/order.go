@@ -1,47 +1,247 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/orderstate"
+	"github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OrderRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewOrderRepo(db *sql.DB) *OrderRepo {
 	return &OrderRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (r *OrderRepo) CreateOrder(req *pb.CreateOrderReq) (*pb.Void, error) {
+func (r *OrderRepo) CreateOrder(ctx context.Context, req *pb.CreateOrderReq) (*pb.Void, error) {
 	id := uuid.NewString()
 
-	query := `INSERT INTO 
-		orders 
-		(id, 
-		user_id, 
-		flash_sale_id, 
-		status) 
-		VALUES 
-		($1, $2, $3, $4)`
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	_, err := r.db.Exec(query, id, req.UserID, req.FlashSaleID, req.OrderStatus)
+	if req.IdempotencyKey != "" {
+		claimed, err := claimIdempotencyKey(ctx, tx, "create_order", req.CallerId, req.IdempotencyKey, req)
+		if err != nil {
+			return nil, err
+		} else if !claimed {
+			return &pb.Void{}, nil
+		}
+	}
 
-	if err != nil {
+	if err = insertOrder(ctx, tx, id, req); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return nil, err
 	}
 	return &pb.Void{}, nil
 }
 
-func (r *OrderRepo) UpdateOrder(req *pb.UpdateOrderReq) (*pb.Void, error) {
+// insertOrder places a pending order for req inside tx: it checks that the sale is
+// active and within purchase limits, reserves the stock and records the items.
+func insertOrder(ctx context.Context, tx dbtx, id string, req *pb.CreateOrderReq) error {
+	// a shared lock keeps the scheduler from closing the sale while the order is placed
+	var (
+		saleStatus string
+		saleLimit  int32
+	)
+	err := tx.QueryRowContext(ctx, `
+		SELECT
+			status,
+			per_user_limit
+		FROM
+			flash_sales
+		WHERE
+			id = $1
+		AND
+			deleted_at = 0
+		FOR SHARE`, req.FlashSaleID).Scan(&saleStatus, &saleLimit)
+	if err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "flash sale not found")
+	} else if err != nil {
+		return err
+	}
+	if saleStatus != "active" {
+		return status.Errorf(codes.FailedPrecondition, "flash sale is %s, orders are accepted only while it is active", saleStatus)
+	}
+
+	if saleLimit > 0 {
+		// orders of one user for different products don't share a row lock, so
+		// serialize them on the user and sale until this transaction ends
+		_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || $2))`, req.UserID, req.FlashSaleID)
+		if err != nil {
+			return err
+		}
+		purchased, err := purchasedUnits(ctx, tx, req.UserID, req.FlashSaleID, "")
+		if err != nil {
+			return err
+		}
+		var requested int32
+		for _, item := range req.Items {
+			requested += item.Quantity
+		}
+		if left := remainingAllowance(saleLimit, purchased); requested > left {
+			return status.Errorf(codes.ResourceExhausted, "purchase limit of %d per customer for this flash sale reached: %d left, %d requested", saleLimit, left, requested)
+		}
+	}
+
+	query := `INSERT INTO
+		orders
+		(id,
+		user_id,
+		flash_sale_id,
+		status)
+		VALUES
+		($1, $2, $3, $4)`
+
+	_, err = tx.ExecContext(ctx, query, id, req.UserID, req.FlashSaleID, req.OrderStatus)
+	if err != nil {
+		return err
+	}
+	if err = trackOrderStatus(ctx, tx, id, req.OrderStatus, "", ""); err != nil {
+		return err
+	}
+
+	// lock rows in a stable order so two orders for the same products can't deadlock
+	items := make([]*pb.OrderItemReq, len(req.Items))
+	copy(items, req.Items)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].FlashSaleProductId < items[j].FlashSaleProductId
+	})
+
+	now := time.Now().Format(time.RFC3339)
+	created := &pb.OrderCreated{OrderId: id, UserId: req.UserID, FlashSaleId: req.FlashSaleID, CreatedAt: now}
+	for _, item := range items {
+		var (
+			available       int32
+			discountedPrice float32
+			originalPrice   float32
+			limit           int32
+		)
+		err = tx.QueryRowContext(ctx, `
+			SELECT
+				COALESCE(f.available_quantity, 0),
+				f.discounted_price,
+				p.price,
+				f.per_user_limit
+			FROM
+				flash_sales_products f
+			JOIN
+				products p
+			ON
+				f.product_id = p.id
+			WHERE
+				f.id = $1
+			AND
+				f.flash_sale_id = $2
+			AND
+				f.deleted_at = 0
+			FOR UPDATE OF f`, item.FlashSaleProductId, req.FlashSaleID).Scan(&available, &discountedPrice, &originalPrice, &limit)
+		if err == sql.ErrNoRows {
+			return status.Errorf(codes.NotFound, "flash sale product %s not found", item.FlashSaleProductId)
+		} else if err != nil {
+			return err
+		}
+
+		// the row lock above also serializes this user's other orders for the product
+		if limit > 0 {
+			purchased, err := purchasedUnits(ctx, tx, req.UserID, req.FlashSaleID, item.FlashSaleProductId)
+			if err != nil {
+				return err
+			}
+			if left := remainingAllowance(limit, purchased); item.Quantity > left {
+				return status.Errorf(codes.ResourceExhausted, "purchase limit of %d per customer for %s reached: %d left, %d requested", limit, item.FlashSaleProductId, left, item.Quantity)
+			}
+		}
+
+		if available < item.Quantity {
+			return status.Errorf(codes.ResourceExhausted, "not enough stock for %s: %d left, %d requested", item.FlashSaleProductId, available, item.Quantity)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE
+				flash_sales_products
+			SET
+				available_quantity = available_quantity - $1,
+				updated_at = NOW()
+			WHERE
+				id = $2`, item.Quantity, item.FlashSaleProductId)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO
+			order_items
+			(order_id,
+			flash_sale_product_id,
+			quantity,
+			discounted_price,
+			original_price)
+			VALUES
+			($1, $2, $3, $4, $5)`, id, item.FlashSaleProductId, item.Quantity, discountedPrice, originalPrice)
+		if err != nil {
+			return err
+		}
+		created.TotalAmount += discountedPrice * float32(item.Quantity)
+
+		if available == item.Quantity {
+			err = addEvent(ctx, tx, storage.StockDepletedTopic, item.FlashSaleProductId, &pb.StockDepleted{
+				FlashSaleId:        req.FlashSaleID,
+				FlashSaleProductId: item.FlashSaleProductId,
+				DepletedAt:         now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return addEvent(ctx, tx, storage.OrderCreatedTopic, id, created)
+}
+
+func (r *OrderRepo) UpdateOrder(ctx context.Context, req *pb.UpdateOrderReq) (*pb.Void, error) {
+	// canceling and refunding move stock and money, they have RPCs of their own
+	switch req.GetBody().GetOrderStatus() {
+	case orderstate.Canceled:
+		return nil, status.Errorf(codes.FailedPrecondition, "use CancelOrder to cancel an order")
+	case orderstate.Refunded:
+		return nil, status.Errorf(codes.FailedPrecondition, "use RequestRefund to refund an order")
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// redelivered kafka messages carry the same key and are skipped here
+	if req.IdempotencyKey != "" {
+		claimed, err := claimIdempotencyKey(ctx, tx, "update_order", req.CallerId, req.IdempotencyKey, req)
+		if err != nil {
+			return nil, err
+		} else if !claimed {
+			return &pb.Void{}, nil
+		}
+	}
+
 	var args []interface{}
 	var conditions []string
 
@@ -55,7 +255,21 @@ func (r *OrderRepo) UpdateOrder(req *pb.UpdateOrderReq) (*pb.Void, error) {
 		conditions = append(conditions, fmt.Sprintf("flash_sale_id = $%d", len(args)))
 	}
 
-	if req.Body.OrderStatus != "" && req.Body.OrderStatus != "string" {
+	statusChanged := req.Body.OrderStatus != "" && req.Body.OrderStatus != "string"
+	if statusChanged {
+		current, err := lockOrderStatus(ctx, tx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err := orderstate.Check(current, req.Body.OrderStatus); err != nil {
+			return nil, err
+		}
+		if current == orderstate.Pending {
+			if err := checkNotHeld(ctx, tx, req.Id); err != nil {
+				return nil, err
+			}
+		}
+
 		args = append(args, req.Body.OrderStatus)
 		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
 	}
@@ -67,15 +281,26 @@ func (r *OrderRepo) UpdateOrder(req *pb.UpdateOrderReq) (*pb.Void, error) {
 
 	args = append(args, req.Id)
 
-	_, err := r.db.Exec(query, args...)
+	_, err = tx.ExecContext(ctx, query, args...)
 	if err != nil {
 		log.Println("Error while updating orders", err)
 		return nil, err
 	}
+
+	if statusChanged {
+		err = trackOrderStatus(ctx, tx, req.Id, req.Body.OrderStatus, req.Body.EstimatedDelivery, req.Body.CurrentLocation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
 	return &pb.Void{}, nil
 }
 
-func (r *OrderRepo) GetOrder(req *pb.GetById) (*pb.Order, error) {
+func (r *OrderRepo) GetOrder(ctx context.Context, req *pb.GetById) (*pb.Order, error) {
 	query := `
 		SELECT 
 			o.id,
@@ -108,11 +333,11 @@ func (r *OrderRepo) GetOrder(req *pb.GetById) (*pb.Order, error) {
 	`
 
 	res := &pb.Order{
-		User: &pb.UserRes{},
+		User:        &pb.UserRes{},
 		FlashSaleID: &pb.FlashSale{},
 	}
 
-	err := r.db.QueryRow(query, req.Id).
+	err := r.db.QueryRowContext(ctx, query, req.Id).
 		Scan(
 			&res.Id,
 			&res.User.Id,
@@ -131,10 +356,14 @@ func (r *OrderRepo) GetOrder(req *pb.GetById) (*pb.Order, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if err = r.fillOrderItems(ctx, []*pb.Order{res}); err != nil {
+		return nil, err
+	}
 	return res, nil
 }
 
-func (r *OrderRepo) ListAllOrders(req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRes, error) {
+func (r *OrderRepo) ListAllOrders(ctx context.Context, req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRes, error) {
 
 	query := `
 		SELECT 
@@ -167,14 +396,14 @@ func (r *OrderRepo) ListAllOrders(req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRe
 			o.created_at DESC
 		`
 
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	res := &pb.ListAllOrdersRes{
-		Orders: make([]*pb.Order, 0), 
+		Orders: make([]*pb.Order, 0),
 	}
 	for rows.Next() {
 		var order pb.Order
@@ -200,56 +429,356 @@ func (r *OrderRepo) ListAllOrders(req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRe
 		}
 		res.Orders = append(res.Orders, &order)
 	}
+	if err = r.fillOrderItems(ctx, res.Orders); err != nil {
+		return nil, err
+	}
 	res.TotalCount = int64(len(res.Orders))
 	return res, nil
 }
 
-func (r *OrderRepo) DeleteOrder(req *pb.GetById) (*pb.Void, error) {
+func (r *OrderRepo) DeleteOrder(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
 	query := `
 		UPDATE 
 			orders 
 		SET 
 			deleted_at = extract(epoch from now()) 
 		WHERE id = $1`
-	_, err := r.db.Exec(query, req.Id)
+	_, err := r.db.ExecContext(ctx, query, req.Id)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.Void{}, nil
 }
 
+func (r *OrderRepo) GetOrderHistory(ctx context.Context, req *pb.OrderHistoryReq) (*pb.OrderHistoryRes, error) {
+	query := `
+		SELECT 
+			o.id,
+			u.id,
+			u.username,
+			u.email,
+			u.full_name,
+			u.date_of_birth,
+			f.id,
+			f.name,
+			f.start_time,
+			f.end_time,
+			f.status,
+			o.status,
+			o.created_at
+		FROM 
+			orders o
+		LEFT JOIN 
+			users u
+		ON 
+			o.user_id = u.id
+		LEFT JOIN 
+			flash_sales f
+		ON 
+			o.flash_sale_id = f.id
+		WHERE 
+			o.user_id = $1
+		AND
+			o.deleted_at = 0
+		ORDER BY
+			o.created_at DESC`
 
-func (s *OrderRepo) GetOrderHistory(req *pb.OrderHistoryReq) (*pb.OrderHistoryRes, error) {
-    rows, err := s.db.Query(`SELECT * FROM orders WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`,
-        req.UserID, req.Pagination.Limit, req.Pagination.Offset)
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
+	args := []interface{}{req.UserID}
+	if req.Pagination != nil && req.Pagination.Limit > 0 {
+		args = append(args, req.Pagination.Limit, req.Pagination.Offset)
+		query += ` LIMIT $2 OFFSET $3`
+	}
 
-    var orders []*pb.Order
-    for rows.Next() {
-        var order pb.Order
-        if err := rows.Scan(&order.Id, &order.User.Id, &order.FlashSaleID, &order.OrderStatus, &order.CreatedAt); err != nil {
-            return nil, err
-        }
-        orders = append(orders, &order)
-    }
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-    return &pb.OrderHistoryRes{Orders: orders, TotalCount: int64(len(orders))}, nil
+	orders := make([]*pb.Order, 0)
+	for rows.Next() {
+		order := pb.Order{
+			User:        &pb.UserRes{},
+			FlashSaleID: &pb.FlashSale{},
+		}
+		err := rows.Scan(
+			&order.Id,
+			&order.User.Id,
+			&order.User.Username,
+			&order.User.Email,
+			&order.User.FullName,
+			&order.User.DateOfBirth,
+			&order.FlashSaleID.Id,
+			&order.FlashSaleID.Name,
+			&order.FlashSaleID.StartTime,
+			&order.FlashSaleID.EndTime,
+			&order.FlashSaleID.Status,
+			&order.OrderStatus,
+			&order.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, &order)
+	}
+	if err = r.fillOrderItems(ctx, orders); err != nil {
+		return nil, err
+	}
+
+	return &pb.OrderHistoryRes{Orders: orders, TotalCount: int64(len(orders))}, nil
+}
+
+// fillOrderItems loads the line items of the given orders and computes their totals.
+func (r *OrderRepo) fillOrderItems(ctx context.Context, orders []*pb.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*pb.Order, len(orders))
+	ids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		order.Items = make([]*pb.OrderItem, 0)
+		byID[order.Id] = order
+		ids = append(ids, order.Id)
+	}
+
+	query := `
+		SELECT
+			i.id,
+			i.order_id,
+			i.flash_sale_product_id,
+			p.id,
+			p.name,
+			i.quantity,
+			i.discounted_price,
+			i.original_price
+		FROM
+			order_items i
+		JOIN
+			flash_sales_products f
+		ON
+			i.flash_sale_product_id = f.id
+		JOIN
+			products p
+		ON
+			f.product_id = p.id
+		WHERE
+			i.order_id = ANY($1)
+		ORDER BY
+			i.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item    pb.OrderItem
+			orderID string
+		)
+		err := rows.Scan(
+			&item.Id,
+			&orderID,
+			&item.FlashSaleProductId,
+			&item.ProductId,
+			&item.ProductName,
+			&item.Quantity,
+			&item.DiscountedPrice,
+			&item.OriginalPrice,
+		)
+		if err != nil {
+			return err
+		}
+		item.Total = item.DiscountedPrice * float32(item.Quantity)
+
+		order := byID[orderID]
+		order.Items = append(order.Items, &item)
+		order.TotalAmount += item.Total
+		order.OriginalAmount += item.OriginalPrice * float32(item.Quantity)
+	}
+
+	return rows.Err()
+}
+
+// CancelOrder cancels an order, gives its stock back and refunds whatever was
+// paid for it. It runs inside the caller's unit of work, see Storage.WithTx.
+func (r *OrderRepo) CancelOrder(ctx context.Context, req *pb.GetById) (*pb.CancelOrderRes, error) {
+	if err := r.db.unitOfWork("CancelOrder"); err != nil {
+		return nil, err
+	}
+
+	current, err := lockOrderStatus(ctx, r.db, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	// a retried cancellation gets the outcome of the first one
+	if current == orderstate.Canceled {
+		res := &pb.CancelOrderRes{CancellationStatus: orderstate.Canceled}
+		err = r.db.QueryRowContext(ctx, `SELECT refund_status FROM refunds WHERE order_id = $1 ORDER BY created_at DESC LIMIT 1`, req.Id).Scan(&res.RefundStatus)
+		if err == sql.ErrNoRows {
+			res.RefundStatus = "none"
+		} else if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+	if err := orderstate.Check(current, orderstate.Canceled); err != nil {
+		return nil, err
+	}
+
+	_, err = r.db.ExecContext(ctx, `UPDATE orders SET status = 'canceled', updated_at = NOW() WHERE id = $1`, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = releaseOrderStock(ctx, r.db, req.Id); err != nil {
+		return nil, err
+	}
+
+	// a hold on the order ends with the order itself
+	_, err = r.db.ExecContext(ctx, `UPDATE reservations SET status = 'released', updated_at = NOW() WHERE order_id = $1 AND status = 'held'`, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = trackOrderStatus(ctx, r.db, req.Id, orderstate.Canceled, "", ""); err != nil {
+		return nil, err
+	}
+	if err = orderCanceled(ctx, r.db, req.Id, "order canceled"); err != nil {
+		return nil, err
+	}
+
+	// whatever was paid for the order goes back through the refund workflow
+	res := &pb.CancelOrderRes{CancellationStatus: orderstate.Canceled, RefundStatus: "none"}
+	refund, err := createRefund(ctx, r.db, req.Id, 0, "order canceled")
+	if err != nil {
+		return nil, err
+	}
+	if refund != nil {
+		res.RefundStatus = refund.RefundStatus
+	}
+
+	return res, nil
+}
+
+func (r *OrderRepo) GetOrderTimeline(ctx context.Context, req *pb.GetById) (*pb.OrderTimeline, error) {
+	res := &pb.OrderTimeline{
+		OrderId: req.Id,
+		Entries: make([]*pb.OrderStatusEntry, 0),
+	}
+
+	err := r.db.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 AND deleted_at = 0`, req.Id).Scan(&res.CurrentStatus)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "order not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			id,
+			order_status,
+			estimated_delivery,
+			current_location,
+			created_at
+		FROM
+			order_status_tracking
+		WHERE
+			order_id = $1
+		ORDER BY
+			created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			entry             pb.OrderStatusEntry
+			estimatedDelivery sql.NullString
+			currentLocation   sql.NullString
+		)
+		err := rows.Scan(
+			&entry.Id,
+			&entry.OrderStatus,
+			&estimatedDelivery,
+			&currentLocation,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.EstimatedDelivery = estimatedDelivery.String
+		entry.CurrentLocation = currentLocation.String
+		res.Entries = append(res.Entries, &entry)
+	}
+
+	return res, rows.Err()
+}
+
+// lockOrderStatus returns the current status of an order and locks its row
+// until the transaction ends.
+func lockOrderStatus(ctx context.Context, tx dbtx, orderID string) (string, error) {
+	var current string
+	err := tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = $1 AND deleted_at = 0 FOR UPDATE`, orderID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return "", status.Errorf(codes.NotFound, "order not found")
+	}
+	return current, err
 }
 
+// trackOrderStatus appends a status change to order_status_tracking.
+func trackOrderStatus(ctx context.Context, tx dbtx, orderID, orderStatus, estimatedDelivery, currentLocation string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO
+		order_status_tracking
+		(order_id,
+		order_status,
+		estimated_delivery,
+		current_location)
+		VALUES
+		($1, $2, $3, $4)`, orderID, orderStatus, nullString(estimatedDelivery), nullString(currentLocation))
+	return err
+}
 
-func (s *OrderRepo) CancelOrder(req *pb.GetById) (*pb.CancelOrderRes, error) {
-    _, err := s.db.Exec(`UPDATE orders SET status = 'canceled', updated_at = NOW() WHERE id = $1`, req.Id)
-    if err != nil {
-        return nil, err
-    }
+// orderCanceled publishes the cancellation of orderID through the outbox.
+func orderCanceled(ctx context.Context, tx dbtx, orderID, reason string) error {
+	return addEvent(ctx, tx, storage.OrderCanceledTopic, orderID, &pb.OrderCanceled{
+		OrderId:    orderID,
+		Reason:     reason,
+		CanceledAt: time.Now().Format(time.RFC3339),
+	})
+}
 
-    _, err = s.db.Exec(`INSERT INTO refunds (order_id, refund_status, refund_amount, created_at) VALUES ($1, 'pending', 0, NOW())`, req.Id)
-    if err != nil {
-        return nil, err
-    }
+// releaseOrderStock gives the units reserved by an order back to the sale.
+func releaseOrderStock(ctx context.Context, tx dbtx, orderID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE
+			flash_sales_products f
+		SET
+			available_quantity = f.available_quantity + i.quantity,
+			updated_at = NOW()
+		FROM
+			order_items i
+		WHERE
+			i.order_id = $1
+		AND
+			f.id = i.flash_sale_product_id`, orderID)
+	return err
+}
 
-    return &pb.CancelOrderRes{CancellationStatus: "canceled", RefundStatus: "pending"}, nil
+// checkNotHeld refuses to move a pending order that a reservation still holds;
+// the outcome of the hold decides its status.
+func checkNotHeld(ctx context.Context, tx dbtx, orderID string) error {
+	var reservationID string
+	err := tx.QueryRowContext(ctx, `SELECT id FROM reservations WHERE order_id = $1 AND status = 'held'`, orderID).Scan(&reservationID)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return status.Errorf(codes.FailedPrecondition, "order is held by reservation %s, confirm or release it instead", reservationID)
 }
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"google.golang.org/protobuf/proto"
+)
+
+type OutboxRepo struct {
+	db *conn
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{
+		db: newConn(db),
+	}
+}
+
+// addEvent writes event to the outbox inside tx, so it gets published once
+// the change it describes commits and never when that rolls back. The event
+// is stored in its envelope, which keeps its ID and time when the relay has
+// to publish it again.
+func addEvent(ctx context.Context, tx dbtx, topic, aggregateID string, event proto.Message) error {
+	env, err := envelope.New(event, 1)
+	if err != nil {
+		return err
+	}
+	payload, err := envelope.Marshal(env)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO outbox (topic, aggregate_id, payload) VALUES ($1, $2, $3)`, topic, aggregateID, payload)
+	return err
+}
+
+// ClaimEvents returns up to limit unsent events that are due at now, oldest
+// first. Claimed events are hidden from other relays until the lease runs out,
+// so an event whose relay died is picked up again. An event is not claimed
+// while an earlier one of its aggregate is still waiting, so the events of an
+// aggregate go out in order.
+func (r *OutboxRepo) ClaimEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*storage.OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE
+			outbox
+		SET
+			attempts = attempts + 1,
+			available_at = $2
+		WHERE
+			id IN (
+				SELECT
+					o.id
+				FROM
+					outbox o
+				WHERE
+					o.sent_at IS NULL
+				AND
+					o.available_at <= $1
+				AND NOT EXISTS (
+					SELECT
+						1
+					FROM
+						outbox earlier
+					WHERE
+						earlier.aggregate_id = o.aggregate_id
+					AND
+						earlier.id < o.id
+					AND
+						earlier.sent_at IS NULL
+					AND
+						earlier.available_at > $1)
+				ORDER BY
+					o.id
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED)
+		RETURNING
+			id,
+			topic,
+			aggregate_id,
+			payload,
+			attempts`, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*storage.OutboxEvent
+	for rows.Next() {
+		var event storage.OutboxEvent
+		if err := rows.Scan(&event.ID, &event.Topic, &event.AggregateID, &event.Payload, &event.Attempts); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+func (r *OutboxRepo) MarkSent(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE outbox SET sent_at = NOW(), last_error = NULL WHERE id = $1`, id)
+	return err
+}
+
+// MarkFailed keeps the event in the outbox until retryAt.
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, retryAt time.Time, cause string) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE outbox SET available_at = $2, last_error = $3 WHERE id = $1`, id, retryAt, cause)
+	return err
+}
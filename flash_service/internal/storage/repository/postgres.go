@@ -8,7 +8,16 @@ import (
 )
 
 type Storage struct {
+	conn *conn
+
 	OrderS           storage.OrderI
+	TransactionS     storage.TransactionI
+	ReservationS     storage.ReservationI
+	RefundS          storage.RefundI
+	SagaS            storage.SagaI
+	OutboxS          storage.OutboxI
+	CommandS         storage.CommandI
+	ProcessedS       storage.ProcessedMessageI
 	ProductS         storage.ProductI
 	AuthS            storage.AuthI
 	UserS            storage.UserI
@@ -20,16 +29,28 @@ type Storage struct {
 }
 
 func NewStorage(db *sql.DB) *Storage {
+	return newStorage(newConn(db))
+}
+
+func newStorage(c *conn) *Storage {
 	return &Storage{
-		OrderS:           NewOrderRepo(db),
-		ProductS:         NewProductRepo(db),
-		AuthS:            NewAuthRepo(db),
-		UserS:            NewUserRepo(db),
-		NotificationS:    NewNotificationRepo(db, &config.Config{}),
-		FlashSaleS:       NewFlashSaleRepo(db),
-		FlashSaleProdctS: NewFlashSaleProductsRepo(db),
-		ReviewS:          NewReviewRepo(db),
-		SocialS:          NewSocialRepo(db),
+		conn:             c,
+		OrderS:           &OrderRepo{db: c},
+		TransactionS:     &TransactionRepo{db: c},
+		ReservationS:     &ReservationRepo{db: c},
+		RefundS:          &RefundRepo{db: c},
+		SagaS:            &SagaRepo{db: c},
+		OutboxS:          &OutboxRepo{db: c},
+		CommandS:         &CommandRepo{db: c},
+		ProcessedS:       &ProcessedMessageRepo{db: c},
+		ProductS:         &ProductsRepo{db: c},
+		AuthS:            &AuthRepo{db: c},
+		UserS:            &UserRepo{db: c},
+		NotificationS:    &NotificationRepo{db: c, cf: &config.Config{}},
+		FlashSaleS:       &FlashSaleRepo{db: c},
+		FlashSaleProdctS: &FlashSaleProductsRepo{db: c},
+		ReviewS:          &ReviewRepo{DB: c},
+		SocialS:          &SocialRepo{DB: c},
 	}
 }
 
@@ -37,6 +58,34 @@ func (s *Storage) Order() storage.OrderI {
 	return s.OrderS
 }
 
+func (s *Storage) Transaction() storage.TransactionI {
+	return s.TransactionS
+}
+
+func (s *Storage) Reservation() storage.ReservationI {
+	return s.ReservationS
+}
+
+func (s *Storage) Refund() storage.RefundI {
+	return s.RefundS
+}
+
+func (s *Storage) Saga() storage.SagaI {
+	return s.SagaS
+}
+
+func (s *Storage) Outbox() storage.OutboxI {
+	return s.OutboxS
+}
+
+func (s *Storage) Command() storage.CommandI {
+	return s.CommandS
+}
+
+func (s *Storage) ProcessedMessage() storage.ProcessedMessageI {
+	return s.ProcessedS
+}
+
 func (s *Storage) Product() storage.ProductI {
 	return s.ProductS
 }
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type ProcessedMessageRepo struct {
+	db *conn
+}
+
+func NewProcessedMessageRepo(db *sql.DB) *ProcessedMessageRepo {
+	return &ProcessedMessageRepo{
+		db: newConn(db),
+	}
+}
+
+// MarkProcessed records messageID and reports whether this was the first
+// time. It runs inside the caller's unit of work, see Storage.WithTx, so the
+// mark commits or rolls back together with the message's write.
+func (r *ProcessedMessageRepo) MarkProcessed(ctx context.Context, messageID string) (bool, error) {
+	if err := r.db.unitOfWork("MarkProcessed"); err != nil {
+		return false, err
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO
+			processed_messages
+			(message_id)
+			VALUES
+			($1)
+		ON CONFLICT (message_id) DO NOTHING`, messageID)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
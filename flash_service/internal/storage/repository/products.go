@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -12,16 +13,16 @@ import (
 )
 
 type ProductsRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewProductRepo(db *sql.DB) *ProductsRepo {
 	return &ProductsRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (p *ProductsRepo) CreateProduct(req *pb.CreateProductReq) (*pb.Void, error) {
+func (p *ProductsRepo) CreateProduct(ctx context.Context, req *pb.CreateProductReq) (*pb.Void, error) {
 	id := uuid.NewString()
 
 	query := `INSERT INTO 
@@ -35,7 +36,7 @@ func (p *ProductsRepo) CreateProduct(req *pb.CreateProductReq) (*pb.Void, error)
 		VALUES 
 			($1, $2, $3, $4, $5, $6)`
 
-	_, err := p.db.Exec(query, id, req.Name, req.Description, req.Price, req.ImageUrl, req.StockQuantity)
+	_, err := p.db.ExecContext(ctx, query, id, req.Name, req.Description, req.Price, req.ImageUrl, req.StockQuantity)
 
 	if err != nil {
 		return nil, err
@@ -43,7 +44,7 @@ func (p *ProductsRepo) CreateProduct(req *pb.CreateProductReq) (*pb.Void, error)
 	return &pb.Void{}, nil
 }
 
-func (p *ProductsRepo) UpdateProduct(req *pb.UpdateProductReq) (*pb.Void, error) {
+func (p *ProductsRepo) UpdateProduct(ctx context.Context, req *pb.UpdateProductReq) (*pb.Void, error) {
 	var args []interface{}
 	var conditions []string
 
@@ -64,7 +65,7 @@ func (p *ProductsRepo) UpdateProduct(req *pb.UpdateProductReq) (*pb.Void, error)
 		query := `UPDATE products SET ` + strings.Join(conditions, ", ") + ` WHERE id = $` + fmt.Sprintf("%d", len(args)+1)
 		args = append(args, req.Id)
 
-		_, err := p.db.Exec(query, args...)
+		_, err := p.db.ExecContext(ctx, query, args...)
 		if err != nil {
 			log.Println("Error while updating products", err)
 			return nil, err
@@ -74,7 +75,7 @@ func (p *ProductsRepo) UpdateProduct(req *pb.UpdateProductReq) (*pb.Void, error)
 	return &pb.Void{}, nil
 
 }
-func (p *ProductsRepo) GetProduct(req *pb.GetById) (*pb.Products, error) {
+func (p *ProductsRepo) GetProduct(ctx context.Context, req *pb.GetById) (*pb.Products, error) {
 	query := `
 		SELECT 
 			id,
@@ -93,7 +94,7 @@ func (p *ProductsRepo) GetProduct(req *pb.GetById) (*pb.Products, error) {
 
 	res := &pb.Products{}
 
-	err := p.db.QueryRow(query, req.Id).
+	err := p.db.QueryRowContext(ctx, query, req.Id).
 		Scan(
 			&res.Id,
 			&res.Name,
@@ -110,7 +111,7 @@ func (p *ProductsRepo) GetProduct(req *pb.GetById) (*pb.Products, error) {
 
 }
 
-func (p *ProductsRepo) ListAllProducts(req *pb.ListAllProductsReq) (*pb.ListAllProductsRes, error) {
+func (p *ProductsRepo) ListAllProducts(ctx context.Context, req *pb.ListAllProductsReq) (*pb.ListAllProductsRes, error) {
 
 	query := `
 		SELECT 
@@ -159,7 +160,7 @@ func (p *ProductsRepo) ListAllProducts(req *pb.ListAllProductsReq) (*pb.ListAllP
 		}
 	}
 
-	rows, err := p.db.Query(query, args...)
+	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +175,7 @@ func (p *ProductsRepo) ListAllProducts(req *pb.ListAllProductsReq) (*pb.ListAllP
 			&product.Id,
 			&product.Name,
 			&product.Description,
-			&product.Price,        
+			&product.Price,
 			&product.ImageUrl,
 			&product.StockQuantity,
 		)
@@ -194,8 +195,7 @@ func (p *ProductsRepo) ListAllProducts(req *pb.ListAllProductsReq) (*pb.ListAllP
 	}, nil
 }
 
-
-func (p *ProductsRepo) DeleteProduct(req *pb.GetById) (*pb.Void, error) {
+func (p *ProductsRepo) DeleteProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
 	query := `
 	UPDATE
 		products
@@ -204,7 +204,7 @@ func (p *ProductsRepo) DeleteProduct(req *pb.GetById) (*pb.Void, error) {
 	WHERE
 		id = $1`
 
-	_, err := p.db.Exec(query, req.Id)
+	_, err := p.db.ExecContext(ctx, query, req.Id)
 
 	if err != nil {
 		return nil, err
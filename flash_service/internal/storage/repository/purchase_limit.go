@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// queryRower is satisfied by both *sql.DB and *sql.Tx.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// purchasedUnits counts the units a user has ordered in a flash sale, limited to
+// one flash sale product when flashSaleProductID is set. Canceled orders gave
+// their units back and are not counted.
+func purchasedUnits(ctx context.Context, q queryRower, userID, flashSaleID, flashSaleProductID string) (int32, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(i.quantity), 0)
+		FROM
+			order_items i
+		JOIN
+			orders o
+		ON
+			i.order_id = o.id
+		WHERE
+			o.user_id = $1
+		AND
+			o.flash_sale_id = $2
+		AND
+			o.status <> 'canceled'
+		AND
+			o.deleted_at = 0`
+	args := []interface{}{userID, flashSaleID}
+
+	if flashSaleProductID != "" {
+		query += " AND i.flash_sale_product_id = $3"
+		args = append(args, flashSaleProductID)
+	}
+
+	var units int32
+	err := q.QueryRowContext(ctx, query, args...).Scan(&units)
+	return units, err
+}
+
+// remainingAllowance returns how many more units a user may buy under limit, or
+// -1 when limit is 0 (no limit).
+func remainingAllowance(limit, purchased int32) int32 {
+	if limit <= 0 {
+		return -1
+	}
+	if purchased >= limit {
+		return 0
+	}
+	return limit - purchased
+}
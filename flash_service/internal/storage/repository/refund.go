@@ -0,0 +1,455 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/orderstate"
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RefundPending   = "pending"
+	RefundApproved  = "approved"
+	RefundProcessed = "processed"
+	RefundRejected  = "rejected"
+)
+
+// refundFrom lists the status a refund must be in to move to each status.
+var refundFrom = map[string]string{
+	RefundApproved:  RefundPending,
+	RefundRejected:  RefundPending,
+	RefundProcessed: RefundApproved,
+}
+
+const refundColumns = `
+			r.id,
+			r.order_id,
+			o.user_id,
+			r.refund_amount,
+			r.refund_status,
+			r.reason,
+			r.transaction_id,
+			r.created_at,
+			r.updated_at`
+
+type RefundRepo struct {
+	db *conn
+}
+
+func NewRefundRepo(db *sql.DB) *RefundRepo {
+	return &RefundRepo{
+		db: newConn(db),
+	}
+}
+
+// RequestRefund opens a pending refund for an order. An amount of 0 refunds
+// everything that was paid and not refunded yet.
+func (r *RefundRepo) RequestRefund(ctx context.Context, req *pb.RefundCreateReq) (*pb.Refund, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// the order lock keeps two requests from refunding the same money
+	if _, err = lockOrderStatus(ctx, tx, req.OrderId); err != nil {
+		return nil, err
+	}
+
+	refund, err := createRefund(ctx, tx, req.OrderId, req.Amount, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	if refund == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "nothing left to refund for order %s", req.OrderId)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (r *RefundRepo) GetRefund(ctx context.Context, req *pb.GetById) (*pb.Refund, error) {
+	query := `SELECT` + refundColumns + `
+		FROM
+			refunds r
+		JOIN
+			orders o
+		ON
+			r.order_id = o.id
+		WHERE
+			r.id = $1`
+
+	refund, err := scanRefund(r.db.QueryRowContext(ctx, query, req.Id))
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "refund not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (r *RefundRepo) ListRefunds(ctx context.Context, req *pb.RefundListReq) (*pb.RefundListRes, error) {
+	query := `SELECT` + refundColumns + `
+		FROM
+			refunds r
+		JOIN
+			orders o
+		ON
+			r.order_id = o.id
+		WHERE
+			1 = 1`
+
+	var args []interface{}
+
+	if req.RefundStatus != "" && req.RefundStatus != "string" {
+		args = append(args, req.RefundStatus)
+		query += fmt.Sprintf(" AND r.refund_status = $%d", len(args))
+	}
+	if req.OrderId != "" && req.OrderId != "string" {
+		args = append(args, req.OrderId)
+		query += fmt.Sprintf(" AND r.order_id = $%d", len(args))
+	}
+	if req.UserId != "" && req.UserId != "string" {
+		args = append(args, req.UserId)
+		query += fmt.Sprintf(" AND o.user_id = $%d", len(args))
+	}
+
+	query += " ORDER BY r.created_at DESC"
+
+	if req.Filter != nil && req.Filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+		args = append(args, req.Filter.Limit, req.Filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &pb.RefundListRes{
+		Refunds: make([]*pb.Refund, 0),
+	}
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		res.Refunds = append(res.Refunds, refund)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res.TotalCount = int64(len(res.Refunds))
+	return res, nil
+}
+
+func (r *RefundRepo) ApproveRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error) {
+	return r.reviewRefund(ctx, req, RefundApproved)
+}
+
+func (r *RefundRepo) RejectRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error) {
+	return r.reviewRefund(ctx, req, RefundRejected)
+}
+
+// ProcessRefund pays an approved refund back: refundPayment returns the money
+// through the provider that captured it, then the ledger gets a credit and the
+// order is marked refunded once everything paid for it came back. Nothing is
+// written when the provider refuses.
+func (r *RefundRepo) ProcessRefund(ctx context.Context, req *pb.GetById, refundPayment storage.RefundPayment) (*pb.Refund, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	refund, err := lockRefund(ctx, tx, req.Id, RefundProcessed)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := lockOrderStatus(ctx, tx, refund.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var paymentID, provider, providerPaymentID string
+	err = tx.QueryRowContext(ctx, `
+		SELECT
+			id,
+			provider,
+			provider_payment_id
+		FROM
+			payments
+		WHERE
+			order_id = $1
+		AND
+			status = 'captured'
+		ORDER BY
+			created_at DESC
+		LIMIT 1`, refund.OrderId).Scan(&paymentID, &provider, &providerPaymentID)
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.FailedPrecondition, "order has no captured payment to refund")
+	} else if err != nil {
+		return nil, err
+	}
+
+	// the refund stays approved, and can be processed again, unless the money went back
+	paymentStatus, err := refundPayment(ctx, provider, providerPaymentID, refund.Amount)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE payments SET status = $1, updated_at = NOW() WHERE id = $2`, paymentStatus, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	refund.TransactionId = uuid.NewString()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO
+			transactions
+			(id,
+			user_id,
+			order_id,
+			amount,
+			type)
+			VALUES
+			($1, $2, $3, $4, 'credit')`, refund.TransactionId, refund.UserId, refund.OrderId, refund.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE
+			refunds
+		SET
+			refund_status = 'processed',
+			transaction_id = $1,
+			updated_at = NOW()
+		WHERE
+			id = $2
+		RETURNING
+			updated_at`, refund.TransactionId, refund.Id).Scan(&refund.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	refund.RefundStatus = RefundProcessed
+
+	// a canceled order stays canceled, anything else that got all its money back is refunded
+	if current != orderstate.Canceled && orderstate.Check(current, orderstate.Refunded) == nil {
+		var paid, processed float32
+		err = tx.QueryRowContext(ctx, `
+			SELECT
+				COALESCE((SELECT SUM(amount) FROM transactions WHERE order_id = $1 AND type = 'debit' AND deleted_at = 0), 0),
+				COALESCE((SELECT SUM(refund_amount) FROM refunds WHERE order_id = $1 AND refund_status = 'processed'), 0)`,
+			refund.OrderId).Scan(&paid, &processed)
+		if err != nil {
+			return nil, err
+		}
+		if processed >= paid {
+			_, err = tx.ExecContext(ctx, `UPDATE orders SET status = 'refunded', updated_at = NOW() WHERE id = $1`, refund.OrderId)
+			if err != nil {
+				return nil, err
+			}
+			if err = trackOrderStatus(ctx, tx, refund.OrderId, orderstate.Refunded, "", ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (r *RefundRepo) reviewRefund(ctx context.Context, req *pb.RefundReviewReq, to string) (*pb.Refund, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	refund, err := lockRefund(ctx, tx, req.Id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Reason != "" {
+		refund.Reason = req.Reason
+	}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE
+			refunds
+		SET
+			refund_status = $1,
+			reason = $2,
+			updated_at = NOW()
+		WHERE
+			id = $3
+		RETURNING
+			updated_at`, to, nullString(refund.Reason), refund.Id).Scan(&refund.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	refund.RefundStatus = to
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+// lockRefund reads a refund for update and checks that it may move to status to.
+func lockRefund(ctx context.Context, tx dbtx, id, to string) (*pb.Refund, error) {
+	query := `SELECT` + refundColumns + `
+		FROM
+			refunds r
+		JOIN
+			orders o
+		ON
+			r.order_id = o.id
+		WHERE
+			r.id = $1
+		FOR UPDATE OF r`
+
+	refund, err := scanRefund(tx.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "refund not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if from := refundFrom[to]; refund.RefundStatus != from {
+		return nil, status.Errorf(codes.FailedPrecondition, "refund is %s, only %s refunds can be %s", refund.RefundStatus, from, to)
+	}
+	return refund, nil
+}
+
+// createRefund records a pending refund of amount for an order, or of all that
+// is refundable when amount is 0. It returns nil when nothing is refundable.
+// The caller must hold the lock on the order.
+func createRefund(ctx context.Context, tx dbtx, orderID string, amount float32, reason string) (*pb.Refund, error) {
+	if amount < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "refund amount can't be negative")
+	}
+
+	var (
+		refundable float32
+		userID     string
+	)
+	err := tx.QueryRowContext(ctx, `
+		SELECT
+			COALESCE((SELECT SUM(amount) FROM transactions WHERE order_id = $1 AND type = 'debit' AND deleted_at = 0), 0)
+			- COALESCE((SELECT SUM(refund_amount) FROM refunds WHERE order_id = $1 AND refund_status <> 'rejected'), 0),
+			user_id
+		FROM
+			orders
+		WHERE
+			id = $1`, orderID).Scan(&refundable, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if refundable <= 0 {
+		return nil, nil
+	}
+	if amount == 0 {
+		amount = refundable
+	} else if amount > refundable {
+		return nil, status.Errorf(codes.InvalidArgument, "refund of %.2f exceeds the %.2f left to refund", amount, refundable)
+	}
+
+	refund := &pb.Refund{
+		Id:           uuid.NewString(),
+		OrderId:      orderID,
+		UserId:       userID,
+		Amount:       amount,
+		RefundStatus: RefundPending,
+		Reason:       reason,
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO
+			refunds
+			(id,
+			order_id,
+			refund_status,
+			refund_amount,
+			reason)
+			VALUES
+			($1, $2, 'pending', $3, $4)
+		RETURNING
+			created_at`, refund.Id, orderID, amount, nullString(reason)).Scan(&refund.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func scanRefund(row rowScanner) (*pb.Refund, error) {
+	var (
+		refund        pb.Refund
+		reason        sql.NullString
+		transactionID sql.NullString
+		updatedAt     sql.NullString
+	)
+	err := row.Scan(
+		&refund.Id,
+		&refund.OrderId,
+		&refund.UserId,
+		&refund.Amount,
+		&refund.RefundStatus,
+		&reason,
+		&transactionID,
+		&refund.CreatedAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	refund.Reason = reason.String
+	refund.TransactionId = transactionID.String
+	refund.UpdatedAt = updatedAt.String
+	return &refund, nil
+}
+
+// flashSaleRefunds returns the refunds of every order placed in a flash sale.
+func flashSaleRefunds(ctx context.Context, tx dbtx, flashSaleID string) ([]*pb.Refund, error) {
+	query := `SELECT` + refundColumns + `
+		FROM
+			refunds r
+		JOIN
+			orders o
+		ON
+			r.order_id = o.id
+		WHERE
+			o.flash_sale_id = $1
+		ORDER BY
+			r.created_at`
+
+	rows, err := tx.QueryContext(ctx, query, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	refunds := make([]*pb.Refund, 0)
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, refund)
+	}
+	return refunds, rows.Err()
+}
@@ -0,0 +1,501 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/orderstate"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ReservationHeld      = "held"
+	ReservationConfirmed = "confirmed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// expireBatchSize caps how many holds one sweep expires.
+const expireBatchSize = 100
+
+type ReservationRepo struct {
+	db *conn
+}
+
+func NewReservationRepo(db *sql.DB) *ReservationRepo {
+	return &ReservationRepo{
+		db: newConn(db),
+	}
+}
+
+// CreateReservation places a pending order for the items and holds its stock
+// until expiresAt.
+func (r *ReservationRepo) CreateReservation(ctx context.Context, req *pb.CreateReservationReq, expiresAt time.Time) (*pb.Reservation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	order := &pb.CreateOrderReq{
+		UserID:      req.UserId,
+		FlashSaleID: req.FlashSaleId,
+		OrderStatus: orderstate.Pending,
+		Items:       req.Items,
+	}
+	res := &pb.Reservation{
+		Id:      uuid.NewString(),
+		OrderId: uuid.NewString(),
+		UserId:  req.UserId,
+		Status:  ReservationHeld,
+	}
+
+	if err = insertOrder(ctx, tx, res.OrderId, order); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO
+			reservations
+			(id,
+			order_id,
+			user_id,
+			expires_at)
+			VALUES
+			($1, $2, $3, $4)
+		RETURNING
+			created_at`, res.Id, res.OrderId, res.UserId, expiresAt).Scan(&res.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	res.ExpiresAt = expiresAt.Format(time.RFC3339)
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return res, r.fillReservationItems(ctx, res)
+}
+
+// ConfirmReservation confirms the order of a hold that has not expired yet.
+func (r *ReservationRepo) ConfirmReservation(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, error) {
+	return r.finishReservation(ctx, req.Id, ReservationConfirmed, now)
+}
+
+// ReleaseReservation cancels the order of a hold and gives its units back.
+func (r *ReservationRepo) ReleaseReservation(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, error) {
+	return r.finishReservation(ctx, req.Id, ReservationReleased, now)
+}
+
+// CheckoutAmount returns a reservation that can still be paid for and what its
+// order costs.
+func (r *ReservationRepo) CheckoutAmount(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, float32, error) {
+	res, err := r.GetReservation(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+	if res.Status != ReservationHeld {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "reservation is already %s", res.Status)
+	}
+	expiresAt, err := time.Parse(time.RFC3339, res.ExpiresAt)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !now.Before(expiresAt) {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "reservation expired at %s", res.ExpiresAt)
+	}
+
+	var amount float32
+	err = r.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(quantity * discounted_price), 0) FROM order_items WHERE order_id = $1`, res.OrderId).Scan(&amount)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, amount, nil
+}
+
+// CompleteCheckout confirms a held reservation that the payment provider
+// charged for, and books the charge: a debit in the ledger and the payment.
+func (r *ReservationRepo) CompleteCheckout(ctx context.Context, req *pb.GetById, payment *pb.Payment, now time.Time) (*pb.Reservation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, changed, err := finishReservationTx(ctx, tx, req.Id, ReservationConfirmed, now)
+	if err != nil {
+		return nil, err
+	}
+	// a confirmed reservation was either paid with this payment already, which
+	// makes the call a retry, or confirmed some other way
+	if !changed {
+		err = tx.QueryRowContext(ctx, `
+			SELECT
+				id,
+				created_at
+			FROM
+				payments
+			WHERE
+				order_id = $1
+			AND
+				provider = $2
+			AND
+				provider_payment_id = $3`, res.OrderId, payment.Provider, payment.ProviderPaymentId).Scan(&payment.Id, &payment.CreatedAt)
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.FailedPrecondition, "reservation is already confirmed")
+		} else if err != nil {
+			return nil, err
+		}
+		payment.OrderId = res.OrderId
+		return res, r.fillReservationItems(ctx, res)
+	}
+
+	transactionID := uuid.NewString()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO
+			transactions
+			(id,
+			user_id,
+			order_id,
+			amount,
+			type)
+			VALUES
+			($1, $2, $3, $4, 'debit')`, transactionID, res.UserId, res.OrderId, payment.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Id = uuid.NewString()
+	payment.OrderId = res.OrderId
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO
+			payments
+			(id,
+			order_id,
+			transaction_id,
+			provider,
+			provider_payment_id,
+			amount,
+			status)
+			VALUES
+			($1, $2, $3, $4, $5, $6, $7)
+		RETURNING
+			created_at`, payment.Id, payment.OrderId, transactionID, payment.Provider, payment.ProviderPaymentId, payment.Amount, payment.Status).
+		Scan(&payment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, r.fillReservationItems(ctx, res)
+}
+
+// CancelCheckout undoes CompleteCheckout: the order is canceled, its units go
+// back to the sale, the reservation is released and the ledger gets a credit
+// for the payment. A reservation that was never confirmed is left alone.
+func (r *ReservationRepo) CancelCheckout(ctx context.Context, req *pb.GetById, now time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var orderID string
+	err = tx.QueryRowContext(ctx, `SELECT order_id FROM reservations WHERE id = $1`, req.Id).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return status.Errorf(codes.NotFound, "reservation not found")
+	} else if err != nil {
+		return err
+	}
+
+	current, err := lockOrderStatus(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	var (
+		userID      string
+		reservation string
+	)
+	err = tx.QueryRowContext(ctx, `SELECT user_id, status FROM reservations WHERE id = $1 FOR UPDATE`, req.Id).Scan(&userID, &reservation)
+	if err != nil {
+		return err
+	}
+	if reservation != ReservationConfirmed {
+		return nil
+	}
+	if err = orderstate.Check(current, orderstate.Canceled); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE orders SET status = 'canceled', updated_at = NOW() WHERE id = $1`, orderID)
+	if err != nil {
+		return err
+	}
+	if err = releaseOrderStock(ctx, tx, orderID); err != nil {
+		return err
+	}
+	if err = trackOrderStatus(ctx, tx, orderID, orderstate.Canceled, "", ""); err != nil {
+		return err
+	}
+	if err = orderCanceled(ctx, tx, orderID, "checkout rolled back"); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE reservations SET status = 'released', updated_at = $1 WHERE id = $2`, now, req.Id)
+	if err != nil {
+		return err
+	}
+
+	var paid float32
+	err = tx.QueryRowContext(ctx, `SELECT COALESCE(SUM(amount), 0) FROM payments WHERE order_id = $1 AND status = 'captured'`, orderID).Scan(&paid)
+	if err != nil {
+		return err
+	}
+	if paid > 0 {
+		// booked as a processed refund so the refund workflow sees nothing left to refund
+		transactionID := uuid.NewString()
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO
+				transactions
+				(id,
+				user_id,
+				order_id,
+				amount,
+				type)
+				VALUES
+				($1, $2, $3, $4, 'credit')`, transactionID, userID, orderID, paid)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO
+				refunds
+				(id,
+				order_id,
+				refund_status,
+				refund_amount,
+				reason,
+				transaction_id)
+				VALUES
+				($1, $2, 'processed', $3, 'checkout rolled back', $4)`, uuid.NewString(), orderID, paid, transactionID)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE payments SET status = 'refunded', updated_at = NOW() WHERE order_id = $1 AND status = 'captured'`, orderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ReservationRepo) GetReservation(ctx context.Context, req *pb.GetById) (*pb.Reservation, error) {
+	res, _, err := scanReservation(r.db.QueryRowContext(ctx, `
+		SELECT
+			id,
+			order_id,
+			user_id,
+			status,
+			expires_at,
+			created_at
+		FROM
+			reservations
+		WHERE
+			id = $1`, req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, r.fillReservationItems(ctx, res)
+}
+
+// ExpireReservations cancels the orders of holds whose expires_at has passed
+// and returns the holds it expired. A hold that fails to expire is logged and
+// picked up again by the next sweep.
+func (r *ReservationRepo) ExpireReservations(ctx context.Context, now time.Time) ([]*pb.Reservation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			id
+		FROM
+			reservations
+		WHERE
+			status = 'held'
+		AND
+			expires_at <= $1
+		ORDER BY
+			expires_at
+		LIMIT $2`, now, expireBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	expired := make([]*pb.Reservation, 0, len(ids))
+	for _, id := range ids {
+		res, err := r.finishReservation(ctx, id, ReservationExpired, now)
+		if err != nil {
+			log.Printf("Error while expiring reservation %s: %v", id, err)
+			continue
+		}
+		expired = append(expired, res)
+	}
+	return expired, nil
+}
+
+// finishReservation moves a held reservation to outcome and its pending order to
+// confirmed or canceled. Repeating the same outcome returns the reservation as is.
+func (r *ReservationRepo) finishReservation(ctx context.Context, id, outcome string, now time.Time) (*pb.Reservation, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, _, err := finishReservationTx(ctx, tx, id, outcome, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return res, r.fillReservationItems(ctx, res)
+}
+
+// finishReservationTx is finishReservation inside the caller's transaction. It
+// reports whether the reservation changed, false when it already had outcome.
+func finishReservationTx(ctx context.Context, tx dbtx, id, outcome string, now time.Time) (*pb.Reservation, bool, error) {
+	var orderID string
+	err := tx.QueryRowContext(ctx, `SELECT order_id FROM reservations WHERE id = $1`, id).Scan(&orderID)
+	if err == sql.ErrNoRows {
+		return nil, false, status.Errorf(codes.NotFound, "reservation not found")
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	// lock the order before the hold, the same order CancelOrder takes them in
+	current, err := lockOrderStatus(ctx, tx, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res, expiresAt, err := scanReservation(tx.QueryRowContext(ctx, `
+		SELECT
+			id,
+			order_id,
+			user_id,
+			status,
+			expires_at,
+			created_at
+		FROM
+			reservations
+		WHERE
+			id = $1
+		FOR UPDATE`, id))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if res.Status == outcome {
+		return res, false, nil
+	}
+	if res.Status != ReservationHeld {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "reservation is already %s", res.Status)
+	}
+	if current != orderstate.Pending {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "order of the reservation is already %s", current)
+	}
+
+	expired := !now.Before(expiresAt)
+	if outcome == ReservationConfirmed && expired {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "reservation expired at %s", res.ExpiresAt)
+	}
+	if outcome == ReservationExpired && !expired {
+		return nil, false, status.Errorf(codes.FailedPrecondition, "reservation is held until %s", res.ExpiresAt)
+	}
+
+	orderStatus := orderstate.Canceled
+	if outcome == ReservationConfirmed {
+		orderStatus = orderstate.Confirmed
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE orders SET status = $1, updated_at = NOW() WHERE id = $2`, orderStatus, orderID)
+	if err != nil {
+		return nil, false, err
+	}
+	if orderStatus == orderstate.Canceled {
+		if err = releaseOrderStock(ctx, tx, orderID); err != nil {
+			return nil, false, err
+		}
+	}
+	if err = trackOrderStatus(ctx, tx, orderID, orderStatus, "", ""); err != nil {
+		return nil, false, err
+	}
+	if orderStatus == orderstate.Canceled {
+		if err = orderCanceled(ctx, tx, orderID, "reservation "+outcome); err != nil {
+			return nil, false, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE reservations SET status = $1, updated_at = $2 WHERE id = $3`, outcome, now, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	res.Status = outcome
+	return res, true, nil
+}
+
+func (r *ReservationRepo) fillReservationItems(ctx context.Context, res *pb.Reservation) error {
+	order := &pb.Order{Id: res.OrderId}
+	if err := (&OrderRepo{db: r.db}).fillOrderItems(ctx, []*pb.Order{order}); err != nil {
+		return err
+	}
+	res.Items = order.Items
+	return nil
+}
+
+func scanReservation(row rowScanner) (*pb.Reservation, time.Time, error) {
+	var (
+		res       pb.Reservation
+		expiresAt time.Time
+	)
+	err := row.Scan(
+		&res.Id,
+		&res.OrderId,
+		&res.UserId,
+		&res.Status,
+		&expiresAt,
+		&res.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, status.Errorf(codes.NotFound, "reservation not found")
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	res.ExpiresAt = expiresAt.Format(time.RFC3339)
+	return &res, expiresAt, nil
+}
@@ -1,40 +1,62 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage"
 )
 
 type ReviewRepo struct {
-	DB *sql.DB
+	DB *conn
 }
 
 func NewReviewRepo(db *sql.DB) *ReviewRepo {
 	return &ReviewRepo{
-		DB: db,
+		DB: newConn(db),
 	}
 }
 
-func (r *ReviewRepo) CreateReview(req *pb.CreateReviewReq) (*pb.Void, error) {
-	_, err := r.DB.Exec(`INSERT INTO reviews (user_id, product_id, rating, review_text, created_at)
+func (r *ReviewRepo) CreateReview(ctx context.Context, req *pb.CreateReviewReq) (*pb.Void, error) {
+	tx, err := r.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO reviews (user_id, product_id, rating, review_text, created_at)
         VALUES ($1, $2, $3, $4, $5)`, req.UserId, req.ProductId, req.Rating, req.ReviewText, req.CreatedAt)
 
 	if err != nil {
 		return nil, err
 	}
 
-	err = r.UpdateProductRating(req.ProductId)
+	err = updateProductRating(ctx, tx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addEvent(ctx, tx, storage.ReviewPostedTopic, req.ProductId, &pb.ReviewPosted{
+		ProductId:  req.ProductId,
+		UserId:     req.UserId,
+		Rating:     req.Rating,
+		ReviewText: req.ReviewText,
+		PostedAt:   req.CreatedAt,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
 	return &pb.Void{}, nil
 }
 
-func (r *ReviewRepo) GetProductRating(req *pb.GetProductRatingReq) (*pb.ProductRatingRes, error) {
+func (r *ReviewRepo) GetProductRating(ctx context.Context, req *pb.GetProductRatingReq) (*pb.ProductRatingRes, error) {
 	var res pb.ProductRatingRes
-	err := r.DB.QueryRow(`
+	err := r.DB.QueryRowContext(ctx, `
         SELECT average_rating, total_reviews
         FROM products
         WHERE id = $1
@@ -48,11 +70,15 @@ func (r *ReviewRepo) GetProductRating(req *pb.GetProductRatingReq) (*pb.ProductR
 	return &res, nil
 }
 
-func (r *ReviewRepo) UpdateProductRating(productId string) error {
+func (r *ReviewRepo) UpdateProductRating(ctx context.Context, productId string) error {
+	return updateProductRating(ctx, r.DB, productId)
+}
+
+func updateProductRating(ctx context.Context, q dbtx, productId string) error {
 	var totalReviews int64
 	var sumRatings int64
 
-	err := r.DB.QueryRow(`
+	err := q.QueryRowContext(ctx, `
         SELECT COUNT(*), SUM(rating)
         FROM reviews
         WHERE product_id = $1
@@ -64,7 +90,7 @@ func (r *ReviewRepo) UpdateProductRating(productId string) error {
 
 	if totalReviews > 0 {
 		averageRating := float64(sumRatings) / float64(totalReviews)
-		_, err = r.DB.Exec(`
+		_, err = q.ExecContext(ctx, `
             INSERT INTO products (id, average_rating, total_reviews)
             VALUES ($1, $2, $3)
             ON CONFLICT (id) DO UPDATE
@@ -75,7 +101,7 @@ func (r *ReviewRepo) UpdateProductRating(productId string) error {
 			return err
 		}
 	} else {
-		_, err = r.DB.Exec(`
+		_, err = q.ExecContext(ctx, `
             INSERT INTO products (id, average_rating, total_reviews)
             VALUES ($1, 0, 0)
             ON CONFLICT (id) DO UPDATE
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/google/uuid"
+)
+
+type SagaRepo struct {
+	db *conn
+}
+
+func NewSagaRepo(db *sql.DB) *SagaRepo {
+	return &SagaRepo{
+		db: newConn(db),
+	}
+}
+
+func (r *SagaRepo) CreateSaga(ctx context.Context, saga *storage.Saga) error {
+	if saga.ID == "" {
+		saga.ID = uuid.NewString()
+	}
+
+	return r.db.QueryRowContext(ctx, `
+		INSERT INTO
+			sagas
+			(id,
+			kind,
+			status,
+			step,
+			data)
+			VALUES
+			($1, $2, $3, $4, $5)
+		RETURNING
+			updated_at`, saga.ID, saga.Kind, saga.Status, saga.Step, saga.Data).Scan(&saga.UpdatedAt)
+}
+
+// UpdateSaga saves the progress of a saga. Saving also renews its lease, so a
+// saga that keeps making progress is never taken for stuck.
+func (r *SagaRepo) UpdateSaga(ctx context.Context, saga *storage.Saga) error {
+	return r.db.QueryRowContext(ctx, `
+		UPDATE
+			sagas
+		SET
+			status = $1,
+			step = $2,
+			data = $3,
+			error = $4,
+			updated_at = NOW()
+		WHERE
+			id = $5
+		RETURNING
+			updated_at`, saga.Status, saga.Step, saga.Data, nullString(saga.Error), saga.ID).Scan(&saga.UpdatedAt)
+}
+
+// ClaimStuckSagas returns up to limit unfinished sagas that made no progress
+// since staleBefore. Claiming renews their lease and counts an attempt, so
+// replicas sweeping at the same time never claim the same saga.
+func (r *SagaRepo) ClaimStuckSagas(ctx context.Context, staleBefore time.Time, limit int) ([]*storage.Saga, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		UPDATE
+			sagas
+		SET
+			attempts = attempts + 1,
+			updated_at = NOW()
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					sagas
+				WHERE
+					status IN ('running', 'compensating')
+				AND
+					updated_at < $1
+				ORDER BY
+					updated_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED)
+		RETURNING
+			id,
+			kind,
+			status,
+			step,
+			data,
+			error,
+			attempts,
+			updated_at`, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []*storage.Saga
+	for rows.Next() {
+		var (
+			saga    storage.Saga
+			sagaErr sql.NullString
+		)
+		err := rows.Scan(&saga.ID, &saga.Kind, &saga.Status, &saga.Step, &saga.Data, &sagaErr, &saga.Attempts, &saga.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		saga.Error = sagaErr.String
+		sagas = append(sagas, &saga)
+	}
+	return sagas, rows.Err()
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -9,17 +10,23 @@ import (
 )
 
 type SocialRepo struct {
-	DB *sql.DB
+	DB *conn
 }
 
 func NewSocialRepo(db *sql.DB) *SocialRepo {
 	return &SocialRepo{
-		DB: db,
+		DB: newConn(db),
 	}
 }
 
-func (s *SocialRepo) ShareDeal(req *pb.ShareDealReq) (*pb.Void, error) {
-	_, err := s.DB.Exec(`INSERT INTO shared_deals (user_id, flash_sale_id, platform, message, shared_at)
+// ShareDeal records a share and counts it in the sale's sharing stats. It runs
+// inside the caller's unit of work, see Storage.WithTx.
+func (s *SocialRepo) ShareDeal(ctx context.Context, req *pb.ShareDealReq) (*pb.Void, error) {
+	if err := s.DB.unitOfWork("ShareDeal"); err != nil {
+		return nil, err
+	}
+
+	_, err := s.DB.ExecContext(ctx, `INSERT INTO shared_deals (user_id, flash_sale_id, platform, message, shared_at)
         VALUES ($1, $2, $3, $4, $5)`, req.UserId, req.FlashSaleId, req.Platform, req.Message, req.SharedAt)
 
 	if err != nil {
@@ -27,7 +34,7 @@ func (s *SocialRepo) ShareDeal(req *pb.ShareDealReq) (*pb.Void, error) {
 	}
 
 	var sharesByPlatform map[string]int64
-	row := s.DB.QueryRow(`
+	row := s.DB.QueryRowContext(ctx, `
         SELECT shares_by_platform FROM sharing_stats WHERE flash_sale_id = $1
     `, req.FlashSaleId)
 
@@ -50,7 +57,7 @@ func (s *SocialRepo) ShareDeal(req *pb.ShareDealReq) (*pb.Void, error) {
 
 	platformDataBytes, _ := json.Marshal(sharesByPlatform)
 
-	_, err = s.DB.Exec(`
+	_, err = s.DB.ExecContext(ctx, `
         INSERT INTO sharing_stats (flash_sale_id, total_shares, shares_by_platform)
         VALUES ($1, $2, $3)
         ON CONFLICT (flash_sale_id) DO UPDATE
@@ -65,12 +72,12 @@ func (s *SocialRepo) ShareDeal(req *pb.ShareDealReq) (*pb.Void, error) {
 	return &pb.Void{}, nil
 }
 
-func (s *SocialRepo) GetSharingStats(req *pb.GetSharingStatsReq) (*pb.SharingStatsRes, error) {
+func (s *SocialRepo) GetSharingStats(ctx context.Context, req *pb.GetSharingStatsReq) (*pb.SharingStatsRes, error) {
 	var totalShares int64
 	var platformData string
 	sharesByPlatform := make(map[string]int64)
 
-	row := s.DB.QueryRow(`
+	row := s.DB.QueryRowContext(ctx, `
         SELECT total_shares, shares_by_platform
         FROM sharing_stats
         WHERE flash_sale_id = $1
@@ -89,8 +96,8 @@ func (s *SocialRepo) GetSharingStats(req *pb.GetSharingStatsReq) (*pb.SharingSta
 	}
 
 	return &pb.SharingStatsRes{
-		FlashSaleId:       req.FlashSaleId,
-		TotalShares:       totalShares,
+		FlashSaleId:      req.FlashSaleId,
+		TotalShares:      totalShares,
 		SharesByPlatform: sharesByPlatform,
 	}, nil
 }
@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransactionRepo struct {
+	db *conn
+}
+
+func NewTransactionRepo(db *sql.DB) *TransactionRepo {
+	return &TransactionRepo{
+		db: newConn(db),
+	}
+}
+
+func (r *TransactionRepo) CreateTransaction(ctx context.Context, req *pb.TransactionCreateReq) (*pb.Void, error) {
+	id := uuid.NewString()
+
+	query := `INSERT INTO
+		transactions
+		(id,
+		user_id,
+		product_id,
+		flash_sale_product_id,
+		amount,
+		type)
+		VALUES
+		($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.ExecContext(ctx, query, id, req.UserId, nullString(req.ProductId), nullString(req.FlashSaleId), req.Amount, req.Type)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Void{}, nil
+}
+
+func (r *TransactionRepo) GetTransaction(ctx context.Context, req *pb.GetById) (*pb.TransactionGetRes, error) {
+	query := `
+		SELECT
+			t.id,
+			t.user_id,
+			t.amount,
+			t.type,
+			t.created_at,
+			p.id,
+			p.name,
+			p.price,
+			f.id,
+			f.discounted_price,
+			t.order_id
+		FROM
+			transactions t
+		LEFT JOIN
+			products p
+		ON
+			t.product_id = p.id
+		LEFT JOIN
+			flash_sales_products f
+		ON
+			t.flash_sale_product_id = f.id
+		WHERE
+			t.id = $1
+		AND
+			t.deleted_at = 0`
+
+	res, err := scanTransaction(r.db.QueryRowContext(ctx, query, req.Id))
+	if err == sql.ErrNoRows {
+		return nil, status.Errorf(codes.NotFound, "transaction not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *TransactionRepo) ListTransactions(ctx context.Context, req *pb.TransactionListReq) (*pb.TransactionListRes, error) {
+	query := `
+		SELECT
+			t.id,
+			t.user_id,
+			t.amount,
+			t.type,
+			t.created_at,
+			p.id,
+			p.name,
+			p.price,
+			f.id,
+			f.discounted_price,
+			t.order_id
+		FROM
+			transactions t
+		LEFT JOIN
+			products p
+		ON
+			t.product_id = p.id
+		LEFT JOIN
+			flash_sales_products f
+		ON
+			t.flash_sale_product_id = f.id
+		WHERE
+			t.deleted_at = 0`
+
+	var args []interface{}
+
+	if req.UserId != "" && req.UserId != "string" {
+		args = append(args, req.UserId)
+		query += fmt.Sprintf(" AND t.user_id = $%d", len(args))
+	}
+	if req.ProductId != "" && req.ProductId != "string" {
+		args = append(args, req.ProductId)
+		query += fmt.Sprintf(" AND t.product_id = $%d", len(args))
+	}
+	if req.FlashSaleId != "" && req.FlashSaleId != "string" {
+		args = append(args, req.FlashSaleId)
+		query += fmt.Sprintf(" AND t.flash_sale_product_id = $%d", len(args))
+	}
+	if req.Type != "" && req.Type != "string" {
+		args = append(args, req.Type)
+		query += fmt.Sprintf(" AND t.type = $%d", len(args))
+	}
+	if req.AmountFrom > 0 {
+		args = append(args, req.AmountFrom)
+		query += fmt.Sprintf(" AND t.amount >= $%d", len(args))
+	}
+	if req.AmountTo > 0 {
+		args = append(args, req.AmountTo)
+		query += fmt.Sprintf(" AND t.amount <= $%d", len(args))
+	}
+
+	query += " ORDER BY t.created_at DESC"
+
+	if req.Filter != nil && req.Filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+		args = append(args, req.Filter.Limit, req.Filter.Offset)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := &pb.TransactionListRes{
+		Transactions: make([]*pb.TransactionGetRes, 0),
+	}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		res.Transactions = append(res.Transactions, transaction)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res.TotalCount = int64(len(res.Transactions))
+	return res, nil
+}
+
+func (r *TransactionRepo) GetBalance(ctx context.Context, req *pb.GetById) (*pb.BalanceGetRes, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE type = 'credit'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE type = 'debit' AND flash_sale_product_id IS NULL), 0),
+			COALESCE(SUM(amount) FILTER (WHERE type = 'debit' AND flash_sale_product_id IS NOT NULL), 0)
+		FROM
+			transactions
+		WHERE
+			user_id = $1
+		AND
+			deleted_at = 0`
+
+	usage := &pb.BalanceGetRes_Stats{}
+	err := r.db.QueryRowContext(ctx, query, req.Id).Scan(
+		&usage.Payments,
+		&usage.ProductsSpendings,
+		&usage.FlashSaleSpendings,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BalanceGetRes{
+		Balance:  usage.Payments - usage.ProductsSpendings - usage.FlashSaleSpendings,
+		GetUsage: true,
+		Usage_:   usage,
+	}, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTransaction(row rowScanner) (*pb.TransactionGetRes, error) {
+	var (
+		res             pb.TransactionGetRes
+		productID       sql.NullString
+		productName     sql.NullString
+		productPrice    sql.NullFloat64
+		flashSaleID     sql.NullString
+		discountedPrice sql.NullFloat64
+		orderID         sql.NullString
+	)
+
+	err := row.Scan(
+		&res.Id,
+		&res.UserId,
+		&res.Amount,
+		&res.Type,
+		&res.CreatedAt,
+		&productID,
+		&productName,
+		&productPrice,
+		&flashSaleID,
+		&discountedPrice,
+		&orderID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	res.OrderId = orderID.String
+
+	if productID.Valid {
+		res.Products = &pb.Products{
+			Id:    productID.String,
+			Name:  productName.String,
+			Price: float32(productPrice.Float64),
+		}
+	}
+	if flashSaleID.Valid {
+		res.FlashSale = &pb.FlashSaleProduct{
+			Id:              flashSaleID.String,
+			DiscountedPrice: float32(discountedPrice.Float64),
+		}
+	}
+	return &res, nil
+}
+
+// nullString stores empty optional ids as NULL instead of an invalid uuid.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// txAttempts bounds how often WithTx runs a unit of work that Postgres keeps
+// aborting with a serialization failure or a deadlock.
+const txAttempts = 3
+
+// dbtx is what statements run on: the pool, a transaction or a savepoint in one.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txn is what conn.Begin hands out: a transaction, or a savepoint when the
+// repository already runs inside one.
+type txn interface {
+	dbtx
+	Commit() error
+	Rollback() error
+}
+
+// conn is the connection a repository runs on. Repositories of a unit of work
+// share one bound to its transaction; the others share one on the pool.
+type conn struct {
+	pool       *sql.DB
+	tx         *sql.Tx
+	savepoints int
+}
+
+func newConn(db *sql.DB) *conn {
+	return &conn{pool: db}
+}
+
+func (c *conn) db() dbtx {
+	if c.tx != nil {
+		return c.tx
+	}
+	return c.pool
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.db().ExecContext(ctx, query, args...)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db().QueryContext(ctx, query, args...)
+}
+
+func (c *conn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.db().QueryRowContext(ctx, query, args...)
+}
+
+// Begin starts a transaction of its own, or a savepoint in the unit of work
+// the connection is bound to, so a repository method is atomic either way.
+func (c *conn) Begin(ctx context.Context) (txn, error) {
+	if c.tx == nil {
+		return c.pool.BeginTx(ctx, nil)
+	}
+
+	c.savepoints++
+	sp := &savepoint{Tx: c.tx, ctx: ctx, name: fmt.Sprintf("sp_%d", c.savepoints)}
+	if _, err := c.tx.ExecContext(ctx, "SAVEPOINT "+sp.name); err != nil {
+		return nil, err
+	}
+	return sp, nil
+}
+
+// unitOfWork fails methods that take their atomicity from the caller's unit of
+// work when they are called outside of one.
+func (c *conn) unitOfWork(method string) error {
+	if c.tx == nil {
+		return status.Errorf(codes.Internal, "%s must run inside WithTx", method)
+	}
+	return nil
+}
+
+type savepoint struct {
+	*sql.Tx
+	ctx  context.Context
+	name string
+	done bool
+}
+
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.Tx.ExecContext(s.ctx, "ROLLBACK TO SAVEPOINT "+s.name)
+	return err
+}
+
+// WithTx runs fn in one serializable transaction with repositories bound to
+// it. The transaction commits when fn returns nil and rolls back otherwise;
+// when Postgres aborts it to keep transactions serializable, fn runs again on
+// a fresh one. Called on storage that is already bound, fn joins the running
+// transaction.
+func (s *Storage) WithTx(ctx context.Context, fn func(tx storage.StorageI) error) error {
+	if s.conn.tx != nil {
+		return fn(s)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := s.runTx(ctx, fn)
+		if !retryable(err) || attempt == txAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * 10 * time.Millisecond):
+		}
+	}
+}
+
+func (s *Storage) runTx(ctx context.Context, fn func(tx storage.StorageI) error) (err error) {
+	tx, err := s.conn.pool.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = fn(newStorage(&conn{pool: s.conn.pool, tx: tx})); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// retryable reports whether a transaction failed only because it ran
+// concurrently with others and may succeed when run again.
+func retryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code {
+	case "40001", // serialization_failure
+		"40P01": // deadlock_detected
+		return true
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -9,21 +10,21 @@ import (
 )
 
 type UserRepo struct {
-	db *sql.DB
+	db *conn
 }
 
 func NewUserRepo(db *sql.DB) *UserRepo {
 	return &UserRepo{
-		db: db,
+		db: newConn(db),
 	}
 }
 
-func (r *UserRepo) GetProfile(req *pb.GetByID) (*pb.UserRes, error) {
+func (r *UserRepo) GetProfile(ctx context.Context, req *pb.GetByID) (*pb.UserRes, error) {
 	res := &pb.UserRes{}
 
 	var date string
 	query := `SELECT id, username, email, full_name, date_of_birth, role FROM users WHERE id = $1`
-	err := r.db.QueryRow(query, req.Id).
+	err := r.db.QueryRowContext(ctx, query, req.Id).
 		Scan(
 			&res.Id,
 			&res.Username,
@@ -43,7 +44,7 @@ func (r *UserRepo) GetProfile(req *pb.GetByID) (*pb.UserRes, error) {
 	return res, nil
 }
 
-func (r *UserRepo) EditProfile(req *pb.UserRes) (*pb.UserRes, error) {
+func (r *UserRepo) EditProfile(ctx context.Context, req *pb.UserRes) (*pb.UserRes, error) {
 	res := &pb.UserRes{}
 
 	query := `UPDATE users SET updated_at = NOW()`
@@ -78,7 +79,7 @@ func (r *UserRepo) EditProfile(req *pb.UserRes) (*pb.UserRes, error) {
 	query += fmt.Sprintf(" WHERE id = $%d", len(arg)+1)
 	arg = append(arg, req.Id)
 
-	_, err := r.db.Exec(query, arg...)
+	_, err := r.db.ExecContext(ctx, query, arg...)
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +87,12 @@ func (r *UserRepo) EditProfile(req *pb.UserRes) (*pb.UserRes, error) {
 	return res, nil
 }
 
-func (r *UserRepo) ChangePassword(req *pb.ChangePasswordReq) (*pb.Void, error) {
+func (r *UserRepo) ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.Void, error) {
 	res := &pb.Void{}
 
 	query := `SELECT password FROM users WHERE id = $1`
 	var password string
-	err := r.db.QueryRow(query, req.Id).Scan(&password)
+	err := r.db.QueryRowContext(ctx, query, req.Id).Scan(&password)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +102,7 @@ func (r *UserRepo) ChangePassword(req *pb.ChangePasswordReq) (*pb.Void, error) {
 	}
 
 	query = `UPDATE users SET updated_at = NOW(), password = $1 WHERE id = $2`
-	_, err = r.db.Exec(query, req.NewPassword, req.Id)
+	_, err = r.db.ExecContext(ctx, query, req.NewPassword, req.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -109,11 +110,11 @@ func (r *UserRepo) ChangePassword(req *pb.ChangePasswordReq) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *UserRepo) GetSetting(req *pb.GetByID) (*pb.Setting, error) {
+func (r *UserRepo) GetSetting(ctx context.Context, req *pb.GetByID) (*pb.Setting, error) {
 	res := &pb.Setting{}
 
 	query := `SELECT privacy_level, notification, language, theme FROM settings WHERE user_id = $1`
-	err := r.db.QueryRow(query, req.Id).
+	err := r.db.QueryRowContext(ctx, query, req.Id).
 		Scan(
 			&res.PrivacyLevel,
 			&res.Notification,
@@ -129,7 +130,7 @@ func (r *UserRepo) GetSetting(req *pb.GetByID) (*pb.Setting, error) {
 	return res, nil
 }
 
-func (r *UserRepo) EditSetting(req *pb.SettingReq) (*pb.Void, error) {
+func (r *UserRepo) EditSetting(ctx context.Context, req *pb.SettingReq) (*pb.Void, error) {
 	res := &pb.Void{}
 
 	query := `UPDATE settings SET updated_at = NOW()`
@@ -163,7 +164,7 @@ func (r *UserRepo) EditSetting(req *pb.SettingReq) (*pb.Void, error) {
 
 	query += fmt.Sprintf(" WHERE user_id = $%d", len(arg)+1)
 	arg = append(arg, req.Id)
-	_, err := r.db.Exec(query, arg...)
+	_, err := r.db.ExecContext(ctx, query, arg...)
 	if err != nil {
 		return nil, err
 	}
@@ -171,23 +172,23 @@ func (r *UserRepo) EditSetting(req *pb.SettingReq) (*pb.Void, error) {
 	return res, nil
 }
 
-func (r *UserRepo) DeleteUser(req *pb.GetByID) (*pb.Void, error) {
+func (r *UserRepo) DeleteUser(ctx context.Context, req *pb.GetByID) (*pb.Void, error) {
 	res := &pb.Void{}
 
-	tr, err := r.db.Begin()
+	tr, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	query := `UPDATE users SET deleted_at = EXTRACT(EPOCH FROM NOW()) WHERE id = $1`
-	_, err = tr.Exec(query, req.Id)
+	_, err = tr.ExecContext(ctx, query, req.Id)
 	if err != nil {
 		tr.Rollback()
 		return nil, err
 	}
 
 	query = `DELETE FROM settings WHERE user_id = $1`
-	_, err = tr.Exec(query, req.Id)
+	_, err = tr.ExecContext(ctx, query, req.Id)
 	if err != nil {
 		tr.Rollback()
 		return nil, err
@@ -0,0 +1,16 @@
+package storage
+
+import "time"
+
+// Saga is the persisted progress of a saga. Step counts the steps that were
+// started; Data is the state the saga's steps share, as JSON.
+type Saga struct {
+	ID        string
+	Kind      string
+	Status    string
+	Step      int
+	Data      []byte
+	Error     string
+	Attempts  int
+	UpdatedAt time.Time
+}
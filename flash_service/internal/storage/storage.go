@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"context"
+	"time"
+
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 )
 
@@ -11,76 +14,139 @@ type StorageI interface {
 	FlashSaleProduct() FlashSaleProductI
 	Notification() NotificationI
 	Order() OrderI
+	Transaction() TransactionI
+	Reservation() ReservationI
+	Refund() RefundI
+	Saga() SagaI
+	Outbox() OutboxI
+	Command() CommandI
+	ProcessedMessage() ProcessedMessageI
 	Product() ProductI
 	Review() ReviewI
 	Social() SocialI
+	// WithTx runs fn in one transaction, handing it storage whose
+	// repositories are all bound to that transaction.
+	WithTx(ctx context.Context, fn func(tx StorageI) error) error
 }
 type AuthI interface {
-	Register(req *pb.RegisterReq) (*pb.Void, error)
-	Login(req *pb.LoginReq) (*pb.User, error)
-	ForgotPassword(req *pb.GetByEmail) (*pb.Void, error)
-	ResetPassword(req *pb.ResetPassReq) (*pb.Void, error)
-	SaveRefreshToken(req *pb.RefToken) (*pb.Void, error)
-	GetAllUsers(req *pb.ListUserReq) (*pb.ListUserRes, error)
-	GetUserById(req *pb.GetById) (*pb.UserRes, error)
+	Register(ctx context.Context, req *pb.RegisterReq) (*pb.Void, error)
+	Login(ctx context.Context, req *pb.LoginReq) (*pb.User, error)
+	ForgotPassword(ctx context.Context, req *pb.GetByEmail) (*pb.Void, error)
+	ResetPassword(ctx context.Context, req *pb.ResetPassReq) (*pb.Void, error)
+	SaveRefreshToken(ctx context.Context, req *pb.RefToken) (*pb.Void, error)
+	RotateRefreshToken(ctx context.Context, req *pb.RotateTokenReq) (*pb.User, error)
+	RevokeRefreshToken(ctx context.Context, req *pb.RefreshReq) (*pb.Void, error)
+	RevokeUserTokens(ctx context.Context, req *pb.GetById) (*pb.Void, error)
+	GetAllUsers(ctx context.Context, req *pb.ListUserReq) (*pb.ListUserRes, error)
+	GetUserById(ctx context.Context, req *pb.GetById) (*pb.UserRes, error)
 }
 type UserI interface {
-	GetProfile(req *pb.GetByID) (*pb.UserRes, error)
-	EditProfile(req *pb.UserRes) (*pb.UserRes, error)
-	ChangePassword(req *pb.ChangePasswordReq) (*pb.Void, error)
-	GetSetting(req *pb.GetByID) (*pb.Setting, error)
-	EditSetting(req *pb.SettingReq) (*pb.Void, error)
-	DeleteUser(req *pb.GetByID) (*pb.Void, error)
+	GetProfile(ctx context.Context, req *pb.GetByID) (*pb.UserRes, error)
+	EditProfile(ctx context.Context, req *pb.UserRes) (*pb.UserRes, error)
+	ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.Void, error)
+	GetSetting(ctx context.Context, req *pb.GetByID) (*pb.Setting, error)
+	EditSetting(ctx context.Context, req *pb.SettingReq) (*pb.Void, error)
+	DeleteUser(ctx context.Context, req *pb.GetByID) (*pb.Void, error)
 }
 
 type FlashSaleI interface {
-	CreateFlashSale(req *pb.CreateFlashSalesReq) (*pb.Void, error)
-	UpdateFlashSale(req *pb.UpdateFlashSalesReq) (*pb.Void, error)
-	ListAllFlashSales(req *pb.ListAllFlashSalesReq) (*pb.ListAllFlashSalesRes, error)
-	GetFlashSale(req *pb.GetById) (*pb.FlashSale, error)
-	DeleteFlashSale(req *pb.GetById) (*pb.Void, error)
-	AddProductToFlashSale(req *pb.AddProductReq) (*pb.Void, error)
-	RemoveProductFromFlashSale(req *pb.RemoveProductReq) (*pb.Void, error)
-	CancelFlashSale(req *pb.GetById) (*pb.CancelFlashSaleRes, error)
-	GetStoreLocation(req *pb.GetStoreLocationReq) (*pb.StoreLocation, error)
+	CreateFlashSale(ctx context.Context, req *pb.CreateFlashSalesReq) (*pb.Void, error)
+	UpdateFlashSale(ctx context.Context, req *pb.UpdateFlashSalesReq) (*pb.Void, error)
+	ListAllFlashSales(ctx context.Context, req *pb.ListAllFlashSalesReq) (*pb.ListAllFlashSalesRes, error)
+	GetFlashSale(ctx context.Context, req *pb.GetById) (*pb.FlashSale, error)
+	DeleteFlashSale(ctx context.Context, req *pb.GetById) (*pb.Void, error)
+	AddProductToFlashSale(ctx context.Context, req *pb.AddProductReq) (*pb.Void, error)
+	RemoveProductFromFlashSale(ctx context.Context, req *pb.RemoveProductReq) (*pb.Void, error)
+	CancelFlashSale(ctx context.Context, req *pb.GetById) (*pb.CancelFlashSaleRes, error)
+	GetStoreLocation(ctx context.Context, req *pb.GetStoreLocationReq) (*pb.StoreLocation, error)
+	AdvanceFlashSales(ctx context.Context, now time.Time) ([]*pb.FlashSaleStatusEvent, error)
+	GetFlashSaleSnapshot(ctx context.Context, req *pb.GetById) ([]*pb.FlashSaleUpdate, error)
 }
 type FlashSaleProductI interface {
-	CreateFlashSaleProduct(req *pb.CreateFlashSaleProductReq) (*pb.Void, error)
-	UpdateFlashSaleProduct(req *pb.UpdateFlashSaleProductReq) (*pb.Void, error)
-	ListAllFlashSaleProducts(req *pb.ListAllFlashSaleProductsReq) (*pb.ListAllFlashSaleProductsRes, error)
-	GetFlashSaleProduct(req *pb.GetById) (*pb.FlashSaleProduct, error)
-	DeleteFlashSaleProduct(req *pb.GetById) (*pb.Void, error)
+	CreateFlashSaleProduct(ctx context.Context, req *pb.CreateFlashSaleProductReq) (*pb.Void, error)
+	UpdateFlashSaleProduct(ctx context.Context, req *pb.UpdateFlashSaleProductReq) (*pb.Void, error)
+	ListAllFlashSaleProducts(ctx context.Context, req *pb.ListAllFlashSaleProductsReq) (*pb.ListAllFlashSaleProductsRes, error)
+	GetFlashSaleProduct(ctx context.Context, req *pb.GetFlashSaleProductReq) (*pb.FlashSaleProduct, error)
+	DeleteFlashSaleProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error)
 }
 type NotificationI interface {
-	CreateNotification(req *pb.NotificationCreate) (*pb.Void, error)
-	DeleteNotification(req *pb.GetById) (*pb.Void, error)
-	UpdateNotification(req *pb.NotificationUpdate) (*pb.Void, error)
-	GetNotifications(req *pb.NotifFilter) (*pb.NotificationList, error)
-	GetNotification(req *pb.GetById) (*pb.NotificationGet, error)
+	CreateNotification(ctx context.Context, req *pb.NotificationCreate) (*pb.Void, error)
+	DeleteNotification(ctx context.Context, req *pb.GetById) (*pb.Void, error)
+	UpdateNotification(ctx context.Context, req *pb.NotificationUpdate) (*pb.Void, error)
+	GetNotifications(ctx context.Context, req *pb.NotifFilter) (*pb.NotificationList, error)
+	GetNotification(ctx context.Context, req *pb.GetById) (*pb.NotificationGet, error)
+	QueueNotification(ctx context.Context, req *pb.NotificationCreate) (string, error)
+	DropNotification(ctx context.Context, req *pb.GetById) error
 }
 type OrderI interface {
-	CreateOrder(req *pb.CreateOrderReq) (*pb.Void, error)
-	UpdateOrder(req *pb.UpdateOrderReq) (*pb.Void, error)
-	ListAllOrders(req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRes, error)
-	GetOrder(req *pb.GetById) (*pb.Order, error)
-	DeleteOrder(req *pb.GetById) (*pb.Void, error)
-	GetOrderHistory(req *pb.OrderHistoryReq) (*pb.OrderHistoryRes, error)
-	CancelOrder(req *pb.GetById) (*pb.CancelOrderRes, error)
+	CreateOrder(ctx context.Context, req *pb.CreateOrderReq) (*pb.Void, error)
+	UpdateOrder(ctx context.Context, req *pb.UpdateOrderReq) (*pb.Void, error)
+	ListAllOrders(ctx context.Context, req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRes, error)
+	GetOrder(ctx context.Context, req *pb.GetById) (*pb.Order, error)
+	DeleteOrder(ctx context.Context, req *pb.GetById) (*pb.Void, error)
+	GetOrderHistory(ctx context.Context, req *pb.OrderHistoryReq) (*pb.OrderHistoryRes, error)
+	CancelOrder(ctx context.Context, req *pb.GetById) (*pb.CancelOrderRes, error)
+	GetOrderTimeline(ctx context.Context, req *pb.GetById) (*pb.OrderTimeline, error)
+}
+type TransactionI interface {
+	CreateTransaction(ctx context.Context, req *pb.TransactionCreateReq) (*pb.Void, error)
+	GetTransaction(ctx context.Context, req *pb.GetById) (*pb.TransactionGetRes, error)
+	ListTransactions(ctx context.Context, req *pb.TransactionListReq) (*pb.TransactionListRes, error)
+	GetBalance(ctx context.Context, req *pb.GetById) (*pb.BalanceGetRes, error)
+}
+type ReservationI interface {
+	CreateReservation(ctx context.Context, req *pb.CreateReservationReq, expiresAt time.Time) (*pb.Reservation, error)
+	ConfirmReservation(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, error)
+	ReleaseReservation(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, error)
+	GetReservation(ctx context.Context, req *pb.GetById) (*pb.Reservation, error)
+	CheckoutAmount(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, float32, error)
+	CompleteCheckout(ctx context.Context, req *pb.GetById, payment *pb.Payment, now time.Time) (*pb.Reservation, error)
+	CancelCheckout(ctx context.Context, req *pb.GetById, now time.Time) error
+	ExpireReservations(ctx context.Context, now time.Time) ([]*pb.Reservation, error)
+}
+type RefundI interface {
+	RequestRefund(ctx context.Context, req *pb.RefundCreateReq) (*pb.Refund, error)
+	GetRefund(ctx context.Context, req *pb.GetById) (*pb.Refund, error)
+	ListRefunds(ctx context.Context, req *pb.RefundListReq) (*pb.RefundListRes, error)
+	ApproveRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error)
+	RejectRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error)
+	ProcessRefund(ctx context.Context, req *pb.GetById, refundPayment RefundPayment) (*pb.Refund, error)
+}
+
+// RefundPayment gives amount of a payment back at the provider that took it
+// and returns the status the provider reports for the payment afterwards.
+type RefundPayment func(ctx context.Context, provider, paymentID string, amount float32) (string, error)
+type SagaI interface {
+	CreateSaga(ctx context.Context, saga *Saga) error
+	UpdateSaga(ctx context.Context, saga *Saga) error
+	ClaimStuckSagas(ctx context.Context, staleBefore time.Time, limit int) ([]*Saga, error)
+}
+type OutboxI interface {
+	ClaimEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*OutboxEvent, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, retryAt time.Time, cause string) error
+}
+type CommandI interface {
+	RecordCommand(ctx context.Context, cmd *pb.CommandStatus) error
+	GetCommandStatus(ctx context.Context, req *pb.CommandStatusReq) (*pb.CommandStatus, error)
+}
+type ProcessedMessageI interface {
+	MarkProcessed(ctx context.Context, messageID string) (bool, error)
 }
 type ProductI interface {
-	CreateProduct(req *pb.CreateProductReq) (*pb.Void, error)
-	UpdateProduct(req *pb.UpdateProductReq) (*pb.Void, error)
-	ListAllProducts(req *pb.ListAllProductsReq) (*pb.ListAllProductsRes, error)
-	GetProduct(req *pb.GetById) (*pb.Products, error)
-	DeleteProduct(req *pb.GetById) (*pb.Void, error)
+	CreateProduct(ctx context.Context, req *pb.CreateProductReq) (*pb.Void, error)
+	UpdateProduct(ctx context.Context, req *pb.UpdateProductReq) (*pb.Void, error)
+	ListAllProducts(ctx context.Context, req *pb.ListAllProductsReq) (*pb.ListAllProductsRes, error)
+	GetProduct(ctx context.Context, req *pb.GetById) (*pb.Products, error)
+	DeleteProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error)
 }
 
 type SocialI interface {
-	ShareDeal(req *pb.ShareDealReq) (*pb.Void, error)
-	GetSharingStats(req *pb.GetSharingStatsReq) (*pb.SharingStatsRes, error)
+	ShareDeal(ctx context.Context, req *pb.ShareDealReq) (*pb.Void, error)
+	GetSharingStats(ctx context.Context, req *pb.GetSharingStatsReq) (*pb.SharingStatsRes, error)
 }
 
 type ReviewI interface {
-	CreateReview(req *pb.CreateReviewReq) (*pb.Void, error)
-	GetProductRating(req *pb.GetProductRatingReq) (*pb.ProductRatingRes, error)
+	CreateReview(ctx context.Context, req *pb.CreateReviewReq) (*pb.Void, error)
+	GetProductRating(ctx context.Context, req *pb.GetProductRatingReq) (*pb.ProductRatingRes, error)
 }
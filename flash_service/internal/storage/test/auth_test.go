@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 	"github.com/Mubinabd/flash_sale/internal/storage/repository"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestRegister(t *testing.T) {
@@ -35,7 +40,7 @@ func TestRegister(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	_, err = authRepo.Register(req)
+	_, err = authRepo.Register(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -66,7 +71,7 @@ func TestLogin(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "role", "password"}).
 			AddRow("1", req.Username, "test@example.com", "user", string(passwordHash)))
 
-	res, err := authRepo.Login(req)
+	res, err := authRepo.Login(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -96,7 +101,7 @@ func TestForgotPassword(t *testing.T) {
 		WithArgs(req.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow(req.Email))
 
-	_, err = authRepo.ForgotPassword(req)
+	_, err = authRepo.ForgotPassword(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -124,7 +129,7 @@ func TestSaveRefreshToken(t *testing.T) {
 		WithArgs(req.UserId, req.Token).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = authRepo.SaveRefreshToken(req)
+	_, err = authRepo.SaveRefreshToken(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -157,7 +162,7 @@ func TestGetAllUsers(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "full_name", "email", "date_of_birth", "role"}).
 			AddRow("1", "testuser", "Test User", "test@example.com", "2000-01-01", "user"))
 
-	res, err := authRepo.GetAllUsers(req)
+	res, err := authRepo.GetAllUsers(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -188,7 +193,7 @@ func TestGetUserById(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "full_name", "email", "date_of_birth", "role"}).
 			AddRow("1", "testuser", "Test User", "test@example.com", "2000-01-01", "user"))
 
-	res, err := authRepo.GetUserById(req)
+	res, err := authRepo.GetUserById(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -200,3 +205,114 @@ func TestGetUserById(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+var tokenColumns = []string{"id", "user_id", "family_id", "rotated_at", "deleted_at"}
+
+func TestRotateRefreshToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	authRepo := repository.NewAuthRepo(db)
+	req := &pb.RotateTokenReq{Token: "old-token", NewToken: "new-token"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id, user_id, family_id, rotated_at, deleted_at FROM tokens WHERE token = \$1 FOR UPDATE`).
+		WithArgs(req.Token).
+		WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow("token-1", "user-1", "family-1", nil, 0))
+	mock.ExpectExec(`UPDATE tokens SET rotated_at = now\(\)`).
+		WithArgs("token-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO tokens \(user_id, token, family_id\)`).
+		WithArgs("user-1", req.NewToken, "family-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT id, username, email, role FROM users`).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "role"}).AddRow("user-1", "mubina", "mubina@example.com", "user"))
+	mock.ExpectCommit()
+
+	user, err := authRepo.RotateRefreshToken(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.Id != "user-1" || user.Role != "user" {
+		t.Errorf("unexpected user %+v", user)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRotateRefreshTokenReuse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	authRepo := repository.NewAuthRepo(db)
+
+	// the token was rotated before, the family goes and that sticks
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM tokens WHERE token = \$1 FOR UPDATE`).
+		WithArgs("old-token").
+		WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow("token-1", "user-1", "family-1", time.Now(), 0))
+	mock.ExpectExec(`UPDATE tokens SET deleted_at = extract\(epoch from now\(\)\)(.+)WHERE family_id = \$1 AND deleted_at = 0`).
+		WithArgs("family-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	// a token of a revoked family is refused
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM tokens WHERE token = \$1 FOR UPDATE`).
+		WithArgs("new-token").
+		WillReturnRows(sqlmock.NewRows(tokenColumns).AddRow("token-2", "user-1", "family-1", nil, 1722513600))
+	mock.ExpectRollback()
+	// and an unknown one too
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT (.+) FROM tokens WHERE token = \$1 FOR UPDATE`).
+		WithArgs("forged-token").
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	for _, token := range []string{"old-token", "new-token", "forged-token"} {
+		_, err := authRepo.RotateRefreshToken(context.Background(), &pb.RotateTokenReq{Token: token, NewToken: "next-token"})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected Unauthenticated for %s, got %v", token, err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRevokeTokens(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	defer db.Close()
+
+	authRepo := repository.NewAuthRepo(db)
+
+	mock.ExpectExec(`UPDATE tokens SET deleted_at = extract\(epoch from now\(\)\)(.+)WHERE family_id = \(SELECT family_id FROM tokens WHERE token = \$1\) AND deleted_at = 0`).
+		WithArgs("some-token").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`UPDATE tokens SET deleted_at = extract\(epoch from now\(\)\)(.+)WHERE user_id = \$1 AND deleted_at = 0`).
+		WithArgs("user-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	if _, err := authRepo.RevokeRefreshToken(context.Background(), &pb.RefreshReq{RefreshToken: "some-token"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if _, err := authRepo.RevokeUserTokens(context.Background(), &pb.GetById{Id: "user-1"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
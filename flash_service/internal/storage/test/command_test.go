@@ -0,0 +1,93 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+)
+
+var commandColumns = []string{"id", "topic", "status", "error", "attempts", "created_at", "updated_at", "user_id"}
+
+func TestRecordCommand(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewCommandRepo(db)
+
+	mock.ExpectQuery("INSERT INTO(.+)commands(.+)ON CONFLICT \\(id\\) DO UPDATE SET(.+)attempts = commands.attempts \\+ 1").
+		WithArgs("cmd-1", "update-order", "failed", "order not found", "user-1").
+		WillReturnRows(sqlmock.NewRows(commandColumns).
+			AddRow("cmd-1", "update-order", "failed", "order not found", 3, "2024-08-01T12:00:00Z", "2024-08-01T12:00:02Z", "user-1"))
+
+	cmd := &pb.CommandStatus{Id: "cmd-1", Topic: "update-order", Status: "failed", Error: "order not found", UserId: "user-1"}
+	if err := repo.RecordCommand(context.Background(), cmd); err != nil {
+		t.Fatalf("error was not expected while recording command: %s", err)
+	}
+	if cmd.Attempts != 3 || cmd.UpdatedAt != "2024-08-01T12:00:02Z" {
+		t.Errorf("expected attempts and timestamps to be filled in, got %+v", cmd)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetCommandStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewCommandRepo(db)
+
+	// a registration was sent by nobody
+	mock.ExpectQuery("SELECT(.+)FROM(.+)commands(.+)COALESCE\\(user_id, ''\\) = \\$2").
+		WithArgs("cmd-1", "").
+		WillReturnRows(sqlmock.NewRows(commandColumns).
+			AddRow("cmd-1", "create", "succeeded", nil, 1, "2024-08-01T12:00:00Z", "2024-08-01T12:00:00Z", nil))
+
+	cmd, err := repo.GetCommandStatus(context.Background(), &pb.CommandStatusReq{Id: "cmd-1"})
+	if err != nil {
+		t.Fatalf("error was not expected while getting the command: %s", err)
+	}
+	if cmd.Status != "succeeded" || cmd.UserId != "" {
+		t.Errorf("unexpected command %+v", cmd)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetCommandStatusNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewCommandRepo(db)
+
+	// not found, or sent by another user
+	mock.ExpectQuery("SELECT(.+)FROM(.+)commands").
+		WithArgs("cmd-1", "user-2").
+		WillReturnRows(sqlmock.NewRows(commandColumns))
+
+	if _, err := repo.GetCommandStatus(context.Background(), &pb.CommandStatusReq{Id: "cmd-1", UserId: "user-2"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -30,7 +31,7 @@ func TestCreateFlashSale(t *testing.T) {
 		WithArgs(sqlmock.AnyArg(), req.Name, req.StartTime, req.EndTime, req.Status).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.CreateFlashSale(req)
+	_, err = repo.CreateFlashSale(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -57,7 +58,7 @@ func TestUpdateFlashSale(t *testing.T) {
 		WithArgs(req.Body.Name, req.Body.StartTime, req.Body.EndTime, req.Body.Status, sqlmock.AnyArg(), req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.UpdateFlashSale(req)
+	_, err = repo.UpdateFlashSale(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -77,7 +78,7 @@ func TestGetFlashSale(t *testing.T) {
 	query := `SELECT id, name, start_time, end_time, status, created_at FROM flash_sales WHERE id = \$1 AND deleted_at = 0`
 	mock.ExpectQuery(query).WithArgs(req.Id).WillReturnRows(rows)
 
-	result, err := repo.GetFlashSale(req)
+	result, err := repo.GetFlashSale(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "1234", result.Id)
@@ -97,7 +98,7 @@ func TestDeleteFlashSale(t *testing.T) {
 	query := `UPDATE flash_sales SET deleted_at = extract\\(epoch from now\\(\\)\\) WHERE id = \$1`
 	mock.ExpectExec(query).WithArgs(req.Id).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.DeleteFlashSale(req)
+	_, err = repo.DeleteFlashSale(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -120,7 +121,7 @@ func TestListAllFlashSales(t *testing.T) {
 	query := `SELECT id, name, start_time, end_time, status, created_at FROM flash_sales WHERE deleted_at = 0 AND name = \$1 AND status = \$2`
 	mock.ExpectQuery(query).WithArgs(req.Name, req.Status).WillReturnRows(rows)
 
-	result, err := repo.ListAllFlashSales(req)
+	result, err := repo.ListAllFlashSales(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result.FlashSales, 1)
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -29,7 +30,7 @@ func TestCreateFlashSaleProduct(t *testing.T) {
 		WithArgs(sqlmock.AnyArg(), req.FlashSaleId, req.ProductId, req.AvailableQuantity, req.DiscountedPrice).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	res, err := repo.CreateFlashSaleProduct(req)
+	res, err := repo.CreateFlashSaleProduct(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -63,7 +64,7 @@ func TestUpdateFlashSaleProduct(t *testing.T) {
 		WithArgs(req.Body.FlashSaleId, req.Body.ProductId, req.Body.AvailableQuantity, req.Body.DiscountedPrice, sqlmock.AnyArg(), req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	res, err := repo.UpdateFlashSaleProduct(req)
+	res, err := repo.UpdateFlashSaleProduct(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -89,7 +90,7 @@ func TestDeleteFlashSaleProduct(t *testing.T) {
 		WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	res, err := repo.DeleteFlashSaleProduct(req)
+	res, err := repo.DeleteFlashSaleProduct(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
@@ -109,25 +110,25 @@ func TestGetFlashSaleProduct(t *testing.T) {
 
 	repo := repository.NewFlashSaleProductsRepo(db)
 
-	req := &genproto.GetById{Id: "productId"}
+	req := &genproto.GetFlashSaleProductReq{Id: "productId"}
 
 	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products").
 		WithArgs(req.Id).
 		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "available_quantity", "discounted_price",
+			"id", "available_quantity", "discounted_price", "per_user_limit",
 			"flash_sale_id", "flash_sale_name", "flash_sale_start_time",
-			"flash_sale_end_time", "flash_sale_status",
+			"flash_sale_end_time", "flash_sale_status", "flash_sale_per_user_limit",
 			"product_id", "product_name", "product_price",
 			"product_description", "product_image_url", "product_stock_quantity",
 		}).AddRow(
-			"productId", 10, 99.99,
+			"productId", 10, 99.99, 0,
 			"flashSaleId", "Flash Sale", "2022-01-01 00:00:00",
-			"2022-12-31 23:59:59", "active",
+			"2022-12-31 23:59:59", "active", 0,
 			"productId", "Product", 199.99,
 			"Description", "http://image.url", 50,
 		))
 
-	res, err := repo.GetFlashSaleProduct(req)
+	res, err := repo.GetFlashSaleProduct(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, res)
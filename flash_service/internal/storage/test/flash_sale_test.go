@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdvanceFlashSales(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewFlashSaleRepo(db)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	mock.ExpectQuery(`UPDATE flash_sales SET status = 'active'`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("sale-1", "Summer Sale"))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("flash-sale-started", "sale-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`WITH due AS (.+) UPDATE flash_sales f SET status = 'completed'`).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "status"}).AddRow("sale-2", "Spring Sale", "active"))
+	mock.ExpectExec(`INSERT INTO outbox`).WithArgs("flash-sale-completed", "sale-2", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	events, err := repo.AdvanceFlashSales(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "pending", events[0].FromStatus)
+	assert.Equal(t, "active", events[0].ToStatus)
+	assert.Equal(t, "active", events[1].FromStatus)
+	assert.Equal(t, "completed", events[1].ToStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAdvanceFlashSalesLockHeld(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewFlashSaleRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock`).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(false))
+	mock.ExpectRollback()
+
+	events, err := repo.AdvanceFlashSales(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFlashSaleSnapshot(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewFlashSaleRepo(db)
+	start := time.Date(2024, 9, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT status, start_time, end_time FROM flash_sales").WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "start_time", "end_time"}).AddRow("active", start, start.Add(2*time.Hour)))
+	mock.ExpectQuery("SELECT id, COALESCE\\(available_quantity, 0\\) FROM flash_sales_products").WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "available_quantity"}).AddRow("fsp-1", 7).AddRow("fsp-2", 0))
+
+	updates, err := repo.GetFlashSaleSnapshot(context.Background(), &pb.GetById{Id: "sale-1"})
+	assert.NoError(t, err)
+	assert.Len(t, updates, 3)
+	assert.Equal(t, "status", updates[0].Type)
+	assert.Equal(t, "active", updates[0].Status)
+	assert.Equal(t, "2024-09-01T10:00:00", updates[0].StartTime)
+	assert.Equal(t, "2024-09-01T12:00:00", updates[0].EndTime)
+	assert.Equal(t, "stock", updates[1].Type)
+	assert.Equal(t, "fsp-1", updates[1].FlashSaleProductId)
+	assert.Equal(t, int32(7), updates[1].AvailableQuantity)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelFlashSale(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT name, status FROM flash_sales (.+) FOR UPDATE`).WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "status"}).AddRow("Summer Sale", "active"))
+	mock.ExpectExec(`UPDATE flash_sales SET status = 'canceled'`).WithArgs("sale-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT (.+) FROM orders (.+) FOR UPDATE`).WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow("order-1", "user-1").AddRow("order-2", "user-1"))
+	for _, order := range []struct {
+		id   string
+		paid float64
+	}{{"order-1", 40}, {"order-2", 0}} {
+		mock.ExpectExec(`UPDATE orders SET status = 'canceled'`).WithArgs(order.id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE flash_sales_products f`).WithArgs(order.id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE reservations SET status = 'released'`).WithArgs(order.id).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO order_status_tracking`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`INSERT INTO outbox`).WithArgs("order-canceled", order.id, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectQuery(`SELECT (.+) FROM orders`).WithArgs(order.id).
+			WillReturnRows(sqlmock.NewRows([]string{"refundable", "user_id"}).AddRow(order.paid, "user-1"))
+		if order.paid > 0 {
+			mock.ExpectQuery(`INSERT INTO refunds`).
+				WithArgs(sqlmock.AnyArg(), order.id, float32(order.paid), "flash sale canceled").
+				WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow("2024-08-01T10:00:00Z"))
+		}
+	}
+	mock.ExpectExec(`UPDATE flash_sales_products SET available_quantity = 0`).WithArgs("sale-1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(`INSERT INTO notification`).WithArgs("user-1", "email", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("notification-1"))
+	mock.ExpectQuery(`INSERT INTO flash_sale_cancellations`).WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("cancellation-1"))
+	mock.ExpectCommit()
+
+	var res *pb.CancelFlashSaleRes
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) (err error) {
+		res, err = tx.FlashSale().CancelFlashSale(context.Background(), &pb.GetById{Id: "sale-1"})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cancellation-1", res.CancellationStatus)
+	assert.Len(t, res.RefundInfo, 1)
+	assert.Equal(t, "order-1", res.RefundInfo[0].OrderId)
+	assert.Equal(t, float32(40), res.RefundInfo[0].Amount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelCompletedFlashSale(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT name, status FROM flash_sales (.+) FOR UPDATE`).WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "status"}).AddRow("Summer Sale", "completed"))
+	mock.ExpectRollback()
+
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.FlashSale().CancelFlashSale(context.Background(), &pb.GetById{Id: "sale-1"})
+		return err
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,14 +1,318 @@
 package repository_test
 
 import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+func TestCreateOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 2},
+		},
+	}
+	item := req.Items[0]
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("active", 0))
+	mock.ExpectExec("INSERT INTO orders").
+		WithArgs(sqlmock.AnyArg(), req.UserID, req.FlashSaleID, req.OrderStatus).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WithArgs(sqlmock.AnyArg(), "pending", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs(item.FlashSaleProductId, req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"available_quantity", "discounted_price", "price", "per_user_limit"}).AddRow(5, "79.90", "120.00", 0))
+	mock.ExpectExec("UPDATE flash_sales_products SET").
+		WithArgs(item.Quantity, item.FlashSaleProductId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_items").
+		WithArgs(sqlmock.AnyArg(), item.FlashSaleProductId, item.Quantity, float32(79.90), float32(120.00)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox").WithArgs("order-created", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err = repo.CreateOrder(context.Background(), req)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateOrderManyItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "fsp-2", Quantity: 3},
+			{FlashSaleProductId: "fsp-3", Quantity: 1},
+			{FlashSaleProductId: "fsp-1", Quantity: 2},
+		},
+	}
+	productColumns := []string{"available_quantity", "discounted_price", "price", "per_user_limit"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("active", 0))
+	mock.ExpectExec("INSERT INTO orders").
+		WithArgs(sqlmock.AnyArg(), req.UserID, req.FlashSaleID, req.OrderStatus).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WithArgs(sqlmock.AnyArg(), "pending", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// the products are locked by id, each is charged its own price and loses its own quantity
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs("fsp-1", req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(10, "80.00", "100.00", 0))
+	mock.ExpectExec("UPDATE flash_sales_products SET").
+		WithArgs(int32(2), "fsp-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_items").
+		WithArgs(sqlmock.AnyArg(), "fsp-1", int32(2), float32(80), float32(100)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs("fsp-2", req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(4, "15.50", "20.00", 0))
+	mock.ExpectExec("UPDATE flash_sales_products SET").
+		WithArgs(int32(3), "fsp-2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_items").
+		WithArgs(sqlmock.AnyArg(), "fsp-2", int32(3), float32(15.5), float32(20)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// the last unit of fsp-3 goes
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs("fsp-3", req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows(productColumns).AddRow(1, "4.25", "5.00", 0))
+	mock.ExpectExec("UPDATE flash_sales_products SET").
+		WithArgs(int32(1), "fsp-3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_items").
+		WithArgs(sqlmock.AnyArg(), "fsp-3", int32(1), float32(4.25), float32(5)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox").WithArgs("stock-depleted", "fsp-3", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	created := &eventArg{event: &pb.OrderCreated{}}
+	mock.ExpectExec("INSERT INTO outbox").WithArgs("order-created", sqlmock.AnyArg(), created).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if _, err = repo.CreateOrder(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	// 2*80 + 3*15.50 + 1*4.25
+	if total := created.event.(*pb.OrderCreated).TotalAmount; total != 210.75 {
+		t.Errorf("expected the order to total 210.75, got %v", total)
+	}
+}
+
+func TestCreateOrderOutOfStock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 3},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("active", 0))
+	mock.ExpectExec("INSERT INTO orders").
+		WithArgs(sqlmock.AnyArg(), req.UserID, req.FlashSaleID, req.OrderStatus).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WithArgs(sqlmock.AnyArg(), "pending", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs(req.Items[0].FlashSaleProductId, req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"available_quantity", "discounted_price", "price", "per_user_limit"}).AddRow(2, "79.90", "120.00", 0))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateOrder(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateOrderInactiveSale(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 1},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("pending", 0))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateOrder(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateOrderProductLimit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 2},
+		},
+	}
+	item := req.Items[0]
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("active", 0))
+	mock.ExpectExec("INSERT INTO orders").
+		WithArgs(sqlmock.AnyArg(), req.UserID, req.FlashSaleID, req.OrderStatus).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WithArgs(sqlmock.AnyArg(), "pending", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT (.+) FROM flash_sales_products f (.+) FOR UPDATE").
+		WithArgs(item.FlashSaleProductId, req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"available_quantity", "discounted_price", "price", "per_user_limit"}).AddRow(5, "79.90", "120.00", 2))
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(i.quantity\\), 0\\) FROM order_items i").
+		WithArgs(req.UserID, req.FlashSaleID, item.FlashSaleProductId).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateOrder(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateOrderSaleLimit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		OrderStatus: "pending",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 1},
+			{FlashSaleProductId: "9c1e4f3a-2d5b-4e7c-8a6f-1b3d5e7f9a2c", Quantity: 1},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status, per_user_limit FROM flash_sales (.+) FOR SHARE").
+		WithArgs(req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "per_user_limit"}).AddRow("active", 3))
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").
+		WithArgs(req.UserID, req.FlashSaleID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT COALESCE\\(SUM\\(i.quantity\\), 0\\) FROM order_items i").
+		WithArgs(req.UserID, req.FlashSaleID).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(2))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateOrder(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestUpdateOrder(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -20,19 +324,264 @@ func TestUpdateOrder(t *testing.T) {
 	req := &pb.UpdateOrderReq{
 		Id: "order-1",
 		Body: &pb.UpdateOrder{
-			UserID:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
-			FlashSaleID: "e8a127d1-b129-4023-85c4-0743a27dd61f",
-			OrderStatus: "completed",
+			UserID:          "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+			FlashSaleID:     "e8a127d1-b129-4023-85c4-0743a27dd61f",
+			OrderStatus:     "shipped",
+			CurrentLocation: "Tashkent hub",
 		},
 	}
 
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs(req.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("confirmed"))
 	mock.ExpectExec("UPDATE orders SET").WithArgs(req.Body.UserID, req.Body.FlashSaleID, req.Body.OrderStatus, sqlmock.AnyArg(), req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").WithArgs(req.Id, req.Body.OrderStatus, nil, req.Body.CurrentLocation).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	_, err = repo.UpdateOrder(context.Background(), req)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateOrderIllegalTransition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
 
-	_, err = repo.UpdateOrder(req)
+	repo := repository.NewOrderRepo(db)
+	req := &pb.UpdateOrderReq{
+		Id:   "order-1",
+		Body: &pb.UpdateOrder{OrderStatus: "confirmed"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs(req.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("refunded"))
+	mock.ExpectRollback()
+
+	_, err = repo.UpdateOrder(context.Background(), req)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateOrderToCanceledOrRefunded(t *testing.T) {
+	for _, next := range []string{"canceled", "refunded"} {
+		t.Run(next, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("could not mock db: %v", err)
+			}
+			defer db.Close()
+
+			repo := repository.NewOrderRepo(db)
+			req := &pb.UpdateOrderReq{
+				Id:   "order-1",
+				Body: &pb.UpdateOrder{OrderStatus: next},
+			}
+
+			// refused before anything is read, CancelOrder and the refunds do this
+			_, err = repo.UpdateOrder(context.Background(), req)
+			if status.Code(err) != codes.FailedPrecondition {
+				t.Errorf("expected FailedPrecondition, got %v", err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCancelShippedOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("shipped"))
+	mock.ExpectRollback()
+
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.Order().CancelOrder(context.Background(), &pb.GetById{Id: "order-1"})
+		return err
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateOrderReplayedKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.CreateOrderReq{
+		UserID:         "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleID:    "e8a127d1-b129-4023-85c4-0743a27dd61f",
+		IdempotencyKey: "key-1",
+		CallerId:       "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		Items: []*pb.OrderItemReq{
+			{FlashSaleProductId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10", Quantity: 2},
+		},
+	}
+	hash := requestHash(t, req)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO idempotency_keys").
+		WithArgs("create_order", req.CallerId, "key-1", hash).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT request_hash FROM idempotency_keys").
+		WithArgs("create_order", req.CallerId, "key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash"}).AddRow(hash))
+	mock.ExpectRollback()
+
+	_, err = repo.CreateOrder(context.Background(), req)
 	if err != nil {
+		t.Fatalf("error was not expected while replaying order: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateOrderReusedKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.UpdateOrderReq{
+		Id:             "order-1",
+		IdempotencyKey: "key-1",
+		CallerId:       "admin-1",
+		Body:           &pb.UpdateOrder{OrderStatus: "confirmed"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO idempotency_keys").
+		WithArgs("update_order", "admin-1", "key-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT request_hash FROM idempotency_keys").
+		WithArgs("update_order", "admin-1", "key-1").
+		WillReturnRows(sqlmock.NewRows([]string{"request_hash"}).AddRow("other"))
+	mock.ExpectRollback()
+
+	_, err = repo.UpdateOrder(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateOrderKeyOfAnotherUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.UpdateOrderReq{
+		Id:             "order-1",
+		IdempotencyKey: "key-1",
+		CallerId:       "admin-2",
+		Body:           &pb.UpdateOrder{OrderStatus: "shipped"},
+	}
+
+	// admin-1 used key-1 as well, which does not keep admin-2 from using it
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO idempotency_keys(.+)ON CONFLICT \\(operation, user_id, key\\)").
+		WithArgs("update_order", "admin-2", "key-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs(req.Id).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("confirmed"))
+	mock.ExpectExec("UPDATE orders SET").WithArgs(req.Body.OrderStatus, sqlmock.AnyArg(), req.Id).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if _, err = repo.UpdateOrder(context.Background(), req); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCancelCanceledOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("canceled"))
+	mock.ExpectQuery("SELECT refund_status FROM refunds").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"refund_status"}).AddRow("pending"))
+	mock.ExpectCommit()
+
+	var res *pb.CancelOrderRes
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) (err error) {
+		res, err = tx.Order().CancelOrder(context.Background(), &pb.GetById{Id: "order-1"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("error was not expected while canceling order twice: %s", err)
+	}
+	if res.CancellationStatus != "canceled" || res.RefundStatus != "pending" {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func requestHash(t *testing.T, req proto.Message) string {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		t.Fatalf("could not marshal request: %v", err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
 }
 
 func TestGetOrder(t *testing.T) {
@@ -53,15 +602,62 @@ func TestGetOrder(t *testing.T) {
 		"1990-01-01", "flash-e8a127d1-b129-4023-85c4-0743a27dd61f", "Flash Sale", time.Now(), time.Now(), "active", "pending", time.Now())
 
 	mock.ExpectQuery("SELECT").WithArgs(req.Id).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM order_items").
+		WillReturnRows(orderItemRows().AddRow("item-1", "order-1", "fsp-1", "product-1", "Phone", 2, "80.00", "100.00"))
 
-	res, err := repo.GetOrder(req)
+	res, err := repo.GetOrder(context.Background(), req)
 	if err != nil {
-		t.Errorf("expected no error, got %v", err)
+		t.Fatalf("expected no error, got %v", err)
 	}
 
 	if res.Id != "order-1" {
 		t.Errorf("expected order ID to be order-1, got %v", res.Id)
 	}
+	if len(res.Items) != 1 || res.TotalAmount != 160 || res.OriginalAmount != 200 {
+		t.Errorf("expected 1 item totalling 160 (200 original), got %v items totalling %v (%v original)", len(res.Items), res.TotalAmount, res.OriginalAmount)
+	}
+}
+
+func TestGetOrderManyItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+	req := &pb.GetById{Id: "order-1"}
+
+	rows := sqlmock.NewRows([]string{
+		"id", "user_id", "username", "email", "full_name",
+		"date_of_birth", "flash_sale_id", "name", "start_time",
+		"end_time", "status", "status", "created_at",
+	}).AddRow("order-1", "fdc7af50-c99d-420c-a74a-43be3cc11c73", "john_doe", "john@example.com", "John Doe",
+		"1990-01-01", "flash-e8a127d1-b129-4023-85c4-0743a27dd61f", "Flash Sale", time.Now(), time.Now(), "active", "pending", time.Now())
+
+	mock.ExpectQuery("SELECT").WithArgs(req.Id).WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM order_items").
+		WillReturnRows(orderItemRows().
+			AddRow("item-1", "order-1", "fsp-1", "product-1", "Phone", 2, "80.00", "100.00").
+			AddRow("item-2", "order-1", "fsp-2", "product-2", "Case", 3, "15.50", "20.00").
+			AddRow("item-3", "order-1", "fsp-3", "product-3", "Cable", 1, "4.25", "5.00"))
+
+	res, err := repo.GetOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(res.Items) != 3 {
+		t.Fatalf("expected 3 items, got %v", res.Items)
+	}
+	for i, want := range []float32{160, 46.5, 4.25} {
+		if res.Items[i].Total != want {
+			t.Errorf("expected item %s to total %v, got %v", res.Items[i].Id, want, res.Items[i].Total)
+		}
+	}
+	if res.TotalAmount != 210.75 || res.OriginalAmount != 265 {
+		t.Errorf("expected the order to total 210.75 (265 original), got %v (%v original)", res.TotalAmount, res.OriginalAmount)
+	}
 }
 
 func TestListAllOrders(t *testing.T) {
@@ -81,8 +677,9 @@ func TestListAllOrders(t *testing.T) {
 		"1990-01-01", "flash-e8a127d1-b129-4023-85c4-0743a27dd61f", "Flash Sale", time.Now(), time.Now(), "active", "pending", time.Now())
 
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT (.+) FROM order_items").WillReturnRows(orderItemRows())
 
-	res, err := repo.ListAllOrders(&pb.ListAllOrdersReq{})
+	res, err := repo.ListAllOrders(context.Background(), &pb.ListAllOrdersReq{})
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -105,8 +702,33 @@ func TestDeleteOrder(t *testing.T) {
 	mock.ExpectExec("UPDATE orders SET").WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.DeleteOrder(req)
+	_, err = repo.DeleteOrder(context.Background(), req)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+func orderItemRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{
+		"id", "order_id", "flash_sale_product_id", "product_id", "name",
+		"quantity", "discounted_price", "original_price",
+	})
+}
+
+// eventArg matches an outbox payload carrying an event of the type of event
+// and decodes it into event.
+type eventArg struct {
+	event proto.Message
+}
+
+func (a *eventArg) Match(v driver.Value) bool {
+	data, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	env, err := envelope.Unmarshal(data)
+	if err != nil {
+		return false
+	}
+	return envelope.Open(env, a.event) == nil
+}
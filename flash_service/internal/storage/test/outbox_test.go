@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+)
+
+func TestClaimEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOutboxRepo(db)
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE outbox SET attempts = attempts \\+ 1(.+)NOT EXISTS(.+)earlier.aggregate_id = o.aggregate_id(.+) FOR UPDATE SKIP LOCKED").
+		WithArgs(now, now.Add(time.Minute), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "aggregate_id", "payload", "attempts"}).
+			AddRow(7, "order-canceled", "order-1", []byte(`{"orderId":"order-1"}`), 1).
+			AddRow(3, "order-created", "order-1", []byte(`{"orderId":"order-1"}`), 2))
+
+	events, err := repo.ClaimEvents(context.Background(), now, time.Minute, 10)
+	if err != nil {
+		t.Fatalf("error was not expected while claiming events: %s", err)
+	}
+	if len(events) != 2 || events[0].ID != 3 || events[1].ID != 7 {
+		t.Fatalf("expected events 3 and 7 in order, got %v", events)
+	}
+	if events[0].Topic != "order-created" || events[0].Attempts != 2 {
+		t.Errorf("unexpected event %+v", events[0])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOutboxRepo(db)
+	retryAt := time.Now().Add(time.Second)
+
+	mock.ExpectExec("UPDATE outbox SET available_at = \\$2, last_error = \\$3").
+		WithArgs(int64(7), retryAt, "kafka: leader not available").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkFailed(context.Background(), 7, retryAt, "kafka: leader not available"); err != nil {
+		t.Fatalf("error was not expected while marking event failed: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
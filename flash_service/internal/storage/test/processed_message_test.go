@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+)
+
+func TestMarkProcessed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+	ctx := context.Background()
+	mark := func(tx storage.StorageI) (bool, error) {
+		return tx.ProcessedMessage().MarkProcessed(ctx, "create-product/0/7")
+	}
+
+	// the handler fails, the mark goes with its rollback
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO(.+)processed_messages(.+)ON CONFLICT \\(message_id\\) DO NOTHING").
+		WithArgs("create-product/0/7").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	// the retry is the first to get through
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO(.+)processed_messages").
+		WithArgs("create-product/0/7").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	// a redelivery finds the mark
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO(.+)processed_messages").
+		WithArgs("create-product/0/7").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	handlerErr := errors.New("product name is taken")
+	err = store.WithTx(ctx, func(tx storage.StorageI) error {
+		if _, err := mark(tx); err != nil {
+			return err
+		}
+		return handlerErr
+	})
+	if err != handlerErr {
+		t.Fatalf("expected the handler error, got %v", err)
+	}
+
+	for _, want := range []bool{true, false} {
+		var first bool
+		err := store.WithTx(ctx, func(tx storage.StorageI) (err error) {
+			first, err = mark(tx)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("error was not expected while marking the message: %s", err)
+		}
+		if first != want {
+			t.Errorf("expected first to be %v, got %v", want, first)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMarkProcessedOutsideTx(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewProcessedMessageRepo(db)
+	if _, err := repo.MarkProcessed(context.Background(), "create-product/0/7"); status.Code(err) != codes.Internal {
+		t.Errorf("expected the missing unit of work to be reported, got %v", err)
+	}
+}
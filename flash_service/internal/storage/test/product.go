@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -31,7 +32,7 @@ func TestCreateProduct(t *testing.T) {
 	mock.ExpectExec("INSERT INTO products").WithArgs(id, req.Name, req.Description, req.Price, req.ImageUrl, req.StockQuantity).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.CreateProduct(req)
+	_, err = repo.CreateProduct(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -56,7 +57,7 @@ func TestUpdateProduct(t *testing.T) {
 	mock.ExpectExec("UPDATE products").WithArgs(req.Body.Name, req.Body.Price, sqlmock.AnyArg(), req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.UpdateProduct(req)
+	_, err = repo.UpdateProduct(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -85,7 +86,7 @@ func TestGetProduct(t *testing.T) {
 			AddRow(expectedProduct.Id, expectedProduct.Name, expectedProduct.Description, expectedProduct.Price, expectedProduct.ImageUrl, expectedProduct.StockQuantity))
 
 	req := &pb.GetById{Id: id}
-	product, err := repo.GetProduct(req)
+	product, err := repo.GetProduct(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedProduct, product)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -105,7 +106,7 @@ func TestListAllProducts(t *testing.T) {
 		AddRow("2", "Product 2", "Description 2", 20.0, "http://example.com/image2.jpg", 200))
 
 	req := &pb.ListAllProductsReq{}
-	res, err := repo.ListAllProducts(req)
+	res, err := repo.ListAllProducts(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Len(t, res.Products, 2)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -125,7 +126,7 @@ func TestDeleteProduct(t *testing.T) {
 	mock.ExpectExec("UPDATE products").WithArgs(req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.DeleteProduct(req)
+	_, err = repo.DeleteProduct(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,221 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var refundRowColumns = []string{"id", "order_id", "user_id", "refund_amount", "refund_status", "reason", "transaction_id", "created_at", "updated_at"}
+
+func TestRequestFullRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("delivered"))
+	mock.ExpectQuery("SELECT (.+) FROM orders").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"refundable", "user_id"}).AddRow(75.5, "user-1"))
+	mock.ExpectQuery("INSERT INTO refunds").
+		WithArgs(sqlmock.AnyArg(), "order-1", float32(75.5), "damaged").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow("2024-08-01T10:00:00Z"))
+	mock.ExpectCommit()
+
+	res, err := repo.RequestRefund(context.Background(), &pb.RefundCreateReq{OrderId: "order-1", Reason: "damaged"})
+	if err != nil {
+		t.Fatalf("error was not expected while requesting refund: %s", err)
+	}
+	if res.Amount != 75.5 || res.RefundStatus != "pending" || res.UserId != "user-1" {
+		t.Errorf("unexpected refund %v", res)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRequestRefundOverPaid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("delivered"))
+	mock.ExpectQuery("SELECT (.+) FROM orders").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"refundable", "user_id"}).AddRow(20, "user-1"))
+	mock.ExpectRollback()
+
+	_, err = repo.RequestRefund(context.Background(), &pb.RefundCreateReq{OrderId: "order-1", Amount: 25})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProcessRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM refunds r (.+) FOR UPDATE OF r").WithArgs("refund-1").
+		WillReturnRows(sqlmock.NewRows(refundRowColumns).
+			AddRow("refund-1", "order-1", "user-1", 30, "approved", nil, nil, "2024-08-01T10:00:00Z", nil))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("delivered"))
+	mock.ExpectQuery("SELECT (.+) FROM payments").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "provider_payment_id"}).AddRow("payment-1", "fake", "fake-payment-1"))
+	mock.ExpectExec("UPDATE payments SET status").WithArgs("refunded", "payment-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO transactions").
+		WithArgs(sqlmock.AnyArg(), "user-1", "order-1", float32(30)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("UPDATE refunds").
+		WithArgs(sqlmock.AnyArg(), "refund-1").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow("2024-08-02T10:00:00Z"))
+	mock.ExpectQuery("SELECT (.+) FROM transactions").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"paid", "processed"}).AddRow(30, 30))
+	mock.ExpectExec("UPDATE orders SET status = 'refunded'").WithArgs("order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	var refunded []string
+	refundPayment := func(ctx context.Context, provider, paymentID string, amount float32) (string, error) {
+		refunded = append(refunded, fmt.Sprintf("%s %s %.2f", provider, paymentID, amount))
+		return "refunded", nil
+	}
+
+	res, err := repo.ProcessRefund(context.Background(), &pb.GetById{Id: "refund-1"}, refundPayment)
+	if err != nil {
+		t.Fatalf("error was not expected while processing refund: %s", err)
+	}
+	if res.RefundStatus != "processed" || res.TransactionId == "" {
+		t.Errorf("unexpected refund %v", res)
+	}
+	if len(refunded) != 1 || refunded[0] != "fake fake-payment-1 30.00" {
+		t.Errorf("expected the payment refunded at the provider once, got %v", refunded)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProcessRefundRefusedByProvider(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM refunds r (.+) FOR UPDATE OF r").WithArgs("refund-1").
+		WillReturnRows(sqlmock.NewRows(refundRowColumns).
+			AddRow("refund-1", "order-1", "user-1", 30, "approved", nil, nil, "2024-08-01T10:00:00Z", nil))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("delivered"))
+	mock.ExpectQuery("SELECT (.+) FROM payments").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "provider_payment_id"}).AddRow("payment-1", "fake", "fake-payment-1"))
+	// no credit is booked and the refund stays approved
+	mock.ExpectRollback()
+
+	refundPayment := func(ctx context.Context, provider, paymentID string, amount float32) (string, error) {
+		return "", status.Error(codes.Unavailable, "payment provider timed out")
+	}
+
+	_, err = repo.ProcessRefund(context.Background(), &pb.GetById{Id: "refund-1"}, refundPayment)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestProcessRefundWithoutPayment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM refunds r (.+) FOR UPDATE OF r").WithArgs("refund-1").
+		WillReturnRows(sqlmock.NewRows(refundRowColumns).
+			AddRow("refund-1", "order-1", "user-1", 30, "approved", nil, nil, "2024-08-01T10:00:00Z", nil))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("delivered"))
+	mock.ExpectQuery("SELECT (.+) FROM payments").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "provider_payment_id"}))
+	mock.ExpectRollback()
+
+	refundPayment := func(ctx context.Context, provider, paymentID string, amount float32) (string, error) {
+		t.Error("expected nothing refunded at the provider")
+		return "", nil
+	}
+
+	_, err = repo.ProcessRefund(context.Background(), &pb.GetById{Id: "refund-1"}, refundPayment)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestApproveRejectedRefund(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewRefundRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM refunds r (.+) FOR UPDATE OF r").WithArgs("refund-1").
+		WillReturnRows(sqlmock.NewRows(refundRowColumns).
+			AddRow("refund-1", "order-1", "user-1", 30, "rejected", "no receipt", nil, "2024-08-01T10:00:00Z", nil))
+	mock.ExpectRollback()
+
+	_, err = repo.ApproveRefund(context.Background(), &pb.RefundReviewReq{Id: "refund-1"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
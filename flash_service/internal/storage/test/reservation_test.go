@@ -0,0 +1,207 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var reservationColumns = []string{"id", "order_id", "user_id", "status", "expires_at", "created_at"}
+
+func TestConfirmReservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewReservationRepo(db)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT order_id FROM reservations").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	mock.ExpectQuery("SELECT (.+) FROM reservations (.+) FOR UPDATE").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow("res-1", "order-1", "user-1", "held", now.Add(5*time.Minute), "2024-09-01T11:55:00Z"))
+	mock.ExpectExec("UPDATE orders SET status").WithArgs("confirmed", "order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").WithArgs("order-1", "confirmed", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE reservations SET status").WithArgs("confirmed", now, "res-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM order_items i").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "flash_sale_product_id", "product_id", "name", "quantity", "discounted_price", "original_price"}))
+
+	res, err := repo.ConfirmReservation(context.Background(), &pb.GetById{Id: "res-1"}, now)
+	if err != nil {
+		t.Fatalf("error was not expected while confirming reservation: %s", err)
+	}
+	if res.Status != "confirmed" {
+		t.Errorf("expected confirmed reservation, got %s", res.Status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestConfirmExpiredReservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewReservationRepo(db)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT order_id FROM reservations").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	mock.ExpectQuery("SELECT (.+) FROM reservations (.+) FOR UPDATE").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow("res-1", "order-1", "user-1", "held", now.Add(-time.Minute), "2024-09-01T11:49:00Z"))
+	mock.ExpectRollback()
+
+	_, err = repo.ConfirmReservation(context.Background(), &pb.GetById{Id: "res-1"}, now)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExpireReservations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewReservationRepo(db)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT id FROM reservations WHERE status = 'held'").WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("res-1"))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT order_id FROM reservations").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	mock.ExpectQuery("SELECT (.+) FROM reservations (.+) FOR UPDATE").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow("res-1", "order-1", "user-1", "held", now.Add(-time.Minute), "2024-09-01T11:49:00Z"))
+	mock.ExpectExec("UPDATE orders SET status").WithArgs("canceled", "order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE flash_sales_products f SET available_quantity = f.available_quantity \\+ i.quantity").WithArgs("order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").WithArgs("order-1", "canceled", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox").WithArgs("order-canceled", "order-1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE reservations SET status").WithArgs("expired", now, "res-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM order_items i").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "flash_sale_product_id", "product_id", "name", "quantity", "discounted_price", "original_price"}))
+
+	expired, err := repo.ExpireReservations(context.Background(), now)
+	if err != nil {
+		t.Fatalf("error was not expected while expiring reservations: %s", err)
+	}
+	if len(expired) != 1 || expired[0].Status != "expired" {
+		t.Errorf("expected one expired reservation, got %v", expired)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCompleteCheckout(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewReservationRepo(db)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	payment := &pb.Payment{Provider: "fake", ProviderPaymentId: "pay-1", Amount: 59.98, Status: "captured"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT order_id FROM reservations").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	mock.ExpectQuery("SELECT (.+) FROM reservations (.+) FOR UPDATE").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow("res-1", "order-1", "user-1", "held", now.Add(5*time.Minute), "2024-09-01T11:55:00Z"))
+	mock.ExpectExec("UPDATE orders SET status").WithArgs("confirmed", "order-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO order_status_tracking").WithArgs("order-1", "confirmed", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE reservations SET status").WithArgs("confirmed", now, "res-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO transactions").WithArgs(sqlmock.AnyArg(), "user-1", "order-1", float32(59.98)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("INSERT INTO payments").
+		WithArgs(sqlmock.AnyArg(), "order-1", sqlmock.AnyArg(), "fake", "pay-1", float32(59.98), "captured").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow("2024-09-01T12:00:00Z"))
+	mock.ExpectCommit()
+	mock.ExpectQuery("SELECT (.+) FROM order_items i").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "order_id", "flash_sale_product_id", "product_id", "name", "quantity", "discounted_price", "original_price"}))
+
+	res, err := repo.CompleteCheckout(context.Background(), &pb.GetById{Id: "res-1"}, payment, now)
+	if err != nil {
+		t.Fatalf("error was not expected while completing checkout: %s", err)
+	}
+	if res.Status != "confirmed" || payment.OrderId != "order-1" || payment.Id == "" {
+		t.Errorf("unexpected result %v, payment %v", res, payment)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCompleteCheckoutConfirmedReservation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewReservationRepo(db)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT order_id FROM reservations").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow("order-1"))
+	mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("confirmed"))
+	mock.ExpectQuery("SELECT (.+) FROM reservations (.+) FOR UPDATE").WithArgs("res-1").
+		WillReturnRows(sqlmock.NewRows(reservationColumns).AddRow("res-1", "order-1", "user-1", "confirmed", now.Add(5*time.Minute), "2024-09-01T11:55:00Z"))
+	mock.ExpectQuery("SELECT (.+) FROM payments").WithArgs("order-1", "fake", "pay-2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectRollback()
+
+	_, err = repo.CompleteCheckout(context.Background(), &pb.GetById{Id: "res-1"}, &pb.Payment{Provider: "fake", ProviderPaymentId: "pay-2", Amount: 10}, now)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
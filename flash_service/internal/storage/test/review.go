@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ func TestCreateReview(t *testing.T) {
 
 	repo := repository.NewReviewRepo(db)
 
+	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO reviews`).
 		WithArgs("user1", "product1", 5, "Great product!", time.Now().Format(time.RFC3339)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
@@ -28,6 +30,11 @@ func TestCreateReview(t *testing.T) {
 		WithArgs("product1", 5.0, 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mock.ExpectExec(`INSERT INTO outbox`).
+		WithArgs("review-posted", "product1", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
 	req := &pb.CreateReviewReq{
 		UserId:     "user1",
 		ProductId:  "product1",
@@ -36,7 +43,7 @@ func TestCreateReview(t *testing.T) {
 		CreatedAt:  time.Now().Format(time.RFC3339),
 	}
 
-	_, err = repo.CreateReview(req)
+	_, err = repo.CreateReview(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -59,7 +66,7 @@ func TestGetProductRating(t *testing.T) {
 		ProductId: "product1",
 	}
 
-	res, err := repo.GetProductRating(req)
+	res, err := repo.GetProductRating(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "product1", res.ProductId)
 	assert.Equal(t, float64(4.5), res.AverageRating)
@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+)
+
+func TestClaimStuckSagas(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewSagaRepo(db)
+	staleBefore := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("UPDATE sagas SET attempts = attempts \\+ 1(.+) FOR UPDATE SKIP LOCKED").
+		WithArgs(staleBefore, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "status", "step", "data", "error", "attempts", "updated_at"}).
+			AddRow("saga-1", "checkout", "running", 2, []byte(`{"reservation_id":"res-1"}`), nil, 1, staleBefore.Add(time.Minute)))
+
+	sagas, err := repo.ClaimStuckSagas(context.Background(), staleBefore, 10)
+	if err != nil {
+		t.Fatalf("error was not expected while claiming sagas: %s", err)
+	}
+	if len(sagas) != 1 || sagas[0].Kind != "checkout" || sagas[0].Step != 2 || sagas[0].Attempts != 1 {
+		t.Errorf("unexpected sagas %v", sagas)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
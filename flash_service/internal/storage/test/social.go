@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/Mubinabd/flash_sale/internal/storage/repository"
 )
 
@@ -19,8 +21,9 @@ func TestShareDeal(t *testing.T) {
 	}
 	defer db.Close()
 
-	repo := repository.NewSocialRepo(db)
+	store := repository.NewStorage(db)
 
+	mock.ExpectBegin()
 	mock.ExpectExec(`INSERT INTO shared_deals`).
 		WithArgs("user1", "flashsale1", "Facebook", "Great deal!", time.Now().Format(time.RFC3339)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
@@ -34,6 +37,7 @@ func TestShareDeal(t *testing.T) {
 	mock.ExpectExec(`INSERT INTO sharing_stats`).
 		WithArgs("flashsale1", 1, `{"Facebook":2}`).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	req := &pb.ShareDealReq{
 		UserId:      "user1",
@@ -43,7 +47,10 @@ func TestShareDeal(t *testing.T) {
 		SharedAt:    time.Now().Format(time.RFC3339),
 	}
 
-	_, err = repo.ShareDeal(req)
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.Social().ShareDeal(context.Background(), req)
+		return err
+	})
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -69,7 +76,7 @@ func TestGetSharingStats(t *testing.T) {
 		FlashSaleId: "flashsale1",
 	}
 
-	res, err := repo.GetSharingStats(req)
+	res, err := repo.GetSharingStats(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, "flashsale1", res.FlashSaleId)
 	assert.Equal(t, int64(7), res.TotalShares)
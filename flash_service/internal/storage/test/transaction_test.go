@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewTransactionRepo(db)
+
+	req := &pb.TransactionCreateReq{
+		UserId:      "fdc7af50-c99d-420c-a74a-43be3cc11c73",
+		FlashSaleId: "5b0b2d4e-6f0c-4c43-9a4f-2f7d3c1f1a10",
+		Amount:      79.9,
+		Type:        "debit",
+	}
+
+	mock.ExpectExec(`INSERT INTO transactions`).
+		WithArgs(sqlmock.AnyArg(), req.UserId, nil, req.FlashSaleId, req.Amount, req.Type).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	_, err = repo.CreateTransaction(context.Background(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBalance(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewTransactionRepo(db)
+
+	mock.ExpectQuery(`SELECT (.+) FROM transactions WHERE user_id = \$1`).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"payments", "products", "flash_sale"}).AddRow("100.00", "20.00", "30.00"))
+
+	res, err := repo.GetBalance(context.Background(), &pb.GetById{Id: "user-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, float32(50), res.Balance)
+	assert.Equal(t, float32(30), res.Usage_.FlashSaleSpendings)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+)
+
+func TestWithTxRetriesSerializationFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+	req := &pb.ShareDealReq{UserId: "user-1", FlashSaleId: "sale-1", Platform: "telegram", SharedAt: "2024-08-01T10:00:00Z"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO shared_deals").
+		WillReturnError(&pq.Error{Code: "40001", Message: "could not serialize access due to concurrent update"})
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO shared_deals").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT shares_by_platform FROM sharing_stats").WithArgs("sale-1").
+		WillReturnRows(sqlmock.NewRows([]string{"shares_by_platform"}).AddRow(`{"telegram": 1}`))
+	mock.ExpectExec("INSERT INTO sharing_stats").WithArgs("sale-1", 1, []byte(`{"telegram":2}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.Social().ShareDeal(context.Background(), req)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("error was not expected after a retried serialization failure: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithTxGivesUpOnSerializationFailures(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	for i := 0; i < 3; i++ {
+		mock.ExpectBegin()
+		mock.ExpectExec("INSERT INTO shared_deals").
+			WillReturnError(&pq.Error{Code: "40001"})
+		mock.ExpectRollback()
+	}
+
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.Social().ShareDeal(context.Background(), &pb.ShareDealReq{FlashSaleId: "sale-1"})
+		return err
+	})
+	if pqErr, ok := err.(*pq.Error); !ok || pqErr.Code != "40001" {
+		t.Errorf("expected the serialization failure, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithTxNestsRepositoryTransactions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	store := repository.NewStorage(db)
+
+	// a failing repository method only rolls back its own savepoint
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM refunds r (.+) FOR UPDATE OF r").WithArgs("refund-1").
+		WillReturnRows(sqlmock.NewRows(refundRowColumns).
+			AddRow("refund-1", "order-1", "user-1", 30, "rejected", "no receipt", nil, "2024-08-01T10:00:00Z", nil))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err = store.WithTx(context.Background(), func(tx storage.StorageI) error {
+		_, err := tx.Refund().ApproveRefund(context.Background(), &pb.RefundReviewReq{Id: "refund-1"})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected FailedPrecondition, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error was not expected while committing: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCancelOrderOutsideTx(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	defer db.Close()
+
+	repo := repository.NewOrderRepo(db)
+
+	_, err = repo.CancelOrder(context.Background(), &pb.GetById{Id: "order-1"})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -33,7 +34,7 @@ func TestGetProfile(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "email", "full_name", "date_of_birth", "role"}).
 			AddRow(expectedUser.Id, expectedUser.Username, expectedUser.Email, expectedUser.FullName, "2000-01-01", expectedUser.Role))
 
-	res, err := repo.GetProfile(req)
+	res, err := repo.GetProfile(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedUser, res)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -60,7 +61,7 @@ func TestEditProfile(t *testing.T) {
 		WithArgs(req.Username, req.Email, req.FullName, req.DateOfBirth, req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	res, err := repo.EditProfile(req)
+	res, err := repo.EditProfile(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Empty(t, res)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -89,7 +90,7 @@ func TestChangePassword(t *testing.T) {
 		WithArgs(req.NewPassword, req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.ChangePassword(req)
+	_, err = repo.ChangePassword(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -116,7 +117,7 @@ func TestGetSetting(t *testing.T) {
 		WillReturnRows(sqlmock.NewRows([]string{"privacy_level", "notification", "language", "theme"}).
 			AddRow(expectedSetting.PrivacyLevel, expectedSetting.Notification, expectedSetting.Language, expectedSetting.Theme))
 
-	res, err := repo.GetSetting(req)
+	res, err := repo.GetSetting(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedSetting, res)
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -143,7 +144,7 @@ func TestEditSetting(t *testing.T) {
 		WithArgs(req.PrivacyLevel, req.Notification, req.Language, req.Theme, req.Id).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	_, err = repo.EditSetting(req)
+	_, err = repo.EditSetting(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
@@ -164,7 +165,7 @@ func TestDeleteUser(t *testing.T) {
 	mock.ExpectExec("DELETE FROM settings WHERE user_id = $1").WithArgs(req.Id).WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	_, err = repo.DeleteUser(req)
+	_, err = repo.DeleteUser(context.Background(), req)
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
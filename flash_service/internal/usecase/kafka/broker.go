@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Broker is what the producer and the consumer manager run on: kafka itself,
+// or MemoryBroker in tests and when flash_service runs on its own.
+type Broker interface {
+	// Publish appends msgs to the topics they name.
+	Publish(ctx context.Context, msgs ...kafka.Message) error
+	// Subscribe reads topic as a member of the consumer group groupID, from
+	// the offset the group committed last.
+	Subscribe(topic, groupID string) Subscription
+	Close() error
+}
+
+// Subscription is a reader of one topic in a consumer group. *kafka.Reader
+// is one.
+type Subscription interface {
+	// FetchMessage blocks until the next message arrives, and returns
+	// io.EOF once the subscription is closed.
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// NewBroker returns the broker called name, "kafka" or "memory".
+func NewBroker(name string, brokers []string) (Broker, error) {
+	switch name {
+	case "kafka":
+		return NewKafkaBroker(brokers), nil
+	case "memory":
+		return NewMemoryBroker(), nil
+	}
+	return nil, fmt.Errorf("unknown broker %q", name)
+}
+
+type kafkaBroker struct {
+	brokers []string
+	writer  *kafka.Writer
+}
+
+// NewKafkaBroker returns a Broker on the given kafka brokers.
+func NewKafkaBroker(brokers []string) Broker {
+	return &kafkaBroker{
+		brokers: brokers,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			AllowAutoTopicCreation: true,
+			// keyed messages keep their order, the others are spread
+			Balancer: &kafka.Hash{},
+		},
+	}
+}
+
+func (b *kafkaBroker) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	return b.writer.WriteMessages(ctx, msgs...)
+}
+
+func (b *kafkaBroker) Subscribe(topic, groupID string) Subscription {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers: b.brokers,
+		Topic:   topic,
+		GroupID: groupID,
+	})
+}
+
+// Close flushes what is still buffered.
+func (b *kafkaBroker) Close() error {
+	return b.writer.Close()
+}
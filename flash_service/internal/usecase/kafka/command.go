@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+)
+
+// Statuses a command goes through.
+const (
+	CommandRetrying  = "retrying"
+	CommandSucceeded = "succeeded"
+	CommandFailed    = "failed"
+)
+
+// ReplyTopicPrefix is the prefix the gateway requires of reply topics. A
+// reply to any other topic could reach one of the consumers here.
+const ReplyTopicPrefix = "replies."
+
+// IsReplyTopic reports whether topic may carry replies to commands.
+func IsReplyTopic(topic string) bool {
+	return strings.HasPrefix(topic, ReplyTopicPrefix) && len(topic) > len(ReplyTopicPrefix)
+}
+
+// CommandResult is the outcome of one attempt at handling a command.
+type CommandResult struct {
+	ID      string
+	Topic   string
+	ReplyTo string
+	// UserID is who sent the command, empty for registrations.
+	UserID string
+	Status string
+	Err    error
+}
+
+// CommandRecorder keeps the outcome of commands, so the client that sent one
+// can find out how it went.
+type CommandRecorder interface {
+	RecordCommand(ctx context.Context, result CommandResult) error
+}
+
+// recordCommand reports an attempt at env if it is a command, which the
+// gateway marks with a correlation ID. A failure to record is logged only, it
+// must not make the message itself fail.
+func (kcm *KafkaConsumerManager) recordCommand(ctx context.Context, topic string, env *pb.Envelope, status string, cause error) {
+	if kcm.commands == nil || env.CorrelationId == "" {
+		return
+	}
+
+	result := CommandResult{
+		ID:      env.CorrelationId,
+		Topic:   topic,
+		ReplyTo: env.ReplyTo,
+		UserID:  env.IssuedBy,
+		Status:  status,
+		Err:     cause,
+	}
+	if err := kcm.commands.RecordCommand(ctx, result); err != nil {
+		log.Printf("Error recording command %s of topic %s: %v", result.ID, topic, err)
+	}
+}
@@ -1,31 +1,59 @@
 package kafka
 
-
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"log"
 	"sync"
+	"time"
 
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
 	"github.com/segmentio/kafka-go"
 )
 
 type KafkaConsumerManager struct {
-	consumers map[string]*kafka.Reader
-	handlers  map[string]func(message []byte)
+	broker    Broker
+	consumers map[string]*consumer
+	commands  CommandRecorder
 	mu        sync.Mutex
+
+	// stopping is canceled by Shutdown; running tracks the consume loops.
+	stopping context.Context
+	stop     context.CancelFunc
+	running  sync.WaitGroup
 }
 
-func NewKafkaConsumerManager() *KafkaConsumerManager {
+// consumer is what a consume loop needs of its topic, handed over whole so
+// that it never reads the manager's map while more topics get registered.
+type consumer struct {
+	topic   string
+	sub     Subscription
+	handler Handler
+	policy  RetryPolicy
+}
+
+// NewKafkaConsumerManager returns a manager that consumes from broker and
+// reports the outcome of commands to commands; it may be nil when nothing
+// tracks them.
+func NewKafkaConsumerManager(broker Broker, commands CommandRecorder) *KafkaConsumerManager {
+	stopping, stop := context.WithCancel(context.Background())
 	return &KafkaConsumerManager{
-		consumers: make(map[string]*kafka.Reader),
-		handlers:  make(map[string]func(message []byte)),
+		broker:    broker,
+		consumers: make(map[string]*consumer),
+		commands:  commands,
+		stopping:  stopping,
+		stop:      stop,
 	}
 }
 
 var ErrConsumerAlreadyExists = errors.New("consumer for this topic already exists")
 
-func (kcm *KafkaConsumerManager) RegisterConsumer(brokers []string, topic, groupID string, handler func(message []byte)) error {
+// RegisterConsumer starts consuming topic with handler. Messages the handler
+// keeps failing on are retried under policy and then forwarded to the
+// topic's dead-letter topic, so one bad message never blocks the rest.
+func (kcm *KafkaConsumerManager) RegisterConsumer(topic, groupID string, handler Handler, policy RetryPolicy) error {
 	kcm.mu.Lock()
 	defer kcm.mu.Unlock()
 
@@ -33,41 +61,155 @@ func (kcm *KafkaConsumerManager) RegisterConsumer(brokers []string, topic, group
 		return ErrConsumerAlreadyExists
 	}
 
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: brokers,
-		Topic:   topic,
-		GroupID: groupID,
-	})
-	kcm.consumers[topic] = reader
-	kcm.handlers[topic] = handler
+	c := &consumer{
+		topic:   topic,
+		sub:     kcm.broker.Subscribe(topic, groupID),
+		handler: handler,
+		policy:  policy,
+	}
+	kcm.consumers[topic] = c
 
-	go kcm.consumeMessages(topic)
+	kcm.running.Add(1)
+	go kcm.consumeMessages(c)
 
 	return nil
 }
 
-func (kcm *KafkaConsumerManager) consumeMessages(topic string) {
-	reader := kcm.consumers[topic]
-	handler := kcm.handlers[topic]
+// minFetchBackoff is the shortest wait after a failed fetch, so that a policy
+// without backoff does not make the loop spin on an unreachable broker.
+const minFetchBackoff = 100 * time.Millisecond
 
+func (kcm *KafkaConsumerManager) consumeMessages(c *consumer) {
+	defer kcm.running.Done()
+	topic, reader := c.topic, c.sub
+
+	// failures counts the fetches that failed in a row; while the broker is
+	// unreachable the loop backs off under the topic's policy instead of spinning
+	failures := 0
 	for {
-		msg, err := reader.ReadMessage(context.Background())
-		if err != nil {
-			log.Printf("Error reading message from topic %s: %v", topic, err)
+		msg, err := reader.FetchMessage(kcm.stopping)
+		if kcm.stopping.Err() != nil || errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			failures++
+			wait := max(c.policy.Backoff(failures), minFetchBackoff)
+			log.Printf("Error reading message from topic %s, retrying in %s: %v", topic, wait, err)
+			select {
+			case <-kcm.stopping.Done():
+				return
+			case <-time.After(wait):
+			}
 			continue
 		}
-		handler(msg.Value)
+		failures = 0
+
+		if !kcm.handle(c, msg) {
+			// stopped halfway, the message is redelivered after a restart
+			return
+		}
+		// the offset is committed even while stopping, the message is done
+		if err := reader.CommitMessages(context.WithoutCancel(kcm.stopping), msg); err != nil {
+			log.Printf("Error committing message %d of topic %s: %v", msg.Offset, topic, err)
+		}
+	}
+}
+
+// handle runs msg through the topic's handler until it succeeds, fails
+// permanently or runs out of attempts, and dead-letters it in the latter
+// cases. It reports false when Shutdown interrupted it before either. The
+// handler itself is not interrupted, Shutdown waits for it.
+func (kcm *KafkaConsumerManager) handle(c *consumer, msg kafka.Message) bool {
+	topic, handler, policy := c.topic, c.handler, c.policy
+	ctx := context.WithoutCancel(kcm.stopping)
+
+	env, err := envelope.Unmarshal(msg.Value)
+	if err != nil {
+		log.Printf("Giving up on message %d of topic %s: %v", msg.Offset, topic, err)
+		return kcm.deadLetter(c, DeadLetter(msg, err, 0, time.Now()))
+	}
+	if env.Id == "" {
+		// still unique, and the same when the message is redelivered
+		env.Id = fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := handler(ctx, env)
+		if err == nil {
+			kcm.recordCommand(ctx, topic, env, CommandSucceeded, nil)
+			return true
+		}
+
+		if IsPermanent(err) || attempt >= policy.MaxAttempts {
+			log.Printf("Giving up on message %s of topic %s after %d attempts: %v", env.Id, topic, attempt, err)
+			kcm.recordCommand(ctx, topic, env, CommandFailed, err)
+			return kcm.deadLetter(c, DeadLetter(msg, err, attempt, time.Now()))
+		}
+
+		kcm.recordCommand(ctx, topic, env, CommandRetrying, err)
+		wait := policy.Backoff(attempt)
+		log.Printf("Error handling message %s of topic %s, attempt %d, retrying in %s: %v", env.Id, topic, attempt, wait, err)
+		select {
+		case <-kcm.stopping.Done():
+			return false
+		case <-time.After(wait):
+		}
 	}
 }
 
+// deadLetter keeps trying to park msg until Shutdown, the message would be
+// lost otherwise.
+func (kcm *KafkaConsumerManager) deadLetter(c *consumer, msg kafka.Message) bool {
+	policy := c.policy
+	msg.Topic = DeadLetterTopic(c.topic)
+
+	for attempt := 1; ; attempt++ {
+		err := kcm.broker.Publish(kcm.stopping, msg)
+		if err == nil {
+			return true
+		}
+
+		wait := policy.Backoff(attempt)
+		log.Printf("Error writing to dead-letter topic %s, retrying in %s: %v", msg.Topic, wait, err)
+		select {
+		case <-kcm.stopping.Done():
+			return false
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Shutdown stops fetching, waits until the messages being handled are done
+// and their offsets committed, and closes the subscriptions. Once ctx is done
+// it stops waiting; unfinished messages are redelivered after a restart. The
+// broker stays open, the producer closes it.
+func (kcm *KafkaConsumerManager) Shutdown(ctx context.Context) error {
+	kcm.stop()
+
+	done := make(chan struct{})
+	go func() {
+		kcm.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Println("Kafka consumers did not finish in time:", ctx.Err())
+	}
+	return kcm.Close()
+}
+
+// Close closes every subscription, also when some of them fail to close, and
+// returns their errors joined.
 func (kcm *KafkaConsumerManager) Close() error {
 	kcm.mu.Lock()
 	defer kcm.mu.Unlock()
 
-	for _, reader := range kcm.consumers {
-		if err := reader.Close(); err != nil {
-			return err
+	var errs []error
+	for topic, c := range kcm.consumers {
+		if err := c.sub.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing consumer of topic %s: %w", topic, err))
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
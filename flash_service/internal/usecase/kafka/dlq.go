@@ -0,0 +1,114 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers a dead-lettered message carries on top of its original ones.
+const (
+	HeaderOriginalTopic     = "dlq-original-topic"
+	HeaderOriginalPartition = "dlq-original-partition"
+	HeaderOriginalOffset    = "dlq-original-offset"
+	HeaderError             = "dlq-error"
+	HeaderAttempts          = "dlq-attempts"
+	HeaderFailedAt          = "dlq-failed-at"
+)
+
+const deadLetterHeaderPrefix = "dlq-"
+
+// DeadLetterTopic is where messages of topic go once their consumer gives up.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// DeadLetter wraps msg for the dead-letter topic, recording where it came
+// from and why it failed.
+func DeadLetter(msg kafka.Message, cause error, attempts int, failedAt time.Time) kafka.Message {
+	headers := make([]kafka.Header, 0, len(msg.Headers)+6)
+	for _, h := range msg.Headers {
+		if !strings.HasPrefix(h.Key, deadLetterHeaderPrefix) {
+			headers = append(headers, h)
+		}
+	}
+	headers = append(headers,
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339))},
+	)
+
+	return kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// Replay turns a dead-lettered message back into one for its original topic.
+func Replay(msg kafka.Message) (kafka.Message, error) {
+	var topic string
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, h := range msg.Headers {
+		if h.Key == HeaderOriginalTopic {
+			topic = string(h.Value)
+		}
+		if !strings.HasPrefix(h.Key, deadLetterHeaderPrefix) {
+			headers = append(headers, h)
+		}
+	}
+	if topic == "" {
+		return kafka.Message{}, fmt.Errorf("message %d has no %s header", msg.Offset, HeaderOriginalTopic)
+	}
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}, nil
+}
+
+// ReplayDeadLetters moves up to limit messages from the dead-letter topic of
+// topic back to the topic they failed on, stopping early once no message
+// arrives for idle. A limit of 0 replays everything. It returns the number of
+// messages replayed.
+func ReplayDeadLetters(ctx context.Context, broker Broker, topic string, limit int, idle time.Duration) (int, error) {
+	reader := broker.Subscribe(DeadLetterTopic(topic), DeadLetterTopic(topic)+"-replay")
+	defer reader.Close()
+
+	replayed := 0
+	for limit == 0 || replayed < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, idle)
+		msg, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			break
+		} else if err != nil {
+			return replayed, err
+		}
+
+		out, err := Replay(msg)
+		if err != nil {
+			return replayed, err
+		}
+		if err := broker.Publish(ctx, out); err != nil {
+			return replayed, err
+		}
+		// commit only after the message is back on its topic
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,159 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MemoryBroker keeps topics in memory. Every topic has a single partition.
+// Consumer groups remember the offset they committed; a group that
+// subscribes again after all its members closed starts over from there, so
+// messages it fetched but never committed are delivered again, as with kafka.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	topics map[string][]kafka.Message
+	groups map[memoryGroupKey]*memoryGroup
+	// published is closed and replaced whenever messages arrive
+	published chan struct{}
+}
+
+type memoryGroupKey struct {
+	topic   string
+	groupID string
+}
+
+type memoryGroup struct {
+	next      int64
+	committed int64
+	members   int
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		topics:    make(map[string][]kafka.Message),
+		groups:    make(map[memoryGroupKey]*memoryGroup),
+		published: make(chan struct{}),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, msgs ...kafka.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		if msg.Topic == "" {
+			return errors.New("message has no topic")
+		}
+		msg.Partition = 0
+		msg.Offset = int64(len(b.topics[msg.Topic]))
+		msg.Time = time.Now()
+		b.topics[msg.Topic] = append(b.topics[msg.Topic], msg)
+	}
+
+	close(b.published)
+	b.published = make(chan struct{})
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(topic, groupID string) Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := memoryGroupKey{topic: topic, groupID: groupID}
+	group, ok := b.groups[key]
+	if !ok {
+		group = &memoryGroup{}
+		b.groups[key] = group
+	}
+	if group.members == 0 {
+		group.next = group.committed
+	}
+	group.members++
+
+	return &memorySubscription{
+		broker: b,
+		key:    key,
+		closed: make(chan struct{}),
+	}
+}
+
+// Messages returns what was published to topic so far.
+func (b *MemoryBroker) Messages(topic string) []kafka.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]kafka.Message(nil), b.topics[topic]...)
+}
+
+func (b *MemoryBroker) Close() error {
+	return nil
+}
+
+type memorySubscription struct {
+	broker    *MemoryBroker
+	key       memoryGroupKey
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (s *memorySubscription) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	b := s.broker
+	for {
+		b.mu.Lock()
+		select {
+		case <-s.closed:
+			b.mu.Unlock()
+			return kafka.Message{}, io.EOF
+		default:
+		}
+
+		group := b.groups[s.key]
+		msgs := b.topics[s.key.topic]
+		if group.next < int64(len(msgs)) {
+			msg := msgs[group.next]
+			group.next++
+			b.mu.Unlock()
+			return msg, nil
+		}
+		published := b.published
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-s.closed:
+			return kafka.Message{}, io.EOF
+		case <-published:
+		}
+	}
+}
+
+func (s *memorySubscription) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	group := b.groups[s.key]
+	for _, msg := range msgs {
+		if msg.Offset+1 > group.committed {
+			group.committed = msg.Offset + 1
+		}
+	}
+	return nil
+}
+
+func (s *memorySubscription) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+
+		s.broker.mu.Lock()
+		s.broker.groups[s.key].members--
+		s.broker.mu.Unlock()
+	})
+	return nil
+}
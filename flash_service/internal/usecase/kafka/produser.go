@@ -3,33 +3,56 @@ package kafka
 import (
 	"context"
 
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
 )
 
 type KafkaProducer interface {
-	ProduceMessages(topic string, message []byte) error
+	// ProduceMessages publishes payload to topic in a new envelope; version
+	// is that of the payload's schema.
+	ProduceMessages(topic string, payload proto.Message, version int32) error
+	// ProduceEnvelope publishes an envelope built earlier, such as one taken
+	// from the outbox. Envelopes with the same non-empty key go to the same
+	// partition, so they are consumed in the order they were published.
+	ProduceEnvelope(topic, key string, env *pb.Envelope) error
 	Close() error
 }
 
 type Producer struct {
-	writer *kafka.Writer
+	broker Broker
 }
 
-func NewKafkaProducer(brokers []string) (KafkaProducer, error) {
-	writer := &kafka.Writer{
-		Addr:                   kafka.TCP(brokers...),
-		AllowAutoTopicCreation: true,
+// NewProducer returns a producer that publishes on broker and closes it on
+// Close.
+func NewProducer(broker Broker) KafkaProducer {
+	return &Producer{broker: broker}
+}
+
+func (p *Producer) ProduceMessages(topic string, payload proto.Message, version int32) error {
+	env, err := envelope.New(payload, version)
+	if err != nil {
+		return err
 	}
-	return &Producer{writer: writer}, nil
+	return p.ProduceEnvelope(topic, "", env)
 }
 
-func (p *Producer) ProduceMessages(topic string, message []byte) error {
-	return p.writer.WriteMessages(context.Background(), kafka.Message{
+func (p *Producer) ProduceEnvelope(topic, key string, env *pb.Envelope) error {
+	message, err := envelope.Marshal(env)
+	if err != nil {
+		return err
+	}
+	msg := kafka.Message{
 		Topic: topic,
 		Value: message,
-	})
+	}
+	if key != "" {
+		msg.Key = []byte(key)
+	}
+	return p.broker.Publish(context.Background(), msg)
 }
 
 func (p *Producer) Close() error {
-	return p.writer.Close()
+	return p.broker.Close()
 }
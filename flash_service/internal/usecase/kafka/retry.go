@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+)
+
+// Handler processes one message, given in the envelope it came in. A
+// returned error makes the consumer retry the message under its RetryPolicy;
+// errors wrapped with Permanent skip the retries and send the message straight
+// to the dead-letter topic.
+type Handler func(ctx context.Context, env *pb.Envelope) error
+
+// RetryPolicy says how often a consumer runs a failing message through its
+// handler before the message goes to the dead-letter topic, and how long it
+// waits in between: InitialBackoff, doubled after every attempt up to
+// MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff is the wait after the given failed attempt.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as one that retrying the message cannot fix, such as a
+// message that does not parse.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
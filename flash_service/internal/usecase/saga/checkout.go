@@ -0,0 +1,211 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckoutKind is the kind of the sagas Checkout runs.
+const CheckoutKind = "checkout"
+
+// checkoutState is what the checkout steps share and what survives a restart.
+type checkoutState struct {
+	ReservationID  string      `json:"reservation_id"`
+	OrderID        string      `json:"order_id,omitempty"`
+	UserID         string      `json:"user_id,omitempty"`
+	Amount         float32     `json:"amount,omitempty"`
+	PaymentID      string      `json:"payment_id,omitempty"`
+	Payment        *pb.Payment `json:"payment,omitempty"`
+	NotificationID string      `json:"notification_id,omitempty"`
+}
+
+// Checkout pays for a held reservation in four steps: check the hold, charge
+// the buyer, confirm the order and book the charge, then queue a notification.
+// Confirming the order is the pivot: a checkout interrupted before it is rolled
+// back, one interrupted after it is finished.
+type Checkout struct {
+	engine
+	payments payment.PaymentProvider
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// NewCheckout returns a Checkout that limits every call to payments to timeout
+// and gives up recovering a saga after maxAttempts.
+func NewCheckout(storage st.StorageI, payments payment.PaymentProvider, timeout time.Duration, maxAttempts int) *Checkout {
+	return &Checkout{
+		engine:   engine{storage: storage, maxAttempts: maxAttempts},
+		payments: payments,
+		timeout:  timeout,
+		now:      time.Now,
+	}
+}
+
+func (c *Checkout) Run(ctx context.Context, req *pb.CheckoutReq) (*pb.CheckoutRes, error) {
+	state := &checkoutState{ReservationID: req.ReservationId}
+	res := &pb.CheckoutRes{}
+
+	r := &run{pivot: 2, state: state}
+	r.steps = c.steps(state, req.PaymentToken, res, func(ctx context.Context) error { return c.save(ctx, r) })
+	if err := c.start(ctx, CheckoutKind, r); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Recover finishes or rolls back a checkout saga that stopped moving.
+func (c *Checkout) Recover(ctx context.Context, record *st.Saga) error {
+	state := &checkoutState{}
+	if err := json.Unmarshal(record.Data, state); err != nil {
+		return err
+	}
+
+	// the payment token is gone, but recovery never charges again: the charge
+	// comes before the pivot and is only ever refunded
+	r := &run{record: record, pivot: 2, state: state}
+	r.steps = c.steps(state, "", &pb.CheckoutRes{}, func(ctx context.Context) error { return c.save(ctx, r) })
+	return c.recover(ctx, r)
+}
+
+// steps builds the checkout steps. checkpoint saves state in the middle of a
+// step, for what recovery must not lose even if the step never returns.
+func (c *Checkout) steps(state *checkoutState, token string, res *pb.CheckoutRes, checkpoint func(ctx context.Context) error) []Step {
+	reservation := &pb.GetById{Id: state.ReservationID}
+
+	return []Step{
+		{
+			Name: "hold",
+			Execute: func(ctx context.Context) error {
+				held, amount, err := c.storage.Reservation().CheckoutAmount(ctx, reservation, c.now())
+				if err != nil {
+					return err
+				}
+				state.OrderID = held.OrderId
+				state.UserID = held.UserId
+				state.Amount = amount
+				return nil
+			},
+			// releasing cancels the order and gives the stock back; a hold that
+			// already ended one way or another has nothing left to give back
+			Compensate: func(ctx context.Context) error {
+				_, err := c.storage.Reservation().ReleaseReservation(ctx, reservation, c.now())
+				switch status.Code(err) {
+				case codes.FailedPrecondition, codes.NotFound:
+					return nil
+				}
+				return err
+			},
+		},
+		{
+			Name: "payment",
+			Execute: func(ctx context.Context) error {
+				if c.payments == nil {
+					return status.Error(codes.Unavailable, "payments are not available")
+				}
+				ctx, cancel := context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+
+				// the reservation id makes a retried checkout reuse the first authorization
+				auth, err := c.payments.Authorize(ctx, payment.AuthorizeReq{
+					IdempotencyKey: state.ReservationID,
+					OrderID:        state.OrderID,
+					UserID:         state.UserID,
+					Amount:         state.Amount,
+					Token:          token,
+				})
+				if err != nil {
+					return paymentError("authorizing payment", err)
+				}
+				state.PaymentID = auth.ID
+				// the buyer is charged from here on; without the id a crash would
+				// leave recovery nothing to refund
+				if err := checkpoint(ctx); err != nil {
+					return err
+				}
+
+				captured, err := c.payments.Capture(ctx, auth.ID, state.Amount)
+				if err != nil {
+					return paymentError("capturing payment", err)
+				}
+				state.Payment = &pb.Payment{
+					Provider:          c.payments.Name(),
+					ProviderPaymentId: captured.ID,
+					Amount:            state.Amount,
+					Status:            captured.Status,
+				}
+				return nil
+			},
+			// an authorization that timed out before we learned its id, or whose
+			// id could not be saved, is left to expire at the provider
+			Compensate: func(ctx context.Context) error {
+				if state.PaymentID == "" {
+					return nil
+				}
+				ctx, cancel := context.WithTimeout(ctx, c.timeout)
+				defer cancel()
+
+				_, err := c.payments.Refund(ctx, state.PaymentID, state.Amount)
+				return err
+			},
+		},
+		{
+			Name: "order",
+			Execute: func(ctx context.Context) error {
+				confirmed, err := c.storage.Reservation().CompleteCheckout(ctx, reservation, state.Payment, c.now())
+				if err != nil {
+					return err
+				}
+				res.Reservation = confirmed
+				res.Payment = state.Payment
+				return nil
+			},
+			Compensate: func(ctx context.Context) error {
+				return c.storage.Reservation().CancelCheckout(ctx, reservation, c.now())
+			},
+		},
+		{
+			Name: "notify",
+			Execute: func(ctx context.Context) error {
+				if state.NotificationID != "" {
+					return nil
+				}
+				id, err := c.storage.Notification().QueueNotification(ctx, &pb.NotificationCreate{
+					UserId:  state.UserID,
+					Type:    "email",
+					Content: fmt.Sprintf("Your order %s is confirmed, %.2f was charged.", state.OrderID, state.Amount),
+				})
+				if err != nil {
+					return err
+				}
+				state.NotificationID = id
+				return nil
+			},
+			Compensate: func(ctx context.Context) error {
+				if state.NotificationID == "" {
+					return nil
+				}
+				return c.storage.Notification().DropNotification(ctx, &pb.GetById{Id: state.NotificationID})
+			},
+		},
+	}
+}
+
+// paymentError turns a provider error into the status the caller gets.
+func paymentError(op string, err error) error {
+	switch {
+	case errors.Is(err, payment.ErrDeclined):
+		return status.Errorf(codes.FailedPrecondition, "%v, the reservation was released", err)
+	case errors.Is(err, payment.ErrTimeout):
+		return status.Errorf(codes.Unavailable, "%v, the reservation was released", err)
+	}
+	return status.Errorf(codes.Unavailable, "%s: %v", op, err)
+}
@@ -0,0 +1,160 @@
+// Package saga runs workflows that span several repositories as a series of
+// steps, undoing the steps already taken when a later one fails. Progress is
+// saved after every move so a saga cut short by a restart can be finished or
+// undone by Recovery.
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+)
+
+// Saga statuses, stored in sagas.status.
+const (
+	Running      = "running"
+	Compensating = "compensating"
+	Completed    = "completed"
+	Compensated  = "compensated"
+	Failed       = "failed"
+)
+
+// compensationTimeout bounds undoing a saga once the caller gave up on it.
+const compensationTimeout = 30 * time.Second
+
+// Step is one move of a saga. Compensate undoes Execute and is also called for
+// a step whose Execute failed or never returned, so both must be safe to repeat
+// and Compensate must cope with Execute having done nothing.
+type Step struct {
+	Name       string
+	Execute    func(ctx context.Context) error
+	Compensate func(ctx context.Context) error
+}
+
+// run is a saga being driven: its steps close over state, which is saved into
+// the record with every change of progress.
+type run struct {
+	record *st.Saga
+	steps  []Step
+	// pivot is the step past which a saga is finished rather than undone; the
+	// steps before it can't be redone without the caller. Recovery finishes a
+	// saga that started the pivot, since it can't tell whether the pivot took.
+	pivot int
+	state interface{}
+}
+
+type engine struct {
+	storage     st.StorageI
+	maxAttempts int
+}
+
+func (e *engine) start(ctx context.Context, kind string, r *run) error {
+	data, err := json.Marshal(r.state)
+	if err != nil {
+		return err
+	}
+	r.record = &st.Saga{Kind: kind, Status: Running, Data: data}
+	if err := e.storage.Saga().CreateSaga(ctx, r.record); err != nil {
+		return err
+	}
+	return e.forward(ctx, r)
+}
+
+func (e *engine) save(ctx context.Context, r *run) error {
+	data, err := json.Marshal(r.state)
+	if err != nil {
+		return err
+	}
+	r.record.Data = data
+	return e.storage.Saga().UpdateSaga(ctx, r.record)
+}
+
+// forward executes the steps from the first one not started yet. A step up to
+// the pivot that fails compensates the saga and its error is returned. A step
+// after the pivot that fails leaves the saga running for recovery to finish:
+// the saga has taken effect, only its tail is missing.
+func (e *engine) forward(ctx context.Context, r *run) error {
+	for r.record.Step < len(r.steps) {
+		step := r.steps[r.record.Step]
+
+		// saved before the step runs, so recovery knows it may have happened
+		r.record.Step++
+		if err := e.save(ctx, r); err != nil {
+			return err
+		}
+
+		if err := step.Execute(ctx); err != nil {
+			r.record.Error = fmt.Sprintf("%s: %v", step.Name, err)
+			// the step may have failed because the caller went away, what
+			// follows must happen all the same
+			ctx, cancel := detached(ctx)
+			defer cancel()
+
+			if r.record.Step-1 > r.pivot {
+				log.Printf("Error while finishing %s saga %s, recovery will retry: %v", r.record.Kind, r.record.ID, err)
+				return e.save(ctx, r)
+			}
+			if cerr := e.backward(ctx, r); cerr != nil {
+				log.Printf("Error while compensating %s saga %s, recovery will retry: %v", r.record.Kind, r.record.ID, cerr)
+			}
+			return err
+		}
+	}
+
+	r.record.Status = Completed
+	return e.save(ctx, r)
+}
+
+// backward compensates the started steps, last one first. A failing
+// compensation leaves the saga compensating for recovery to retry.
+func (e *engine) backward(ctx context.Context, r *run) error {
+	r.record.Status = Compensating
+	if err := e.save(ctx, r); err != nil {
+		return err
+	}
+
+	for r.record.Step > 0 {
+		step := r.steps[r.record.Step-1]
+		if err := step.Compensate(ctx); err != nil {
+			r.record.Error = fmt.Sprintf("compensating %s: %v", step.Name, err)
+			if serr := e.save(ctx, r); serr != nil {
+				log.Printf("Error while saving %s saga %s: %v", r.record.Kind, r.record.ID, serr)
+			}
+			return err
+		}
+
+		r.record.Step--
+		if err := e.save(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	r.record.Status = Compensated
+	return e.save(ctx, r)
+}
+
+// recover picks up a saga that stopped moving. A running saga that got past
+// its pivot is finished by repeating its last started step; any other is
+// compensated. A saga that keeps failing is marked failed for a person to fix.
+func (e *engine) recover(ctx context.Context, r *run) error {
+	if r.record.Attempts > e.maxAttempts {
+		r.record.Status = Failed
+		return e.save(ctx, r)
+	}
+
+	if r.record.Status == Running && r.record.Step > r.pivot {
+		r.record.Step--
+		return e.forward(ctx, r)
+	}
+	return e.backward(ctx, r)
+}
+
+// detached returns a context that outlives the caller giving up on ctx, so
+// that a saga is not left half undone until recovery, but is still bounded.
+func detached(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), compensationTimeout)
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+)
+
+// FlashSaleScheduler moves flash sales from pending to active to completed
+// according to their start_time and end_time. The events of every change go
+// out through the outbox, in the transaction that made it.
+type FlashSaleScheduler struct {
+	storage  st.StorageI
+	interval time.Duration
+}
+
+func NewFlashSaleScheduler(storage st.StorageI, interval time.Duration) *FlashSaleScheduler {
+	return &FlashSaleScheduler{
+		storage:  storage,
+		interval: interval,
+	}
+}
+
+// Run ticks until ctx is canceled.
+func (s *FlashSaleScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *FlashSaleScheduler) tick(ctx context.Context) {
+	events, err := s.storage.FlashSale().AdvanceFlashSales(ctx, time.Now())
+	if err != nil {
+		log.Println("Error while advancing flash sales:", err)
+		return
+	}
+
+	for _, event := range events {
+		log.Printf("Flash sale %s moved from %s to %s", event.FlashSaleId, event.FromStatus, event.ToStatus)
+	}
+}
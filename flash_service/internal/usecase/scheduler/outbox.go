@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+)
+
+const (
+	// relayBatchSize caps how many events one pass publishes.
+	relayBatchSize = 100
+	// relayLease hides claimed events from other replicas while they are
+	// being published; a relay that dies leaves them to be claimed again.
+	relayLease = time.Minute
+)
+
+// OutboxRelay publishes the domain events written to the outbox. An event is
+// marked sent only after kafka accepted it, so every event is delivered at
+// least once and consumers have to tolerate duplicates. A failed publish is
+// retried with exponential backoff from retryBackoff up to maxBackoff.
+//
+// Events are keyed by their aggregate and the events of one aggregate are
+// published in the order they were written: once one fails, the later ones
+// wait for its retry.
+type OutboxRelay struct {
+	storage      st.StorageI
+	producer     kafka.KafkaProducer
+	interval     time.Duration
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+}
+
+func NewOutboxRelay(storage st.StorageI, producer kafka.KafkaProducer, interval, retryBackoff, maxBackoff time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		storage:      storage,
+		producer:     producer,
+		interval:     interval,
+		retryBackoff: retryBackoff,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// Run relays until ctx is canceled.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// keep going while there is a backlog
+		for r.Relay(ctx) == relayBatchSize && ctx.Err() == nil {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay publishes one batch of due events and returns how many it claimed.
+func (r *OutboxRelay) Relay(ctx context.Context) int {
+	events, err := r.storage.Outbox().ClaimEvents(ctx, time.Now(), relayLease, relayBatchSize)
+	if err != nil {
+		log.Println("Error while claiming outbox events:", err)
+		return 0
+	}
+
+	// aggregates whose event failed, and when that event is retried
+	held := make(map[string]time.Time)
+	for _, event := range events {
+		if retryAt, ok := held[event.AggregateID]; ok {
+			if err := r.storage.Outbox().MarkFailed(ctx, event.ID, retryAt, "waiting for an earlier event of "+event.AggregateID); err != nil {
+				log.Printf("Error while holding back outbox event %d: %v", event.ID, err)
+			}
+			continue
+		}
+
+		if err := r.publish(event); err != nil {
+			retryAt := time.Now().Add(r.backoff(event.Attempts))
+			held[event.AggregateID] = retryAt
+			log.Printf("Error while publishing outbox event %d to %s, retrying at %s: %v", event.ID, event.Topic, retryAt.Format(time.RFC3339), err)
+			if err := r.storage.Outbox().MarkFailed(ctx, event.ID, retryAt, err.Error()); err != nil {
+				log.Printf("Error while marking outbox event %d failed: %v", event.ID, err)
+			}
+			continue
+		}
+
+		// a failure here only means the event goes out once more
+		if err := r.storage.Outbox().MarkSent(ctx, event.ID); err != nil {
+			log.Printf("Error while marking outbox event %d sent: %v", event.ID, err)
+		}
+	}
+	return len(events)
+}
+
+// publish sends the envelope the event was stored in, keyed by its aggregate.
+func (r *OutboxRelay) publish(event *st.OutboxEvent) error {
+	env, err := envelope.Unmarshal(event.Payload)
+	if err != nil {
+		return err
+	}
+	return r.producer.ProduceEnvelope(event.Topic, event.AggregateID, env)
+}
+
+// backoff doubles the wait with every failed attempt.
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	wait := r.retryBackoff
+	for i := 1; i < attempts && wait < r.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > r.maxBackoff {
+		wait = r.maxBackoff
+	}
+	return wait
+}
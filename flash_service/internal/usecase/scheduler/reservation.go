@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+)
+
+// ReservationSweeper expires holds that were neither confirmed nor released in
+// time, which cancels their orders and returns the units to the sale.
+type ReservationSweeper struct {
+	storage  st.StorageI
+	interval time.Duration
+}
+
+func NewReservationSweeper(storage st.StorageI, interval time.Duration) *ReservationSweeper {
+	return &ReservationSweeper{
+		storage:  storage,
+		interval: interval,
+	}
+}
+
+// Run sweeps until ctx is canceled.
+func (s *ReservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ReservationSweeper) sweep(ctx context.Context) {
+	expired, err := s.storage.Reservation().ExpireReservations(ctx, time.Now())
+	if err != nil {
+		log.Println("Error while expiring reservations:", err)
+		return
+	}
+
+	for _, res := range expired {
+		log.Printf("Reservation %s expired, order %s canceled", res.Id, res.OrderId)
+	}
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+)
+
+// recoverBatchSize caps how many stuck sagas one pass picks up.
+const recoverBatchSize = 50
+
+// SagaRecoverer finishes or undoes stuck sagas of one kind.
+type SagaRecoverer interface {
+	Recover(ctx context.Context, saga *st.Saga) error
+}
+
+// SagaRecovery picks up sagas that made no progress for staleAfter, most
+// likely because the replica running them went down, and hands each to the
+// recoverer of its kind.
+type SagaRecovery struct {
+	storage    st.StorageI
+	interval   time.Duration
+	staleAfter time.Duration
+	recoverers map[string]SagaRecoverer
+}
+
+func NewSagaRecovery(storage st.StorageI, interval, staleAfter time.Duration, recoverers map[string]SagaRecoverer) *SagaRecovery {
+	return &SagaRecovery{
+		storage:    storage,
+		interval:   interval,
+		staleAfter: staleAfter,
+		recoverers: recoverers,
+	}
+}
+
+// Run recovers until ctx is canceled.
+func (s *SagaRecovery) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.recover(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SagaRecovery) recover(ctx context.Context) {
+	sagas, err := s.storage.Saga().ClaimStuckSagas(ctx, time.Now().Add(-s.staleAfter), recoverBatchSize)
+	if err != nil {
+		log.Println("Error while claiming stuck sagas:", err)
+		return
+	}
+
+	for _, saga := range sagas {
+		recoverer, ok := s.recoverers[saga.Kind]
+		if !ok {
+			log.Printf("No recoverer for saga %s of kind %s", saga.ID, saga.Kind)
+			continue
+		}
+
+		if err := recoverer.Recover(ctx, saga); err != nil {
+			log.Printf("Error while recovering %s saga %s: %v", saga.Kind, saga.ID, err)
+			continue
+		}
+		log.Printf("Recovered %s saga %s, now %s", saga.Kind, saga.ID, saga.Status)
+	}
+}
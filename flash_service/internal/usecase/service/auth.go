@@ -20,7 +20,7 @@ func NewAuthService(storage storage.StorageI, kafka kafka.KafkaProducer) *AuthSe
 }
 
 func (s *AuthService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.Void, error) {
-	res, err := s.storage.Auth().Register(req)
+	res, err := s.storage.Auth().Register(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +29,7 @@ func (s *AuthService) Register(ctx context.Context, req *pb.RegisterReq) (*pb.Vo
 }
 
 func (s *AuthService) Login(ctx context.Context, req *pb.LoginReq) (*pb.User, error) {
-	res, err := s.storage.Auth().Login(req)
+	res, err := s.storage.Auth().Login(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (s *AuthService) Login(ctx context.Context, req *pb.LoginReq) (*pb.User, er
 }
 
 func (s *AuthService) ForgotPassword(ctx context.Context, req *pb.GetByEmail) (*pb.Void, error) {
-	res, err := s.storage.Auth().ForgotPassword(req)
+	res, err := s.storage.Auth().ForgotPassword(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func (s *AuthService) ForgotPassword(ctx context.Context, req *pb.GetByEmail) (*
 }
 
 func (s *AuthService) ResetPassword(ctx context.Context, req *pb.ResetPassReq) (*pb.Void, error) {
-	res, err := s.storage.Auth().ResetPassword(req)
+	res, err := s.storage.Auth().ResetPassword(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,34 @@ func (s *AuthService) ResetPassword(ctx context.Context, req *pb.ResetPassReq) (
 }
 
 func (s *AuthService) SaveRefreshToken(ctx context.Context, req *pb.RefToken) (*pb.Void, error) {
-	res, err := s.storage.Auth().SaveRefreshToken(req)
+	res, err := s.storage.Auth().SaveRefreshToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) RotateRefreshToken(ctx context.Context, req *pb.RotateTokenReq) (*pb.User, error) {
+	res, err := s.storage.Auth().RotateRefreshToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) RevokeRefreshToken(ctx context.Context, req *pb.RefreshReq) (*pb.Void, error) {
+	res, err := s.storage.Auth().RevokeRefreshToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) RevokeUserTokens(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
+	res, err := s.storage.Auth().RevokeUserTokens(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +92,7 @@ func (s *AuthService) SaveRefreshToken(ctx context.Context, req *pb.RefToken) (*
 }
 
 func (s *AuthService) GetAllUsers(ctx context.Context, req *pb.ListUserReq) (*pb.ListUserRes, error) {
-	res, err := s.storage.Auth().GetAllUsers(req)
+	res, err := s.storage.Auth().GetAllUsers(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +100,7 @@ func (s *AuthService) GetAllUsers(ctx context.Context, req *pb.ListUserReq) (*pb
 	return res, nil
 }
 func (s *AuthService) GetUserById(ctx context.Context, req *pb.GetById) (*pb.UserRes, error) {
-	res, err := s.storage.Auth().GetUserById(req)
+	res, err := s.storage.Auth().GetUserById(ctx, req)
 	if err != nil {
 		return nil, err
 	}
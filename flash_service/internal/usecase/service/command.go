@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+)
+
+// CommandService tracks the writes the gateway hands over through kafka. It
+// records the outcome the consumers report, optionally replies on the topic
+// the command asked for, and answers status lookups.
+type CommandService struct {
+	storage  st.StorageI
+	producer kafka.KafkaProducer
+	pb.UnimplementedCommandServiceServer
+}
+
+func NewCommandService(storage st.StorageI, kafka kafka.KafkaProducer) *CommandService {
+	return &CommandService{
+		storage:  storage,
+		producer: kafka,
+	}
+}
+
+func (s *CommandService) GetCommandStatus(ctx context.Context, req *pb.CommandStatusReq) (*pb.CommandStatus, error) {
+	res, err := s.storage.Command().GetCommandStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RecordCommand saves result and, once the command is finished, publishes its
+// status to the reply topic if it named one. Topics without
+// kafka.ReplyTopicPrefix are never replied on.
+func (s *CommandService) RecordCommand(ctx context.Context, result kafka.CommandResult) error {
+	cmd := &pb.CommandStatus{
+		Id:     result.ID,
+		Topic:  result.Topic,
+		Status: result.Status,
+		UserId: result.UserID,
+	}
+	if result.Err != nil {
+		cmd.Error = result.Err.Error()
+	}
+
+	if err := s.storage.Command().RecordCommand(ctx, cmd); err != nil {
+		return err
+	}
+	if result.ReplyTo == "" || result.Status == kafka.CommandRetrying {
+		return nil
+	}
+	if !kafka.IsReplyTopic(result.ReplyTo) {
+		log.Printf("Not replying to command %s on %s: not a reply topic", cmd.Id, result.ReplyTo)
+		return nil
+	}
+
+	reply, err := envelope.New(cmd, 1)
+	if err != nil {
+		return err
+	}
+	reply.CorrelationId = cmd.Id
+	if err := s.producer.ProduceEnvelope(result.ReplyTo, "", reply); err != nil {
+		// the status is saved, the client can still look it up
+		log.Printf("Error replying to command %s on %s: %v", cmd.Id, result.ReplyTo, err)
+	}
+	return nil
+}
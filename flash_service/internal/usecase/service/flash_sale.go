@@ -6,21 +6,33 @@ import (
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
 	st "github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"github.com/Mubinabd/flash_sale/internal/usecase/watcher"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type FlashSaleService struct {
 	storage  st.StorageI
+	hub      *watcher.Hub
 	pb.UnimplementedFlashSaleServiceServer
 }
 
-func NewFlashSaleService(storage st.StorageI, kafka kafka.KafkaProducer) *FlashSaleService {
+// NewFlashSaleService returns the service; hub may be nil when nothing needs
+// WatchFlashSale.
+func NewFlashSaleService(storage st.StorageI, kafka kafka.KafkaProducer, hub *watcher.Hub) *FlashSaleService {
 	return &FlashSaleService{
 		storage: storage,
+		hub:     hub,
 	}
 }
 
 func (s *FlashSaleService) CreateFlashSale(ctx context.Context, req *pb.CreateFlashSalesReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSale().CreateFlashSale(req)
+	// the scheduler activates the sale once start_time is reached
+	if req.Status == "" {
+		req.Status = "pending"
+	}
+
+	res, err := s.storage.FlashSale().CreateFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +41,7 @@ func (s *FlashSaleService) CreateFlashSale(ctx context.Context, req *pb.CreateFl
 }
 
 func (s *FlashSaleService) UpdateFlashSale(ctx context.Context, req *pb.UpdateFlashSalesReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSale().UpdateFlashSale(req)
+	res, err := s.storage.FlashSale().UpdateFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +50,7 @@ func (s *FlashSaleService) UpdateFlashSale(ctx context.Context, req *pb.UpdateFl
 }
 
 func (s *FlashSaleService) ListAllFlashSales(ctx context.Context, req *pb.ListAllFlashSalesReq) (*pb.ListAllFlashSalesRes, error) {
-	res, err := s.storage.FlashSale().ListAllFlashSales(req)
+	res, err := s.storage.FlashSale().ListAllFlashSales(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +59,7 @@ func (s *FlashSaleService) ListAllFlashSales(ctx context.Context, req *pb.ListAl
 }
 
 func (s *FlashSaleService) GetFlashSale(ctx context.Context, req *pb.GetById) (*pb.FlashSale, error) {
-	res, err := s.storage.FlashSale().GetFlashSale(req)
+	res, err := s.storage.FlashSale().GetFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +68,7 @@ func (s *FlashSaleService) GetFlashSale(ctx context.Context, req *pb.GetById) (*
 }
 
 func (s *FlashSaleService) DeleteFlashSale(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
-	res, err := s.storage.FlashSale().DeleteFlashSale(req)
+	res, err := s.storage.FlashSale().DeleteFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +77,7 @@ func (s *FlashSaleService) DeleteFlashSale(ctx context.Context, req *pb.GetById)
 }
 
 func (s *FlashSaleService) AddProductToFlashSale(ctx context.Context, req *pb.AddProductReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSale().AddProductToFlashSale(req)
+	res, err := s.storage.FlashSale().AddProductToFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -74,7 +86,7 @@ func (s *FlashSaleService) AddProductToFlashSale(ctx context.Context, req *pb.Ad
 }
 
 func (s *FlashSaleService) RemoveProductFromFlashSale(ctx context.Context, req *pb.RemoveProductReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSale().RemoveProductFromFlashSale(req)
+	res, err := s.storage.FlashSale().RemoveProductFromFlashSale(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +95,11 @@ func (s *FlashSaleService) RemoveProductFromFlashSale(ctx context.Context, req *
 }
 
 func (s *FlashSaleService) CancelFlashSale(ctx context.Context, req *pb.GetById) (*pb.CancelFlashSaleRes, error) {
-	res, err := s.storage.FlashSale().CancelFlashSale(req)
+	var res *pb.CancelFlashSaleRes
+	err := s.storage.WithTx(ctx, func(tx st.StorageI) (err error) {
+		res, err = tx.FlashSale().CancelFlashSale(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -93,10 +109,51 @@ func (s *FlashSaleService) CancelFlashSale(ctx context.Context, req *pb.GetById)
 
 
 func (s *FlashSaleService) GetStoreLocation(ctx context.Context, req *pb.GetStoreLocationReq) (*pb.StoreLocation, error) {
-	res, err := s.storage.FlashSale().GetStoreLocation(req)
+	res, err := s.storage.FlashSale().GetStoreLocation(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
 	return res, nil
-}
\ No newline at end of file
+}
+
+func (s *FlashSaleService) WatchFlashSale(req *pb.GetById, stream pb.FlashSaleService_WatchFlashSaleServer) error {
+	if s.hub == nil {
+		return status.Error(codes.Unavailable, "flash sale updates are not available")
+	}
+
+	// subscribe before reading the snapshot so nothing falls in between
+	updates, unsubscribe := s.hub.Subscribe(req.Id)
+	defer unsubscribe()
+
+	snapshot, err := s.storage.FlashSale().GetFlashSaleSnapshot(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	for _, update := range snapshot {
+		if err := stream.Send(update); err != nil {
+			return err
+		}
+	}
+	if saleEnded(snapshot[0]) {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case update := <-updates:
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			if saleEnded(update) {
+				return nil
+			}
+		}
+	}
+}
+
+func saleEnded(update *pb.FlashSaleUpdate) bool {
+	return update.Type == "status" && (update.Status == "completed" || update.Status == "canceled")
+}
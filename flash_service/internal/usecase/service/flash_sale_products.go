@@ -24,7 +24,7 @@ func NewFlashSaleProductService(storage st.StorageI, kafka kafka.KafkaProducer)
 
 
 func (s *FlashSaleProductService) CreateFlashSaleProduct(ctx context.Context, req *pb.CreateFlashSaleProductReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSaleProduct().CreateFlashSaleProduct(req)
+	res, err := s.storage.FlashSaleProduct().CreateFlashSaleProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func (s *FlashSaleProductService) CreateFlashSaleProduct(ctx context.Context, re
 }
 
 func (s *FlashSaleProductService) UpdateFlashSaleProduct(ctx context.Context, req *pb.UpdateFlashSaleProductReq) (*pb.Void, error) {
-	res, err := s.storage.FlashSaleProduct().UpdateFlashSaleProduct(req)
+	res, err := s.storage.FlashSaleProduct().UpdateFlashSaleProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func (s *FlashSaleProductService) UpdateFlashSaleProduct(ctx context.Context, re
 }
 
 func (s *FlashSaleProductService) ListAllFlashSaleProducts(ctx context.Context, req *pb.ListAllFlashSaleProductsReq) (*pb.ListAllFlashSaleProductsRes, error) {
-	res, err := s.storage.FlashSaleProduct().ListAllFlashSaleProducts(req)
+	res, err := s.storage.FlashSaleProduct().ListAllFlashSaleProducts(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -50,8 +50,8 @@ func (s *FlashSaleProductService) ListAllFlashSaleProducts(ctx context.Context,
 	return res, nil
 }
 
-func (s *FlashSaleProductService) GetFlashSaleProduct(ctx context.Context, req *pb.GetById) (*pb.FlashSaleProduct, error) {
-	res, err := s.storage.FlashSaleProduct().GetFlashSaleProduct(req)
+func (s *FlashSaleProductService) GetFlashSaleProduct(ctx context.Context, req *pb.GetFlashSaleProductReq) (*pb.FlashSaleProduct, error) {
+	res, err := s.storage.FlashSaleProduct().GetFlashSaleProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +60,7 @@ func (s *FlashSaleProductService) GetFlashSaleProduct(ctx context.Context, req *
 }
 
 func (s *FlashSaleProductService) DeleteFlashSaleProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
-	res, err := s.storage.FlashSaleProduct().DeleteFlashSaleProduct(req)
+	res, err := s.storage.FlashSaleProduct().DeleteFlashSaleProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -18,17 +18,17 @@ func NewNotificationService(stg storage.StorageI, kafka kafka.KafkaProducer) *No
 }
 
 func (s *NotificationService) CreateNotification(ctx context.Context, req *pb.NotificationCreate) (*pb.Void, error) {
-	return s.stg.Notification().CreateNotification(req)
+	return s.stg.Notification().CreateNotification(ctx, req)
 }
 func (s *NotificationService) DeleteNotification(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
-	return s.stg.Notification().DeleteNotification(req)
+	return s.stg.Notification().DeleteNotification(ctx, req)
 }
 func (s *NotificationService) UpdateNotification(ctx context.Context, req *pb.NotificationUpdate) (*pb.Void, error) {
-	return s.stg.Notification().UpdateNotification(req)
+	return s.stg.Notification().UpdateNotification(ctx, req)
 }
 func (s *NotificationService) GetNotifications(ctx context.Context, req *pb.NotifFilter) (*pb.NotificationList, error) {
-	return s.stg.Notification().GetNotifications(req)
+	return s.stg.Notification().GetNotifications(ctx, req)
 }
 func (s *NotificationService) GetNotification(ctx context.Context, req *pb.GetById) (*pb.NotificationGet, error) {
-	return s.stg.Notification().GetNotification(req)
+	return s.stg.Notification().GetNotification(ctx, req)
 }
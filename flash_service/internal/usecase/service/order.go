@@ -4,8 +4,11 @@ import (
 	"context"
 
 	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/orderstate"
 	st "github.com/Mubinabd/flash_sale/internal/storage"
 	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type OrderService struct {
@@ -20,7 +23,20 @@ func NewOrderService(storage st.StorageI, kafka kafka.KafkaProducer) *OrderServi
 }
 
 func (s *OrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderReq) (*pb.Void, error) {
-	res, err := s.storage.Order().CreateOrder(req)
+	// every order starts pending and moves on through UpdateOrder
+	req.OrderStatus = orderstate.Pending
+
+	// flash_sale_product_id and quantity are shorthand for a single item order
+	items := req.Items
+	if req.FlashSaleProductId != "" {
+		items = append(items, &pb.OrderItemReq{FlashSaleProductId: req.FlashSaleProductId, Quantity: req.Quantity})
+	}
+	if len(items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "order must contain at least one item")
+	}
+	req.Items = mergeItems(items)
+
+	res, err := s.storage.Order().CreateOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +45,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderReq)
 }
 
 func (s *OrderService) UpdateOrder(ctx context.Context, req *pb.UpdateOrderReq) (*pb.Void, error) {
-	res, err := s.storage.Order().UpdateOrder(req)
+	res, err := s.storage.Order().UpdateOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +54,7 @@ func (s *OrderService) UpdateOrder(ctx context.Context, req *pb.UpdateOrderReq)
 }
 
 func (s *OrderService) ListAllOrders(ctx context.Context, req *pb.ListAllOrdersReq) (*pb.ListAllOrdersRes, error) {
-	res, err := s.storage.Order().ListAllOrders(req)
+	res, err := s.storage.Order().ListAllOrders(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +63,7 @@ func (s *OrderService) ListAllOrders(ctx context.Context, req *pb.ListAllOrdersR
 }
 
 func (s *OrderService) GetOrder(ctx context.Context, req *pb.GetById) (*pb.Order, error) {
-	res, err := s.storage.Order().GetOrder(req)
+	res, err := s.storage.Order().GetOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +72,7 @@ func (s *OrderService) GetOrder(ctx context.Context, req *pb.GetById) (*pb.Order
 }
 
 func (s *OrderService) DeleteOrder(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
-	res, err := s.storage.Order().DeleteOrder(req)
+	res, err := s.storage.Order().DeleteOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -67,7 +83,7 @@ func (s *OrderService) DeleteOrder(ctx context.Context, req *pb.GetById) (*pb.Vo
 
 
 func (s *OrderService) GetOrderHistory(ctx context.Context, req *pb.OrderHistoryReq) (*pb.OrderHistoryRes, error) {
-	res, err := s.storage.Order().GetOrderHistory(req)
+	res, err := s.storage.Order().GetOrderHistory(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -77,7 +93,11 @@ func (s *OrderService) GetOrderHistory(ctx context.Context, req *pb.OrderHistory
 
 
 func (s *OrderService) CancelOrder(ctx context.Context, req *pb.GetById) (*pb.CancelOrderRes, error) {
-	res, err := s.storage.Order().CancelOrder(req)
+	var res *pb.CancelOrderRes
+	err := s.storage.WithTx(ctx, func(tx st.StorageI) (err error) {
+		res, err = tx.Order().CancelOrder(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -85,3 +105,30 @@ func (s *OrderService) CancelOrder(ctx context.Context, req *pb.GetById) (*pb.Ca
 	return res, nil
 }
 
+func (s *OrderService) GetOrderTimeline(ctx context.Context, req *pb.GetById) (*pb.OrderTimeline, error) {
+	res, err := s.storage.Order().GetOrderTimeline(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// mergeItems folds repeated flash sale products into one item each and treats a
+// missing quantity as 1.
+func mergeItems(items []*pb.OrderItemReq) []*pb.OrderItemReq {
+	merged := make(map[string]*pb.OrderItemReq)
+	res := make([]*pb.OrderItemReq, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			item.Quantity = 1
+		}
+		if m, ok := merged[item.FlashSaleProductId]; ok {
+			m.Quantity += item.Quantity
+			continue
+		}
+		merged[item.FlashSaleProductId] = item
+		res = append(res, item)
+	}
+	return res
+}
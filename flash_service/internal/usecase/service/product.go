@@ -20,7 +20,7 @@ func NewProductService(storage st.StorageI, kafka kafka.KafkaProducer) *ProductS
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, req *pb.CreateProductReq) (*pb.Void, error) {
-	res, err := s.storage.Product().CreateProduct(req)
+	res, err := s.storage.Product().CreateProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +29,7 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *pb.CreateProduc
 }
 
 func (s *ProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProductReq) (*pb.Void, error) {
-	res, err := s.storage.Product().UpdateProduct(req)
+	res, err := s.storage.Product().UpdateProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (s *ProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProduc
 }
 
 func (s *ProductService) ListAllProducts(ctx context.Context, req *pb.ListAllProductsReq) (*pb.ListAllProductsRes, error) {
-	res, err := s.storage.Product().ListAllProducts(req)
+	res, err := s.storage.Product().ListAllProducts(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func (s *ProductService) ListAllProducts(ctx context.Context, req *pb.ListAllPro
 }
 
 func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetById) (*pb.Products, error) {
-	res, err := s.storage.Product().GetProduct(req)
+	res, err := s.storage.Product().GetProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetById) (*pb.P
 }
 
 func (s *ProductService) DeleteProduct(ctx context.Context, req *pb.GetById) (*pb.Void, error) {
-	res, err := s.storage.Product().DeleteProduct(req)
+	res, err := s.storage.Product().DeleteProduct(ctx, req)
 	if err != nil {
 		return nil, err
 	}
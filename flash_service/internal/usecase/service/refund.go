@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RefundService struct {
+	storage  st.StorageI
+	payments payment.PaymentProvider
+	timeout  time.Duration
+	pb.UnimplementedRefundServiceServer
+}
+
+// NewRefundService returns a RefundService that pays refunds back through
+// payments, limiting every call to timeout.
+func NewRefundService(storage st.StorageI, kafka kafka.KafkaProducer, payments payment.PaymentProvider, timeout time.Duration) *RefundService {
+	return &RefundService{
+		storage:  storage,
+		payments: payments,
+		timeout:  timeout,
+	}
+}
+
+func (s *RefundService) RequestRefund(ctx context.Context, req *pb.RefundCreateReq) (*pb.Refund, error) {
+	if req.OrderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_id is required")
+	}
+	if req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount can't be negative")
+	}
+
+	res, err := s.storage.Refund().RequestRefund(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RefundService) GetRefund(ctx context.Context, req *pb.GetById) (*pb.Refund, error) {
+	res, err := s.storage.Refund().GetRefund(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RefundService) ListRefunds(ctx context.Context, req *pb.RefundListReq) (*pb.RefundListRes, error) {
+	res, err := s.storage.Refund().ListRefunds(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RefundService) ApproveRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error) {
+	res, err := s.storage.Refund().ApproveRefund(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RefundService) RejectRefund(ctx context.Context, req *pb.RefundReviewReq) (*pb.Refund, error) {
+	if req.Reason == "" {
+		return nil, status.Error(codes.InvalidArgument, "reason is required to reject a refund")
+	}
+
+	res, err := s.storage.Refund().RejectRefund(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *RefundService) ProcessRefund(ctx context.Context, req *pb.GetById) (*pb.Refund, error) {
+	res, err := s.storage.Refund().ProcessRefund(ctx, req, s.refundPayment)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// refundPayment gives amount of a captured payment back to the buyer.
+func (s *RefundService) refundPayment(ctx context.Context, provider, paymentID string, amount float32) (string, error) {
+	if s.payments == nil || s.payments.Name() != provider {
+		return "", status.Errorf(codes.Unavailable, "payment provider %s is not available", provider)
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	p, err := s.payments.Refund(ctx, paymentID, amount)
+	if errors.Is(err, payment.ErrDeclined) {
+		return "", status.Errorf(codes.FailedPrecondition, "refunding payment: %v", err)
+	} else if err != nil {
+		return "", status.Errorf(codes.Unavailable, "refunding payment: %v", err)
+	}
+	return p.Status, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"github.com/Mubinabd/flash_sale/internal/usecase/saga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReservationService struct {
+	storage  st.StorageI
+	hold     time.Duration
+	checkout *saga.Checkout
+	pb.UnimplementedReservationServiceServer
+}
+
+// NewReservationService returns a service whose reservations hold stock for hold
+// and are paid for through checkout.
+func NewReservationService(storage st.StorageI, kafka kafka.KafkaProducer, hold time.Duration, checkout *saga.Checkout) *ReservationService {
+	return &ReservationService{
+		storage:  storage,
+		hold:     hold,
+		checkout: checkout,
+	}
+}
+
+func (s *ReservationService) CreateReservation(ctx context.Context, req *pb.CreateReservationReq) (*pb.Reservation, error) {
+	if req.UserId == "" || req.FlashSaleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and flash_sale_id are required")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "reservation must contain at least one item")
+	}
+	req.Items = mergeItems(req.Items)
+
+	res, err := s.storage.Reservation().CreateReservation(ctx, req, time.Now().Add(s.hold))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *ReservationService) ConfirmReservation(ctx context.Context, req *pb.GetById) (*pb.Reservation, error) {
+	res, err := s.storage.Reservation().ConfirmReservation(ctx, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *ReservationService) ReleaseReservation(ctx context.Context, req *pb.GetById) (*pb.Reservation, error) {
+	res, err := s.storage.Reservation().ReleaseReservation(ctx, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *ReservationService) GetReservation(ctx context.Context, req *pb.GetById) (*pb.Reservation, error) {
+	res, err := s.storage.Reservation().GetReservation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Checkout charges the buyer for a held reservation and confirms it. When any
+// step fails the steps before it are undone and the reservation is released.
+func (s *ReservationService) Checkout(ctx context.Context, req *pb.CheckoutReq) (*pb.CheckoutRes, error) {
+	if req.ReservationId == "" || req.PaymentToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "reservation_id and payment_token are required")
+	}
+
+	res, err := s.checkout.Run(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
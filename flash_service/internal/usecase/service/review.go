@@ -20,7 +20,7 @@ func NewReviewService(storage st.StorageI, kafka kafka.KafkaProducer) *ReviewSer
 }
 
 func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewReq) (*pb.Void, error) {
-	res, err := s.storage.Review().CreateReview(req)
+	res, err := s.storage.Review().CreateReview(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRe
 
 
 func (s *ReviewService) GetProductRating(ctx context.Context, req *pb.GetProductRatingReq) (*pb.ProductRatingRes, error) {
-	res, err := s.storage.Review().GetProductRating(req)
+	res, err := s.storage.Review().GetProductRating(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -20,7 +20,11 @@ func NewSocialService(storage st.StorageI, kafka kafka.KafkaProducer) *SocialSer
 }
 
 func (s *SocialService) ShareDeal(ctx context.Context, req *pb.ShareDealReq) (*pb.Void, error) {
-	res, err := s.storage.Social().ShareDeal(req)
+	var res *pb.Void
+	err := s.storage.WithTx(ctx, func(tx st.StorageI) (err error) {
+		res, err = tx.Social().ShareDeal(ctx, req)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +33,7 @@ func (s *SocialService) ShareDeal(ctx context.Context, req *pb.ShareDealReq) (*p
 }
 
 func (s *SocialService) GetSharingStats(ctx context.Context, req *pb.GetSharingStatsReq) (*pb.SharingStatsRes, error) {
-	res, err := s.storage.Social().GetSharingStats(req)
+	res, err := s.storage.Social().GetSharingStats(ctx, req)
 	if err != nil {
 		return nil, err
 	}
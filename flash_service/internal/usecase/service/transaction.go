@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type TransactionService struct {
+	storage st.StorageI
+	pb.UnimplementedTransactionServiceServer
+}
+
+func NewTransactionService(storage st.StorageI, kafka kafka.KafkaProducer) *TransactionService {
+	return &TransactionService{
+		storage: storage,
+	}
+}
+
+func (s *TransactionService) CreateTransaction(ctx context.Context, req *pb.TransactionCreateReq) (*pb.Void, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Type != "debit" && req.Type != "credit" {
+		return nil, status.Errorf(codes.InvalidArgument, "type must be debit or credit, got %q", req.Type)
+	}
+	if req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+
+	res, err := s.storage.Transaction().CreateTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *TransactionService) GetTransaction(ctx context.Context, req *pb.GetById) (*pb.TransactionGetRes, error) {
+	res, err := s.storage.Transaction().GetTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *TransactionService) ListTransactions(ctx context.Context, req *pb.TransactionListReq) (*pb.TransactionListRes, error) {
+	res, err := s.storage.Transaction().ListTransactions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (s *TransactionService) GetBalance(ctx context.Context, req *pb.GetById) (*pb.BalanceGetRes, error) {
+	res, err := s.storage.Transaction().GetBalance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
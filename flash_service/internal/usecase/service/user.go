@@ -20,7 +20,7 @@ func NewUserService(storage st.StorageI, kafka kafka.KafkaProducer) *UserService
 }
 
 func (s *UserService) GetProfile(ctx context.Context, req *pb.GetByID) (*pb.UserRes, error) {
-	res, err := s.storage.User().GetProfile(req)
+	res, err := s.storage.User().GetProfile(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +29,7 @@ func (s *UserService) GetProfile(ctx context.Context, req *pb.GetByID) (*pb.User
 }
 
 func (s *UserService) EditProfile(ctx context.Context, req *pb.UserRes) (*pb.UserRes, error) {
-	res, err := s.storage.User().EditProfile(req)
+	res, err := s.storage.User().EditProfile(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (s *UserService) EditProfile(ctx context.Context, req *pb.UserRes) (*pb.Use
 }
 
 func (s *UserService) ChangePassword(ctx context.Context, req *pb.ChangePasswordReq) (*pb.Void, error) {
-	res, err := s.storage.User().ChangePassword(req)
+	res, err := s.storage.User().ChangePassword(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func (s *UserService) ChangePassword(ctx context.Context, req *pb.ChangePassword
 }
 
 func (s *UserService) GetSetting(ctx context.Context, req *pb.GetByID) (*pb.Setting, error) {
-	res, err := s.storage.User().GetSetting(req)
+	res, err := s.storage.User().GetSetting(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +56,7 @@ func (s *UserService) GetSetting(ctx context.Context, req *pb.GetByID) (*pb.Sett
 }
 
 func (s *UserService) EditSetting(ctx context.Context, req *pb.SettingReq) (*pb.Void, error) {
-	res, err := s.storage.User().EditSetting(req)
+	res, err := s.storage.User().EditSetting(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func (s *UserService) EditSetting(ctx context.Context, req *pb.SettingReq) (*pb.
 }
 
 func (s *UserService) DeleteUser(ctx context.Context, req *pb.GetByID) (*pb.Void, error) {
-	res, err := s.storage.User().DeleteUser(req)
+	res, err := s.storage.User().DeleteUser(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,460 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/saga"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// world is the state the fakes below share: a single reservation, the queued
+// notifications and the saved sagas. fail makes the named call return an error;
+// crashAt makes saving a saga that reached that step fail, as if the replica
+// went down before it could. hangUp is called on the call named hangUpOn, as
+// if the client gave up on the checkout then.
+type world struct {
+	fail          map[string]error
+	crashAt       int
+	hangUpOn      string
+	hangUp        context.CancelFunc
+	calls         []string
+	reservation   string
+	notifications map[string]bool
+	sagas         map[string]st.Saga
+}
+
+func newWorld() *world {
+	return &world{
+		fail:          make(map[string]error),
+		reservation:   "held",
+		notifications: make(map[string]bool),
+		sagas:         make(map[string]st.Saga),
+	}
+}
+
+func (w *world) call(ctx context.Context, op string) error {
+	w.calls = append(w.calls, op)
+	if op == w.hangUpOn {
+		w.hangUp()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return w.fail[op]
+}
+
+type fakeStorage struct {
+	st.StorageI
+	w *world
+}
+
+func (s fakeStorage) Reservation() st.ReservationI   { return fakeReservations{w: s.w} }
+func (s fakeStorage) Notification() st.NotificationI { return fakeNotifications{w: s.w} }
+func (s fakeStorage) Saga() st.SagaI                 { return fakeSagas{w: s.w} }
+
+type fakeReservations struct {
+	st.ReservationI
+	w *world
+}
+
+func (r fakeReservations) reservation() *pb.Reservation {
+	return &pb.Reservation{Id: "res-1", OrderId: "order-1", UserId: "user-1", Status: r.w.reservation}
+}
+
+func (r fakeReservations) CheckoutAmount(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, float32, error) {
+	if err := r.w.call(ctx, "CheckoutAmount"); err != nil {
+		return nil, 0, err
+	}
+	if r.w.reservation != "held" {
+		return nil, 0, status.Errorf(codes.FailedPrecondition, "reservation is already %s", r.w.reservation)
+	}
+	return r.reservation(), 59.98, nil
+}
+
+func (r fakeReservations) ReleaseReservation(ctx context.Context, req *pb.GetById, now time.Time) (*pb.Reservation, error) {
+	if err := r.w.call(ctx, "ReleaseReservation"); err != nil {
+		return nil, err
+	}
+	switch r.w.reservation {
+	case "held":
+		r.w.reservation = "released"
+	case "released":
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation is already %s", r.w.reservation)
+	}
+	return r.reservation(), nil
+}
+
+func (r fakeReservations) CompleteCheckout(ctx context.Context, req *pb.GetById, p *pb.Payment, now time.Time) (*pb.Reservation, error) {
+	if err := r.w.call(ctx, "CompleteCheckout"); err != nil {
+		return nil, err
+	}
+	if r.w.reservation != "held" {
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation is already %s", r.w.reservation)
+	}
+	r.w.reservation = "confirmed"
+	p.Id = "payment-1"
+	return r.reservation(), nil
+}
+
+func (r fakeReservations) CancelCheckout(ctx context.Context, req *pb.GetById, now time.Time) error {
+	if err := r.w.call(ctx, "CancelCheckout"); err != nil {
+		return err
+	}
+	if r.w.reservation == "confirmed" {
+		r.w.reservation = "released"
+	}
+	return nil
+}
+
+type fakeNotifications struct {
+	st.NotificationI
+	w *world
+}
+
+func (n fakeNotifications) QueueNotification(ctx context.Context, req *pb.NotificationCreate) (string, error) {
+	if err := n.w.call(ctx, "QueueNotification"); err != nil {
+		return "", err
+	}
+	n.w.notifications["notification-1"] = true
+	return "notification-1", nil
+}
+
+func (n fakeNotifications) DropNotification(ctx context.Context, req *pb.GetById) error {
+	if err := n.w.call(ctx, "DropNotification"); err != nil {
+		return err
+	}
+	delete(n.w.notifications, req.Id)
+	return nil
+}
+
+type fakeSagas struct {
+	st.SagaI
+	w *world
+}
+
+func (s fakeSagas) CreateSaga(ctx context.Context, saga *st.Saga) error {
+	saga.ID = "saga-1"
+	s.w.sagas[saga.ID] = *saga
+	return nil
+}
+
+func (s fakeSagas) UpdateSaga(ctx context.Context, saga *st.Saga) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.w.fail["UpdateSaga"]; err != nil {
+		return err
+	}
+	if s.w.crashAt != 0 && saga.Step == s.w.crashAt {
+		return errors.New("replica went down")
+	}
+	s.w.sagas[saga.ID] = *saga
+	return nil
+}
+
+// provider records the calls to the fake provider and fails those asked to.
+type provider struct {
+	*payment.Fake
+	w *world
+}
+
+func (p provider) Authorize(ctx context.Context, req payment.AuthorizeReq) (*payment.Payment, error) {
+	if err := p.w.call(ctx, "Authorize"); err != nil {
+		return nil, err
+	}
+	return p.Fake.Authorize(ctx, req)
+}
+
+func (p provider) Capture(ctx context.Context, id string, amount float32) (*payment.Payment, error) {
+	if err := p.w.call(ctx, "Capture"); err != nil {
+		return nil, err
+	}
+	return p.Fake.Capture(ctx, id, amount)
+}
+
+func (p provider) Refund(ctx context.Context, id string, amount float32) (*payment.Payment, error) {
+	if err := p.w.call(ctx, "Refund"); err != nil {
+		return nil, err
+	}
+	return p.Fake.Refund(ctx, id, amount)
+}
+
+func newCheckout(t *testing.T, w *world) (*saga.Checkout, *payment.Fake) {
+	fake, err := payment.NewFake(payment.Succeed, "secret")
+	if err != nil {
+		t.Fatalf("could not create fake provider: %v", err)
+	}
+	return saga.NewCheckout(fakeStorage{w: w}, provider{Fake: fake, w: w}, 20*time.Millisecond, 5), fake
+}
+
+var checkoutReq = &pb.CheckoutReq{ReservationId: "res-1", PaymentToken: "tok_visa"}
+
+func TestCheckoutSagaCompletes(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+
+	res, err := checkout.Run(context.Background(), checkoutReq)
+	if err != nil {
+		t.Fatalf("error was not expected while checking out: %s", err)
+	}
+	if res.Reservation.Status != "confirmed" || res.Payment.Status != payment.StatusCaptured || res.Payment.Amount != 59.98 {
+		t.Errorf("unexpected result %v", res)
+	}
+
+	want := []string{"CheckoutAmount", "Authorize", "Capture", "CompleteCheckout", "QueueNotification"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record := w.sagas["saga-1"]; record.Status != saga.Completed || record.Step != 4 {
+		t.Errorf("expected completed saga after 4 steps, got %s after %d", record.Status, record.Step)
+	}
+	if !w.notifications["notification-1"] {
+		t.Error("expected a queued notification")
+	}
+}
+
+func TestCheckoutSagaCompensates(t *testing.T) {
+	boom := errors.New("boom")
+
+	tests := []struct {
+		name  string
+		setup func(w *world, fake *payment.Fake)
+		code  codes.Code
+		calls []string
+	}{
+		{
+			name:  "hold fails",
+			setup: func(w *world, fake *payment.Fake) { w.fail["CheckoutAmount"] = boom },
+			code:  codes.Unknown,
+			calls: []string{"CheckoutAmount", "ReleaseReservation"},
+		},
+		{
+			name:  "payment declined",
+			setup: func(w *world, fake *payment.Fake) { fake.SetMode(payment.Decline) },
+			code:  codes.FailedPrecondition,
+			calls: []string{"CheckoutAmount", "Authorize", "ReleaseReservation"},
+		},
+		{
+			name:  "payment times out",
+			setup: func(w *world, fake *payment.Fake) { fake.SetMode(payment.Timeout) },
+			code:  codes.Unavailable,
+			calls: []string{"CheckoutAmount", "Authorize", "ReleaseReservation"},
+		},
+		{
+			name:  "capture fails",
+			setup: func(w *world, fake *payment.Fake) { w.fail["Capture"] = boom },
+			code:  codes.Unavailable,
+			calls: []string{"CheckoutAmount", "Authorize", "Capture", "Refund", "ReleaseReservation"},
+		},
+		{
+			name:  "order fails",
+			setup: func(w *world, fake *payment.Fake) { w.fail["CompleteCheckout"] = boom },
+			code:  codes.Unknown,
+			calls: []string{"CheckoutAmount", "Authorize", "Capture", "CompleteCheckout", "CancelCheckout", "Refund", "ReleaseReservation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newWorld()
+			checkout, fake := newCheckout(t, w)
+			tt.setup(w, fake)
+
+			_, err := checkout.Run(context.Background(), checkoutReq)
+			if err == nil {
+				t.Fatal("expected checkout to fail")
+			}
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %s, got %v", tt.code, err)
+			}
+
+			if !reflect.DeepEqual(w.calls, tt.calls) {
+				t.Errorf("expected calls %v, got %v", tt.calls, w.calls)
+			}
+			if w.reservation != "released" {
+				t.Errorf("expected released reservation, got %s", w.reservation)
+			}
+			if record := w.sagas["saga-1"]; record.Status != saga.Compensated || record.Step != 0 {
+				t.Errorf("expected compensated saga, got %s at step %d", record.Status, record.Step)
+			}
+		})
+	}
+}
+
+func TestCheckoutSagaCompensatesWhenCallerGivesUp(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w.hangUpOn, w.hangUp = "CompleteCheckout", cancel
+
+	if _, err := checkout.Run(ctx, checkoutReq); err == nil {
+		t.Fatal("expected checkout to fail")
+	}
+
+	want := []string{"CheckoutAmount", "Authorize", "Capture", "CompleteCheckout", "CancelCheckout", "Refund", "ReleaseReservation"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record := w.sagas["saga-1"]; record.Status != saga.Compensated || w.reservation != "released" {
+		t.Errorf("expected rolled back checkout, got saga %s and reservation %s", record.Status, w.reservation)
+	}
+}
+
+func TestCheckoutSagaFinishesAfterPivot(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+	w.fail["QueueNotification"] = errors.New("boom")
+
+	// the order is paid and confirmed, a missing notification does not undo it
+	res, err := checkout.Run(context.Background(), checkoutReq)
+	if err != nil {
+		t.Fatalf("error was not expected while checking out: %s", err)
+	}
+	if res.Reservation.Status != "confirmed" || w.reservation != "confirmed" {
+		t.Errorf("expected confirmed reservation, got %v", res.Reservation)
+	}
+	record := w.sagas["saga-1"]
+	if record.Status != saga.Running || record.Step != 4 || record.Error == "" {
+		t.Fatalf("expected saga left running at the notification, got %s at step %d", record.Status, record.Step)
+	}
+
+	delete(w.fail, "QueueNotification")
+	w.calls = nil
+	if err := checkout.Recover(context.Background(), &record); err != nil {
+		t.Fatalf("error was not expected while recovering: %s", err)
+	}
+
+	want := []string{"QueueNotification"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record.Status != saga.Completed || !w.notifications["notification-1"] {
+		t.Errorf("expected completed saga with a notification, got %s", record.Status)
+	}
+}
+
+func TestCheckoutSagaRetriesCompensation(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+	w.fail["CompleteCheckout"] = errors.New("boom")
+	w.fail["Refund"] = errors.New("provider down")
+
+	if _, err := checkout.Run(context.Background(), checkoutReq); err == nil {
+		t.Fatal("expected checkout to fail")
+	}
+	record := w.sagas["saga-1"]
+	if record.Status != saga.Compensating || record.Step != 2 {
+		t.Fatalf("expected saga compensating the payment, got %s at step %d", record.Status, record.Step)
+	}
+
+	delete(w.fail, "Refund")
+	w.calls = nil
+	if err := checkout.Recover(context.Background(), &record); err != nil {
+		t.Fatalf("error was not expected while recovering: %s", err)
+	}
+
+	want := []string{"Refund", "ReleaseReservation"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record := w.sagas["saga-1"]; record.Status != saga.Compensated {
+		t.Errorf("expected compensated saga, got %s", record.Status)
+	}
+}
+
+func TestRecoverCheckoutBeforePivot(t *testing.T) {
+	w := newWorld()
+	checkout, fake := newCheckout(t, w)
+
+	// the replica goes down right after charging the buyer, before the order
+	// step is saved
+	w.crashAt = 3
+	if _, err := checkout.Run(context.Background(), checkoutReq); err == nil {
+		t.Fatal("expected checkout to be cut short")
+	}
+	record := w.sagas["saga-1"]
+	if record.Status != saga.Running || record.Step != 2 {
+		t.Fatalf("expected saga running the payment, got %s at step %d", record.Status, record.Step)
+	}
+
+	w.crashAt = 0
+	w.calls = nil
+	if err := checkout.Recover(context.Background(), &record); err != nil {
+		t.Fatalf("error was not expected while recovering: %s", err)
+	}
+
+	want := []string{"Refund", "ReleaseReservation"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record.Status != saga.Compensated || w.reservation != "released" {
+		t.Errorf("expected rolled back checkout, got saga %s and reservation %s", record.Status, w.reservation)
+	}
+
+	var state struct {
+		PaymentID string `json:"payment_id"`
+	}
+	if err := json.Unmarshal(record.Data, &state); err != nil || state.PaymentID == "" {
+		t.Fatalf("expected the saved saga to know the payment, got %s", record.Data)
+	}
+	if refunded, _ := fake.Refund(context.Background(), state.PaymentID, 0.01); refunded != nil {
+		t.Error("expected the payment to be refunded already")
+	}
+}
+
+func TestRecoverCheckoutAfterPivot(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+
+	// the replica went down while confirming the order
+	record := stuckSaga(t, 3, map[string]interface{}{
+		"reservation_id": "res-1", "order_id": "order-1", "user_id": "user-1", "amount": 59.98, "payment_id": "pay-1",
+		"payment": map[string]interface{}{"provider": "fake", "provider_payment_id": "pay-1", "amount": 59.98, "status": "captured"},
+	})
+
+	if err := checkout.Recover(context.Background(), record); err != nil {
+		t.Fatalf("error was not expected while recovering: %s", err)
+	}
+
+	want := []string{"CompleteCheckout", "QueueNotification"}
+	if !reflect.DeepEqual(w.calls, want) {
+		t.Errorf("expected calls %v, got %v", want, w.calls)
+	}
+	if record.Status != saga.Completed || w.reservation != "confirmed" {
+		t.Errorf("expected finished checkout, got saga %s and reservation %s", record.Status, w.reservation)
+	}
+}
+
+func TestRecoverCheckoutGivesUp(t *testing.T) {
+	w := newWorld()
+	checkout, _ := newCheckout(t, w)
+
+	record := stuckSaga(t, 2, map[string]interface{}{"reservation_id": "res-1"})
+	record.Attempts = 6
+
+	if err := checkout.Recover(context.Background(), record); err != nil {
+		t.Fatalf("error was not expected while recovering: %s", err)
+	}
+	if len(w.calls) != 0 || record.Status != saga.Failed {
+		t.Errorf("expected failed saga without calls, got %s after %v", record.Status, w.calls)
+	}
+}
+
+func stuckSaga(t *testing.T, step int, state map[string]interface{}) *st.Saga {
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("could not marshal saga state: %v", err)
+	}
+	return &st.Saga{ID: "saga-1", Kind: saga.CheckoutKind, Status: saga.Running, Step: step, Data: data, Attempts: 1}
+}
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
+	"github.com/Mubinabd/flash_sale/internal/usecase/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newReservationService(t *testing.T, w *world) (*service.ReservationService, *payment.Fake) {
+	checkout, fake := newCheckout(t, w)
+	return service.NewReservationService(fakeStorage{w: w}, nil, 0, checkout), fake
+}
+
+func TestCheckout(t *testing.T) {
+	w := newWorld()
+	svc, _ := newReservationService(t, w)
+
+	res, err := svc.Checkout(context.Background(), &pb.CheckoutReq{ReservationId: "res-1", PaymentToken: "tok_visa"})
+	if err != nil {
+		t.Fatalf("error was not expected while checking out: %s", err)
+	}
+	if res.Reservation.Id != "res-1" || res.Reservation.Status != "confirmed" {
+		t.Errorf("expected the reservation confirmed, got %v", res.Reservation)
+	}
+	if res.Payment.Status != payment.StatusCaptured || res.Payment.Amount != 59.98 {
+		t.Errorf("expected the reservation paid, got %v", res.Payment)
+	}
+}
+
+func TestCheckoutRequiresReservationAndToken(t *testing.T) {
+	for name, req := range map[string]*pb.CheckoutReq{
+		"no reservation": {PaymentToken: "tok_visa"},
+		"no token":       {ReservationId: "res-1"},
+		"nothing at all": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := newWorld()
+			svc, _ := newReservationService(t, w)
+
+			if _, err := svc.Checkout(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("expected InvalidArgument, got %v", err)
+			}
+			if len(w.calls) != 0 || w.reservation != "held" {
+				t.Errorf("expected nothing to happen, got calls %v and a %s reservation", w.calls, w.reservation)
+			}
+		})
+	}
+}
+
+func TestCheckoutDeclined(t *testing.T) {
+	w := newWorld()
+	svc, fake := newReservationService(t, w)
+	fake.SetMode(payment.Decline)
+
+	_, err := svc.Checkout(context.Background(), &pb.CheckoutReq{ReservationId: "res-1", PaymentToken: "tok_visa"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+	if w.reservation != "released" {
+		t.Errorf("expected the reservation released, got %s", w.reservation)
+	}
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"github.com/Mubinabd/flash_sale/internal/usecase/service"
+)
+
+// commands keeps the last recorded status of every command.
+type commands struct {
+	st.CommandI
+	recorded map[string]*pb.CommandStatus
+}
+
+func (c *commands) RecordCommand(ctx context.Context, cmd *pb.CommandStatus) error {
+	if prev, ok := c.recorded[cmd.Id]; ok {
+		cmd.Attempts = prev.Attempts
+	}
+	cmd.Attempts++
+	c.recorded[cmd.Id] = cmd
+	return nil
+}
+
+type commandStorage struct {
+	st.StorageI
+	commands *commands
+}
+
+func (s commandStorage) Command() st.CommandI { return s.commands }
+
+func TestRecordCommandReplies(t *testing.T) {
+	store := &commands{recorded: make(map[string]*pb.CommandStatus)}
+	replies := &producer{}
+	svc := service.NewCommandService(commandStorage{commands: store}, replies)
+	ctx := context.Background()
+
+	steps := []kafka.CommandResult{
+		{ID: "cmd-1", Topic: "update-order", ReplyTo: "replies.orders", Status: kafka.CommandRetrying, Err: errors.New("connection refused")},
+		{ID: "cmd-1", Topic: "update-order", ReplyTo: "replies.orders", Status: kafka.CommandSucceeded},
+		{ID: "cmd-2", Topic: "update-order", UserID: "user-1", Status: kafka.CommandFailed, Err: errors.New("order not found")},
+		{ID: "cmd-3", Topic: "update-order", ReplyTo: "update-order", Status: kafka.CommandSucceeded},
+	}
+	for _, step := range steps {
+		if err := svc.RecordCommand(ctx, step); err != nil {
+			t.Fatalf("error was not expected while recording %s: %s", step.Status, err)
+		}
+	}
+
+	if cmd := store.recorded["cmd-1"]; cmd.Status != kafka.CommandSucceeded || cmd.Attempts != 2 || cmd.Error != "" {
+		t.Errorf("unexpected status of cmd-1: %+v", cmd)
+	}
+	if cmd := store.recorded["cmd-2"]; cmd.Status != kafka.CommandFailed || cmd.Error != "order not found" || cmd.UserId != "user-1" {
+		t.Errorf("unexpected status of cmd-2: %+v", cmd)
+	}
+	if cmd := store.recorded["cmd-3"]; cmd.Status != kafka.CommandSucceeded {
+		t.Errorf("unexpected status of cmd-3: %+v", cmd)
+	}
+	// only the finished command that asked for a reply on a reply topic gets one
+	if len(replies.published) != 1 {
+		t.Fatalf("expected one reply, got %v", replies.published)
+	}
+	topic, _, _ := strings.Cut(replies.published[0], ":")
+	var reply pb.CommandStatus
+	if err := envelope.Open(replies.envelopes[0], &reply); err != nil {
+		t.Fatalf("error was not expected while reading the reply: %s", err)
+	}
+	if replies.envelopes[0].CorrelationId != "cmd-1" {
+		t.Errorf("expected the reply to carry the command ID, got %q", replies.envelopes[0].CorrelationId)
+	}
+	if topic != "replies.orders" || reply.Id != "cmd-1" || reply.Status != kafka.CommandSucceeded || reply.Attempts != 2 {
+		t.Errorf("unexpected reply on %s: %+v", topic, &reply)
+	}
+}
@@ -0,0 +1,128 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// brokenBroker hands out subscriptions that fail every fetch with fetchErr and
+// fail to close with the error closeErrs holds for their topic.
+type brokenBroker struct {
+	kafka.Broker
+	fetchErr  error
+	closeErrs map[string]error
+
+	mu      sync.Mutex
+	fetches int
+	closed  []string
+}
+
+func (b *brokenBroker) Subscribe(topic, groupID string) kafka.Subscription {
+	return &brokenSubscription{b: b, topic: topic}
+}
+
+func (b *brokenBroker) fetched() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fetches
+}
+
+type brokenSubscription struct {
+	kafka.Subscription
+	b     *brokenBroker
+	topic string
+}
+
+func (s *brokenSubscription) FetchMessage(ctx context.Context) (kafkago.Message, error) {
+	s.b.mu.Lock()
+	s.b.fetches++
+	s.b.mu.Unlock()
+	if err := ctx.Err(); err != nil {
+		return kafkago.Message{}, err
+	}
+	return kafkago.Message{}, s.b.fetchErr
+}
+
+func (s *brokenSubscription) Close() error {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.b.closed = append(s.b.closed, s.topic)
+	return s.b.closeErrs[s.topic]
+}
+
+func ignore(ctx context.Context, env *pb.Envelope) error { return nil }
+
+func TestConsumerBacksOffOnFetchErrors(t *testing.T) {
+	broker := &brokenBroker{fetchErr: errors.New("connection refused")}
+	kcm := kafka.NewKafkaConsumerManager(broker, nil)
+	policy := kafka.RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Minute}
+	if err := kcm.RegisterConsumer("update-order", "update-order-id", ignore, policy); err != nil {
+		t.Fatalf("error was not expected while registering the consumer: %s", err)
+	}
+
+	// fetches after 0, 100ms and 300ms, the next one is not due before 700ms
+	time.Sleep(500 * time.Millisecond)
+	if fetches := broker.fetched(); fetches == 0 || fetches > 3 {
+		t.Errorf("expected at most 3 fetches in 500ms, got %d", fetches)
+	}
+	if err := kcm.Shutdown(context.Background()); err != nil {
+		t.Errorf("error was not expected while shutting down: %s", err)
+	}
+}
+
+func TestConsumerBacksOffWithoutPolicy(t *testing.T) {
+	broker := &brokenBroker{fetchErr: errors.New("connection refused")}
+	kcm := kafka.NewKafkaConsumerManager(broker, nil)
+	if err := kcm.RegisterConsumer("update-order", "update-order-id", ignore, kafka.RetryPolicy{}); err != nil {
+		t.Fatalf("error was not expected while registering the consumer: %s", err)
+	}
+	defer kcm.Shutdown(context.Background())
+
+	time.Sleep(250 * time.Millisecond)
+	if fetches := broker.fetched(); fetches > 3 {
+		t.Errorf("expected the consumer not to spin, got %d fetches in 250ms", fetches)
+	}
+}
+
+func TestCloseClosesEverySubscription(t *testing.T) {
+	closeOrders := errors.New("close update-order")
+	closeProducts := errors.New("close update-product")
+	broker := &brokenBroker{
+		fetchErr: errors.New("connection refused"),
+		closeErrs: map[string]error{
+			"update-order":   closeOrders,
+			"update-product": closeProducts,
+		},
+	}
+	kcm := kafka.NewKafkaConsumerManager(broker, nil)
+	policy := kafka.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	for _, topic := range []string{"create-product", "update-order", "update-product"} {
+		if err := kcm.RegisterConsumer(topic, topic+"-id", ignore, policy); err != nil {
+			t.Fatalf("error was not expected while registering the consumer of %s: %s", topic, err)
+		}
+	}
+
+	// let the consumers fail their first fetch, the backoff does not hold up
+	// the shutdown
+	time.Sleep(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	err := kcm.Shutdown(ctx)
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("expected the consumers to stop right away, took %s", took)
+	}
+	if !errors.Is(err, closeOrders) || !errors.Is(err, closeProducts) {
+		t.Errorf("expected the errors of both subscriptions, got %v", err)
+	}
+	if len(broker.closed) != 3 {
+		t.Errorf("expected every subscription to be closed, got %v", broker.closed)
+	}
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := kafka.RetryPolicy{MaxAttempts: 5, InitialBackoff: 200 * time.Millisecond, MaxBackoff: time.Second}
+
+	want := []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, wait := range want {
+		if got := policy.Backoff(i + 1); got != wait {
+			t.Errorf("attempt %d: expected %s, got %s", i+1, wait, got)
+		}
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	cause := errors.New("unexpected token")
+	err := fmt.Errorf("update-order: %w", kafka.Permanent(cause))
+
+	if !kafka.IsPermanent(err) {
+		t.Errorf("expected a wrapped permanent error to stay permanent")
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the cause to be kept")
+	}
+	if kafka.IsPermanent(cause) || kafka.Permanent(nil) != nil {
+		t.Errorf("expected only marked errors to be permanent")
+	}
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	msg := kafkago.Message{
+		Topic:     "update-order",
+		Partition: 2,
+		Offset:    41,
+		Key:       []byte("order-1"),
+		Value:     []byte(`{"id":"order-1"`),
+		Headers:   []kafkago.Header{{Key: "trace-id", Value: []byte("abc")}},
+	}
+	failedAt := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	dead := kafka.DeadLetter(msg, errors.New("unexpected EOF"), 1, failedAt)
+	headers := map[string]string{}
+	for _, h := range dead.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	want := map[string]string{
+		"trace-id":                    "abc",
+		kafka.HeaderOriginalTopic:     "update-order",
+		kafka.HeaderOriginalPartition: "2",
+		kafka.HeaderOriginalOffset:    "41",
+		kafka.HeaderError:             "unexpected EOF",
+		kafka.HeaderAttempts:          "1",
+		kafka.HeaderFailedAt:          "2024-08-01T12:00:00Z",
+	}
+	for key, value := range want {
+		if headers[key] != value {
+			t.Errorf("header %s: expected %q, got %q", key, value, headers[key])
+		}
+	}
+	if dead.Topic != "" {
+		t.Errorf("expected the topic to be left to the dead-letter writer, got %s", dead.Topic)
+	}
+
+	replayed, err := kafka.Replay(dead)
+	if err != nil {
+		t.Fatalf("error was not expected while replaying: %s", err)
+	}
+	if replayed.Topic != "update-order" || string(replayed.Key) != "order-1" || string(replayed.Value) != string(msg.Value) {
+		t.Errorf("expected the original message back, got %+v", replayed)
+	}
+	if len(replayed.Headers) != 1 || replayed.Headers[0].Key != "trace-id" {
+		t.Errorf("expected only the original headers, got %v", replayed.Headers)
+	}
+
+	if _, err := kafka.Replay(kafkago.Message{Value: msg.Value}); err == nil {
+		t.Errorf("expected a message without origin to be rejected")
+	}
+}
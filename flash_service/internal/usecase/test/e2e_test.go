@@ -0,0 +1,259 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	segkafka "github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/Mubinabd/flash_sale/internal/app"
+	"github.com/Mubinabd/flash_sale/internal/pkg/config"
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/Mubinabd/flash_sale/internal/storage/repository"
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+	"github.com/Mubinabd/flash_sale/internal/usecase/service"
+)
+
+var e2eCommandColumns = []string{"id", "topic", "status", "error", "attempts", "created_at", "updated_at", "user_id"}
+
+// flashService runs the consumers of flash_service on an in-memory broker
+// and a mocked database, the way app.Run wires them.
+type flashService struct {
+	broker  *kafka.MemoryBroker
+	mock    sqlmock.Sqlmock
+	replies kafka.Subscription
+}
+
+func newFlashService(t *testing.T) *flashService {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("could not mock db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	broker := kafka.NewMemoryBroker()
+	producer := kafka.NewProducer(broker)
+	storage := repository.NewStorage(db)
+	commands := service.NewCommandService(storage, producer)
+
+	kcm, err := app.Register(app.NewKafkaHandler(storage, producer), broker, commands, &config.Config{
+		KafkaMaxAttempts:  1,
+		KafkaRetryBackoff: time.Millisecond,
+		KafkaMaxBackoff:   time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("error was not expected while registering consumers: %s", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := kcm.Shutdown(ctx); err != nil {
+			t.Errorf("error was not expected while shutting down: %s", err)
+		}
+		producer.Close()
+	})
+
+	return &flashService{
+		broker:  broker,
+		mock:    mock,
+		replies: broker.Subscribe("replies.gateway", "gateway"),
+	}
+}
+
+// gatewayCommand is a command as the gateway's producer sent it. They are
+// recorded in testdata/gateway by the producer tests of the gateway.
+type gatewayCommand struct {
+	Topic string          `json:"topic"`
+	Value json.RawMessage `json:"value"`
+}
+
+// command loads the command recorded as name and decodes its payload into
+// payload.
+func command(t *testing.T, name string, payload proto.Message) *gatewayCommand {
+	data, err := os.ReadFile(filepath.Join("testdata", "gateway", name+".json"))
+	if err != nil {
+		t.Fatalf("error was not expected while loading command %s: %s", name, err)
+	}
+	var cmd gatewayCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		t.Fatalf("error was not expected while loading command %s: %s", name, err)
+	}
+	env, err := envelope.Unmarshal(cmd.Value)
+	if err != nil {
+		t.Fatalf("error was not expected while reading command %s: %s", name, err)
+	}
+	if err := envelope.Open(env, payload); err != nil {
+		t.Fatalf("error was not expected while reading command %s: %s", name, err)
+	}
+	return &cmd
+}
+
+// send publishes cmd the way the gateway did, and returns its ID.
+func (s *flashService) send(t *testing.T, cmd *gatewayCommand) string {
+	if err := s.broker.Publish(context.Background(), segkafka.Message{Topic: cmd.Topic, Value: cmd.Value}); err != nil {
+		t.Fatalf("error was not expected while publishing to %s: %s", cmd.Topic, err)
+	}
+	env, err := envelope.Unmarshal(cmd.Value)
+	if err != nil {
+		t.Fatalf("error was not expected while reading the command: %s", err)
+	}
+	return env.CorrelationId
+}
+
+// reply waits for the outcome of the next command.
+func (s *flashService) reply(t *testing.T) *pb.CommandStatus {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msg, err := s.replies.FetchMessage(ctx)
+	if err != nil {
+		t.Fatalf("no reply arrived: %s", err)
+	}
+	if err := s.replies.CommitMessages(ctx, msg); err != nil {
+		t.Fatalf("error was not expected while committing the reply: %s", err)
+	}
+
+	env, err := envelope.Unmarshal(msg.Value)
+	if err != nil {
+		t.Fatalf("error was not expected while reading the reply: %s", err)
+	}
+	var cmd pb.CommandStatus
+	if err := envelope.Open(env, &cmd); err != nil {
+		t.Fatalf("error was not expected while reading the reply: %s", err)
+	}
+	return &cmd
+}
+
+// expectHandled expects the message to be marked processed in the
+// transaction of its handler, whose queries run in between.
+func (s *flashService) expectHandled(handler func()) {
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("INSERT INTO(.+)processed_messages").
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	handler()
+}
+
+// expectCommand expects the outcome of a command issuedBy sent to be
+// recorded; issuedBy is nil for registrations, which nobody sends.
+func (s *flashService) expectCommand(topic, status string, cause, issuedBy interface{}) {
+	s.mock.ExpectQuery("INSERT INTO(.+)commands").
+		WithArgs(sqlmock.AnyArg(), topic, status, cause, issuedBy).
+		WillReturnRows(sqlmock.NewRows(e2eCommandColumns).
+			AddRow("cmd", topic, status, cause, 1, "2024-08-01T12:00:00Z", "2024-08-01T12:00:00Z", issuedBy))
+}
+
+func TestEndToEndRegister(t *testing.T) {
+	s := newFlashService(t)
+	req := &pb.RegisterReq{}
+	register := command(t, "register", req)
+
+	s.expectHandled(func() {
+		s.mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectQuery("INSERT INTO users").
+			WithArgs(req.Username, req.Email, req.Password, req.FullName, req.DateOfBirth).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-1"))
+		s.mock.ExpectExec("INSERT INTO settings").WithArgs("user-1").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	s.mock.ExpectCommit()
+	s.expectCommand("create", kafka.CommandSucceeded, nil, nil)
+
+	id := s.send(t, register)
+	if cmd := s.reply(t); cmd.Id != id || cmd.Status != kafka.CommandSucceeded {
+		t.Errorf("expected command %s to succeed, got %+v", id, cmd)
+	}
+
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEndToEndCreateProduct(t *testing.T) {
+	s := newFlashService(t)
+	req := &pb.CreateProductReq{}
+	create := command(t, "create-product", req)
+
+	s.expectHandled(func() {
+		s.mock.ExpectExec("INSERT INTO(.+)products").
+			WithArgs(sqlmock.AnyArg(), req.Name, req.Description, req.Price, req.ImageUrl, req.StockQuantity).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	})
+	s.mock.ExpectCommit()
+	s.expectCommand("create-product", kafka.CommandSucceeded, nil, "admin-1")
+
+	id := s.send(t, create)
+	if cmd := s.reply(t); cmd.Id != id || cmd.Status != kafka.CommandSucceeded {
+		t.Errorf("expected command %s to succeed, got %+v", id, cmd)
+	}
+
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEndToEndUpdateOrder(t *testing.T) {
+	s := newFlashService(t)
+	ship := command(t, "ship-order", &pb.UpdateOrderReq{})
+	reopen := command(t, "reopen-order", &pb.UpdateOrderReq{})
+
+	// a confirmed order ships
+	s.expectHandled(func() {
+		s.mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("confirmed"))
+		s.mock.ExpectExec("UPDATE orders SET").
+			WithArgs("shipped", sqlmock.AnyArg(), "order-1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		s.mock.ExpectExec("INSERT INTO order_status_tracking").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		s.mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	s.mock.ExpectCommit()
+	s.expectCommand("update-order", kafka.CommandSucceeded, nil, "admin-1")
+
+	id := s.send(t, ship)
+	if cmd := s.reply(t); cmd.Id != id || cmd.Status != kafka.CommandSucceeded {
+		t.Errorf("expected command %s to succeed, got %+v", id, cmd)
+	}
+
+	// a shipped order cannot go back to pending, the message is dead-lettered
+	s.expectHandled(func() {
+		s.mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+		s.mock.ExpectQuery("SELECT status FROM orders (.+) FOR UPDATE").WithArgs("order-1").
+			WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("shipped"))
+		s.mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	s.mock.ExpectRollback()
+	s.expectCommand("update-order", kafka.CommandFailed, "rpc error: code = FailedPrecondition desc = order cannot move from shipped to pending", "admin-1")
+
+	id = s.send(t, reopen)
+	if cmd := s.reply(t); cmd.Id != id || cmd.Status != kafka.CommandFailed {
+		t.Errorf("expected command %s to fail, got %+v", id, cmd)
+	}
+
+	dead := s.broker.Subscribe(kafka.DeadLetterTopic("update-order"), "replay")
+	defer dead.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msg, err := dead.FetchMessage(ctx)
+	if err != nil {
+		t.Fatalf("expected the message on the dead-letter topic: %s", err)
+	}
+	replayed, err := kafka.Replay(msg)
+	if err != nil || replayed.Topic != "update-order" {
+		t.Errorf("expected the dead letter to replay to update-order, got %q: %v", replayed.Topic, err)
+	}
+
+	if err := s.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,40 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+)
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	env, err := envelope.New(&pb.UpdateOrderReq{Id: "order-1", IdempotencyKey: "key-1"}, 2)
+	if err != nil {
+		t.Fatalf("error was not expected while wrapping: %s", err)
+	}
+	data, err := envelope.Marshal(env)
+	if err != nil {
+		t.Fatalf("error was not expected while marshaling: %s", err)
+	}
+
+	got, err := envelope.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("error was not expected while unmarshaling: %s", err)
+	}
+	if got.Type != "proto.UpdateOrderReq" || got.Version != 2 || got.Producer != envelope.Producer || got.Id == "" || got.ProducedAt == "" {
+		t.Errorf("unexpected envelope %+v", got)
+	}
+
+	var req pb.UpdateOrderReq
+	if err := envelope.Open(got, &req); err != nil || req.Id != "order-1" || req.IdempotencyKey != "key-1" {
+		t.Errorf("expected the payload back, got %v: %v", &req, err)
+	}
+	// a payload is only ever decoded as the type it was sent as
+	if err := envelope.Open(got, &pb.UpdateProductReq{}); err == nil {
+		t.Errorf("expected a payload of another type to be rejected")
+	}
+	// raw payloads from before the envelope are not mistaken for one
+	if _, err := envelope.Unmarshal([]byte(`{"id":"order-1","idempotencyKey":"key-1"}`)); err == nil {
+		t.Errorf("expected a message without envelope to be rejected")
+	}
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	segkafka "github.com/segmentio/kafka-go"
+
+	"github.com/Mubinabd/flash_sale/internal/usecase/kafka"
+)
+
+func TestMemoryBrokerGroups(t *testing.T) {
+	broker := kafka.NewMemoryBroker()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := broker.Publish(ctx,
+		segkafka.Message{Topic: "create-product", Value: []byte("a")},
+		segkafka.Message{Topic: "create-product", Value: []byte("b")},
+	)
+	if err != nil {
+		t.Fatalf("error was not expected while publishing: %s", err)
+	}
+
+	fetch := func(sub kafka.Subscription) segkafka.Message {
+		msg, err := sub.FetchMessage(ctx)
+		if err != nil {
+			t.Fatalf("error was not expected while fetching: %s", err)
+		}
+		return msg
+	}
+
+	// a only gets committed, so the group is handed b again after a restart
+	sub := broker.Subscribe("create-product", "create-product-id")
+	if msg := fetch(sub); string(msg.Value) != "a" || msg.Offset != 0 {
+		t.Fatalf("expected a at offset 0, got %q at %d", msg.Value, msg.Offset)
+	}
+	if err := sub.CommitMessages(ctx, segkafka.Message{Offset: 0}); err != nil {
+		t.Fatalf("error was not expected while committing: %s", err)
+	}
+	if msg := fetch(sub); string(msg.Value) != "b" {
+		t.Fatalf("expected b, got %q", msg.Value)
+	}
+	sub.Close()
+
+	sub = broker.Subscribe("create-product", "create-product-id")
+	defer sub.Close()
+	if msg := fetch(sub); string(msg.Value) != "b" || msg.Offset != 1 {
+		t.Errorf("expected b to be redelivered, got %q at %d", msg.Value, msg.Offset)
+	}
+
+	// another group reads the topic from the start
+	other := broker.Subscribe("create-product", "audit")
+	if msg := fetch(other); string(msg.Value) != "a" {
+		t.Errorf("expected a new group to start at a, got %q", msg.Value)
+	}
+
+	// a blocked fetch ends once its subscription closes
+	done := make(chan error, 1)
+	go func() {
+		closing := broker.Subscribe("update-product", "update-product-id")
+		go closing.Close()
+		_, err := closing.FetchMessage(ctx)
+		done <- err
+	}()
+	if err := <-done; !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF after close, got %v", err)
+	}
+}
@@ -0,0 +1,214 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/envelope"
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/scheduler"
+	"google.golang.org/protobuf/proto"
+)
+
+// outbox keeps events in memory the way the outbox table does: claiming hides
+// an event until its lease runs out.
+type outbox struct {
+	st.OutboxI
+	events    []*st.OutboxEvent
+	available map[int64]time.Time
+	sent      map[int64]bool
+	failed    map[int64]string
+}
+
+func newOutbox(events ...*st.OutboxEvent) *outbox {
+	return &outbox{
+		events:    events,
+		available: make(map[int64]time.Time),
+		sent:      make(map[int64]bool),
+		failed:    make(map[int64]string),
+	}
+}
+
+func (o *outbox) ClaimEvents(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*st.OutboxEvent, error) {
+	var claimed []*st.OutboxEvent
+	waiting := make(map[string]bool)
+	for _, event := range o.events {
+		if o.sent[event.ID] {
+			continue
+		}
+		if o.available[event.ID].After(now) || waiting[event.AggregateID] || len(claimed) == limit {
+			// later events of the aggregate wait for this one
+			waiting[event.AggregateID] = true
+			continue
+		}
+		event.Attempts++
+		o.available[event.ID] = now.Add(lease)
+		claimed = append(claimed, event)
+	}
+	return claimed, nil
+}
+
+func (o *outbox) MarkSent(ctx context.Context, id int64) error {
+	o.sent[id] = true
+	return nil
+}
+
+func (o *outbox) MarkFailed(ctx context.Context, id int64, retryAt time.Time, cause string) error {
+	o.available[id] = retryAt
+	o.failed[id] = cause
+	return nil
+}
+
+type outboxStorage struct {
+	st.StorageI
+	outbox *outbox
+}
+
+func (s outboxStorage) Outbox() st.OutboxI { return s.outbox }
+
+// producer records the topic, key and envelope ID of what was published and
+// fails topics listed in down.
+type producer struct {
+	published []string
+	keys      []string
+	envelopes []*pb.Envelope
+	down      map[string]bool
+}
+
+func (p *producer) ProduceMessages(topic string, payload proto.Message, version int32) error {
+	env, err := envelope.New(payload, version)
+	if err != nil {
+		return err
+	}
+	return p.ProduceEnvelope(topic, "", env)
+}
+
+func (p *producer) ProduceEnvelope(topic, key string, env *pb.Envelope) error {
+	if p.down[topic] {
+		return errors.New("kafka: leader not available")
+	}
+	p.published = append(p.published, topic+":"+env.Id)
+	p.keys = append(p.keys, key)
+	p.envelopes = append(p.envelopes, env)
+	return nil
+}
+
+func (p *producer) Close() error { return nil }
+
+// stored is an outbox payload: the event in its envelope.
+func stored(t *testing.T, id string, event proto.Message) []byte {
+	env, err := envelope.New(event, 1)
+	if err != nil {
+		t.Fatalf("could not wrap event: %v", err)
+	}
+	env.Id = id
+	payload, err := envelope.Marshal(env)
+	if err != nil {
+		t.Fatalf("could not marshal envelope: %v", err)
+	}
+	return payload
+}
+
+func TestOutboxRelayPublishes(t *testing.T) {
+	box := newOutbox(
+		&st.OutboxEvent{ID: 1, Topic: st.OrderCreatedTopic, AggregateID: "order-1", Payload: stored(t, "event-1", &pb.OrderCreated{OrderId: "order-1"})},
+		&st.OutboxEvent{ID: 2, Topic: st.OrderCanceledTopic, AggregateID: "order-1", Payload: stored(t, "event-2", &pb.OrderCanceled{OrderId: "order-1"})},
+	)
+	kafka := &producer{}
+	relay := scheduler.NewOutboxRelay(outboxStorage{outbox: box}, kafka, time.Second, time.Second, time.Minute)
+
+	if n := relay.Relay(context.Background()); n != 2 {
+		t.Fatalf("expected 2 claimed events, got %d", n)
+	}
+	want := []string{"order-created:event-1", "order-canceled:event-2"}
+	if len(kafka.published) != 2 || kafka.published[0] != want[0] || kafka.published[1] != want[1] {
+		t.Errorf("expected %v published, got %v", want, kafka.published)
+	}
+	if !box.sent[1] || !box.sent[2] {
+		t.Errorf("expected both events marked sent, got %v", box.sent)
+	}
+	if kafka.keys[0] != "order-1" || kafka.keys[1] != "order-1" {
+		t.Errorf("expected the events keyed by their order, got %v", kafka.keys)
+	}
+	var created pb.OrderCreated
+	if err := envelope.Open(kafka.envelopes[0], &created); err != nil || created.OrderId != "order-1" {
+		t.Errorf("expected the stored event to be published, got %v: %v", &created, err)
+	}
+
+	// sent events are not published again
+	if n := relay.Relay(context.Background()); n != 0 {
+		t.Errorf("expected nothing left to relay, got %d", n)
+	}
+}
+
+func TestOutboxRelayRetriesWithBackoff(t *testing.T) {
+	box := newOutbox(
+		&st.OutboxEvent{ID: 1, Topic: st.StockDepletedTopic, AggregateID: "product-1", Payload: stored(t, "event-1", &pb.StockDepleted{})},
+		&st.OutboxEvent{ID: 2, Topic: st.ReviewPostedTopic, AggregateID: "product-2", Payload: stored(t, "event-2", &pb.ReviewPosted{})},
+	)
+	kafka := &producer{down: map[string]bool{st.StockDepletedTopic: true}}
+	relay := scheduler.NewOutboxRelay(outboxStorage{outbox: box}, kafka, time.Second, time.Second, 3*time.Second)
+
+	for attempt, wait := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		// make the failed event due again
+		box.available[1] = time.Time{}
+
+		before := time.Now()
+		relay.Relay(context.Background())
+		if box.sent[1] {
+			t.Fatalf("attempt %d: event published while kafka was down", attempt+1)
+		}
+		if box.failed[1] == "" {
+			t.Errorf("attempt %d: expected the error to be recorded", attempt+1)
+		}
+		if retryAt := box.available[1]; retryAt.Before(before.Add(wait)) || retryAt.After(time.Now().Add(wait)) {
+			t.Errorf("attempt %d: expected a retry in %s, got %s", attempt+1, wait, retryAt.Sub(before))
+		}
+	}
+	if !box.sent[2] {
+		t.Errorf("expected the event of another aggregate on a healthy topic to be sent")
+	}
+
+	kafka.down = nil
+	box.available[1] = time.Time{}
+	relay.Relay(context.Background())
+	if !box.sent[1] {
+		t.Errorf("expected the event to be sent once kafka is back")
+	}
+}
+
+func TestOutboxRelayKeepsAggregateOrder(t *testing.T) {
+	box := newOutbox(
+		&st.OutboxEvent{ID: 1, Topic: st.OrderCreatedTopic, AggregateID: "order-1", Payload: stored(t, "event-1", &pb.OrderCreated{OrderId: "order-1"})},
+		&st.OutboxEvent{ID: 2, Topic: st.OrderCanceledTopic, AggregateID: "order-1", Payload: stored(t, "event-2", &pb.OrderCanceled{OrderId: "order-1"})},
+		&st.OutboxEvent{ID: 3, Topic: st.OrderCanceledTopic, AggregateID: "order-2", Payload: stored(t, "event-3", &pb.OrderCanceled{OrderId: "order-2"})},
+	)
+	kafka := &producer{down: map[string]bool{st.OrderCreatedTopic: true}}
+	relay := scheduler.NewOutboxRelay(outboxStorage{outbox: box}, kafka, time.Second, time.Second, time.Minute)
+
+	relay.Relay(context.Background())
+	if len(kafka.published) != 1 || kafka.published[0] != "order-canceled:event-3" {
+		t.Fatalf("expected only the event of order-2 published, got %v", kafka.published)
+	}
+	if box.sent[2] || box.available[2] != box.available[1] {
+		t.Errorf("expected the cancel of order-1 to wait for its creation, available at %s and %s", box.available[2], box.available[1])
+	}
+
+	// nothing of order-1 goes out before the failed event is due again
+	relay.Relay(context.Background())
+	if len(kafka.published) != 1 {
+		t.Fatalf("expected order-1 to be held back, got %v", kafka.published)
+	}
+
+	kafka.down = nil
+	box.available[1] = time.Time{}
+	box.available[2] = time.Time{}
+	relay.Relay(context.Background())
+	want := []string{"order-canceled:event-3", "order-created:event-1", "order-canceled:event-2"}
+	if len(kafka.published) != 3 || kafka.published[1] != want[1] || kafka.published[2] != want[2] {
+		t.Errorf("expected %v published, got %v", want, kafka.published)
+	}
+}
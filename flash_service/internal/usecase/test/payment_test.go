@@ -0,0 +1,164 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mubinabd/flash_sale/internal/pkg/payment"
+)
+
+func newFake(t *testing.T) *payment.Fake {
+	fake, err := payment.NewFake(payment.Succeed, "secret")
+	if err != nil {
+		t.Fatalf("could not create fake provider: %v", err)
+	}
+	return fake
+}
+
+func authorize(t *testing.T, fake *payment.Fake, key string, amount float32) *payment.Payment {
+	p, err := fake.Authorize(context.Background(), payment.AuthorizeReq{IdempotencyKey: key, OrderID: "order-1", UserID: "user-1", Amount: amount, Token: "tok_visa"})
+	if err != nil {
+		t.Fatalf("error was not expected while authorizing: %s", err)
+	}
+	return p
+}
+
+func TestNewPaymentProvider(t *testing.T) {
+	provider, err := payment.New("fake", payment.Succeed, "secret")
+	if err != nil || provider.Name() != "fake" {
+		t.Errorf("expected the fake provider, got %v: %v", provider, err)
+	}
+	if _, err := payment.New("stripe", payment.Succeed, "secret"); err == nil {
+		t.Error("expected an unknown provider to be refused")
+	}
+	if _, err := payment.NewFake("sometimes", "secret"); err == nil {
+		t.Error("expected an unknown mode to be refused")
+	}
+}
+
+func TestFakeAuthorize(t *testing.T) {
+	fake := newFake(t)
+
+	p := authorize(t, fake, "checkout:res-1", 59.98)
+	if p.ID == "" || p.Status != payment.StatusAuthorized || p.Amount != 59.98 {
+		t.Errorf("unexpected payment %+v", p)
+	}
+	// a retry gets the first authorization back
+	if again := authorize(t, fake, "checkout:res-1", 59.98); again.ID != p.ID {
+		t.Errorf("expected the retry to return payment %s, got %s", p.ID, again.ID)
+	}
+	if other := authorize(t, fake, "checkout:res-2", 59.98); other.ID == p.ID {
+		t.Error("expected another key to authorize another payment")
+	}
+
+	_, err := fake.Authorize(context.Background(), payment.AuthorizeReq{Amount: 0})
+	if !errors.Is(err, payment.ErrDeclined) {
+		t.Errorf("expected a payment of nothing to be declined, got %v", err)
+	}
+}
+
+func TestFakeAuthorizeModes(t *testing.T) {
+	fake := newFake(t)
+
+	fake.SetMode(payment.Decline)
+	if _, err := fake.Authorize(context.Background(), payment.AuthorizeReq{Amount: 10}); !errors.Is(err, payment.ErrDeclined) {
+		t.Errorf("expected ErrDeclined, got %v", err)
+	}
+
+	fake.SetMode(payment.Timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := fake.Authorize(ctx, payment.AuthorizeReq{Amount: 10}); !errors.Is(err, payment.ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+
+	fake.SetMode(payment.Succeed)
+	authorize(t, fake, "", 10)
+}
+
+func TestFakeCapture(t *testing.T) {
+	fake := newFake(t)
+	ctx := context.Background()
+	p := authorize(t, fake, "", 59.98)
+
+	if _, err := fake.Capture(ctx, p.ID, 60); err == nil {
+		t.Error("expected a capture above the authorization to fail")
+	}
+	captured, err := fake.Capture(ctx, p.ID, 59.98)
+	if err != nil || captured.Status != payment.StatusCaptured || captured.Captured != 59.98 {
+		t.Fatalf("expected the payment captured, got %+v: %v", captured, err)
+	}
+	// a retried capture is answered with the first one
+	if again, err := fake.Capture(ctx, p.ID, 59.98); err != nil || again.Captured != 59.98 {
+		t.Errorf("expected the retry to succeed, got %+v: %v", again, err)
+	}
+	if _, err := fake.Capture(ctx, p.ID, 10); err == nil {
+		t.Error("expected another capture of a captured payment to fail")
+	}
+	if _, err := fake.Capture(ctx, "missing", 10); err == nil {
+		t.Error("expected the capture of an unknown payment to fail")
+	}
+}
+
+func TestFakeRefund(t *testing.T) {
+	fake := newFake(t)
+	ctx := context.Background()
+
+	// an authorization that was never captured is voided
+	voided := authorize(t, fake, "", 20)
+	if p, err := fake.Refund(ctx, voided.ID, 20); err != nil || p.Status != payment.StatusRefunded || p.Refunded != 0 {
+		t.Errorf("expected the authorization voided, got %+v: %v", p, err)
+	}
+	if _, err := fake.Refund(ctx, voided.ID, 20); err == nil {
+		t.Error("expected a refunded payment not to be refunded again")
+	}
+
+	p := authorize(t, fake, "", 50)
+	if _, err := fake.Capture(ctx, p.ID, 50); err != nil {
+		t.Fatalf("error was not expected while capturing: %s", err)
+	}
+	if res, err := fake.Refund(ctx, p.ID, 20); err != nil || res.Status != payment.StatusCaptured || res.Refunded != 20 {
+		t.Errorf("expected a partial refund, got %+v: %v", res, err)
+	}
+	if _, err := fake.Refund(ctx, p.ID, 40); err == nil {
+		t.Error("expected a refund above what is left to fail")
+	}
+	if res, err := fake.Refund(ctx, p.ID, 30); err != nil || res.Status != payment.StatusRefunded || res.Refunded != 50 {
+		t.Errorf("expected the payment refunded in full, got %+v: %v", res, err)
+	}
+}
+
+func TestFakeVerifyWebhook(t *testing.T) {
+	fake := newFake(t)
+	payload := []byte(`{"type":"payment.updated","payment_id":"payment-1","status":"captured","amount":59.98}`)
+
+	event, err := fake.VerifyWebhook(payload, fake.Sign(payload))
+	if err != nil {
+		t.Fatalf("error was not expected while verifying the webhook: %s", err)
+	}
+	if event.PaymentID != "payment-1" || event.Status != payment.StatusCaptured || event.Amount != 59.98 {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	other, err := payment.NewFake(payment.Succeed, "another secret")
+	if err != nil {
+		t.Fatalf("could not create fake provider: %v", err)
+	}
+	for name, signature := range map[string]string{
+		"missing":            "",
+		"not hex":            "signature",
+		"of another secret":  other.Sign(payload),
+		"of another payload": fake.Sign([]byte(`{"status":"refunded"}`)),
+	} {
+		if _, err := fake.VerifyWebhook(payload, signature); !errors.Is(err, payment.ErrInvalidSignature) {
+			t.Errorf("signature %s: expected ErrInvalidSignature, got %v", name, err)
+		}
+	}
+
+	garbage := []byte("not json")
+	if _, err := fake.VerifyWebhook(garbage, fake.Sign(garbage)); err == nil || errors.Is(err, payment.ErrInvalidSignature) {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
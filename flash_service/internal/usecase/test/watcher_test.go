@@ -0,0 +1,224 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	st "github.com/Mubinabd/flash_sale/internal/storage"
+	"github.com/Mubinabd/flash_sale/internal/usecase/service"
+	"github.com/Mubinabd/flash_sale/internal/usecase/watcher"
+	"google.golang.org/grpc"
+)
+
+func stock(flashSaleID string, available int32) *pb.FlashSaleUpdate {
+	return &pb.FlashSaleUpdate{Type: "stock", FlashSaleId: flashSaleID, AvailableQuantity: available}
+}
+
+// receive returns the next update on ch, failing the test if none comes.
+func receive(t *testing.T, ch <-chan *pb.FlashSaleUpdate) *pb.FlashSaleUpdate {
+	t.Helper()
+	select {
+	case update := <-ch:
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("expected an update, got none")
+		return nil
+	}
+}
+
+// nothingOn fails the test if ch holds an update.
+func nothingOn(t *testing.T, ch <-chan *pb.FlashSaleUpdate) {
+	t.Helper()
+	select {
+	case update := <-ch:
+		t.Errorf("expected no update, got %v", update)
+	default:
+	}
+}
+
+func TestHubFansOut(t *testing.T) {
+	hub := watcher.NewHub()
+	first, unsubscribeFirst := hub.Subscribe("sale-1")
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := hub.Subscribe("sale-1")
+	defer unsubscribeSecond()
+	other, unsubscribeOther := hub.Subscribe("sale-2")
+	defer unsubscribeOther()
+
+	update := stock("sale-1", 5)
+	hub.Publish(update)
+
+	if got := receive(t, first); got != update {
+		t.Errorf("expected the first subscriber to get %v, got %v", update, got)
+	}
+	if got := receive(t, second); got != update {
+		t.Errorf("expected the second subscriber to get %v, got %v", update, got)
+	}
+	// every sale has subscribers of its own
+	nothingOn(t, other)
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub := watcher.NewHub()
+	gone, unsubscribe := hub.Subscribe("sale-1")
+	staying, unsubscribeStaying := hub.Subscribe("sale-1")
+	defer unsubscribeStaying()
+
+	unsubscribe()
+	hub.Publish(stock("sale-1", 5))
+
+	nothingOn(t, gone)
+	if got := receive(t, staying); got.AvailableQuantity != 5 {
+		t.Errorf("expected the other subscriber to still get updates, got %v", got)
+	}
+
+	// the last one out leaves nothing behind, a new subscriber starts afresh
+	unsubscribeStaying()
+	again, unsubscribeAgain := hub.Subscribe("sale-1")
+	defer unsubscribeAgain()
+	hub.Publish(stock("sale-1", 4))
+	if got := receive(t, again); got.AvailableQuantity != 4 {
+		t.Errorf("expected a new subscriber to get updates, got %v", got)
+	}
+}
+
+func TestHubDropsForSlowSubscribers(t *testing.T) {
+	hub := watcher.NewHub()
+	slow, unsubscribeSlow := hub.Subscribe("sale-1")
+	defer unsubscribeSlow()
+
+	fast, unsubscribeFast := hub.Subscribe("sale-1")
+	defer unsubscribeFast()
+
+	// the slow subscriber never reads, the fast one keeps up
+	var sent []*pb.FlashSaleUpdate
+	for i := 0; i <= cap(slow); i++ {
+		update := stock("sale-1", int32(cap(slow)-i))
+		published := make(chan struct{})
+		go func() {
+			defer close(published)
+			hub.Publish(update)
+		}()
+		select {
+		case <-published:
+		case <-time.After(time.Second):
+			t.Fatalf("publishing update %d blocked on the slow subscriber", i+1)
+		}
+		if got := receive(t, fast); got != update {
+			t.Errorf("expected the fast subscriber to get update %d, got %v", i+1, got)
+		}
+		sent = append(sent, update)
+	}
+
+	if len(slow) != cap(slow) {
+		t.Fatalf("expected the slow subscriber to hold %d updates, got %d", cap(slow), len(slow))
+	}
+	// the slow subscriber keeps what fit and misses the rest
+	for i := 0; i < cap(slow); i++ {
+		if got := <-slow; got != sent[i] {
+			t.Errorf("update %d: expected %v, got %v", i+1, sent[i], got)
+		}
+	}
+	nothingOn(t, slow)
+}
+
+// watchStorage serves a snapshot of a sale and tells snapshotTaken once the
+// watcher read it.
+type watchStorage struct {
+	st.StorageI
+	snapshot      []*pb.FlashSaleUpdate
+	snapshotTaken chan struct{}
+}
+
+func (s watchStorage) FlashSale() st.FlashSaleI { return watchFlashSales{s: s} }
+
+type watchFlashSales struct {
+	st.FlashSaleI
+	s watchStorage
+}
+
+func (f watchFlashSales) GetFlashSaleSnapshot(ctx context.Context, req *pb.GetById) ([]*pb.FlashSaleUpdate, error) {
+	defer close(f.s.snapshotTaken)
+	return f.s.snapshot, nil
+}
+
+// watchStream is the server side of a WatchFlashSale stream, handing what is
+// sent to sent.
+type watchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.FlashSaleUpdate
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(update *pb.FlashSaleUpdate) error {
+	s.sent <- update
+	return nil
+}
+
+// watch starts watching sale-1 and returns the stream and the outcome of the
+// call once it ends.
+func watch(t *testing.T, ctx context.Context, hub *watcher.Hub) (*watchStream, <-chan error) {
+	storage := watchStorage{
+		snapshot:      []*pb.FlashSaleUpdate{{Type: "status", FlashSaleId: "sale-1", Status: "active"}, stock("sale-1", 10)},
+		snapshotTaken: make(chan struct{}),
+	}
+	stream := &watchStream{ctx: ctx, sent: make(chan *pb.FlashSaleUpdate, 16)}
+	svc := service.NewFlashSaleService(storage, nil, hub)
+
+	done := make(chan error, 1)
+	go func() { done <- svc.WatchFlashSale(&pb.GetById{Id: "sale-1"}, stream) }()
+
+	select {
+	case <-storage.snapshotTaken:
+	case <-time.After(time.Second):
+		t.Fatal("expected the snapshot to be read")
+	}
+	for range storage.snapshot {
+		receive(t, stream.sent)
+	}
+	return stream, done
+}
+
+func TestWatchFlashSaleEndsOnDisconnect(t *testing.T) {
+	hub := watcher.NewHub()
+	ctx, disconnect := context.WithCancel(context.Background())
+	defer disconnect()
+	stream, done := watch(t, ctx, hub)
+
+	hub.Publish(stock("sale-1", 9))
+	if got := receive(t, stream.sent); got.AvailableQuantity != 9 {
+		t.Errorf("expected the stock update to be sent, got %v", got)
+	}
+
+	disconnect()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the stream to end cleanly, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream to end once the client disconnected")
+	}
+}
+
+func TestWatchFlashSaleEndsWithTheSale(t *testing.T) {
+	hub := watcher.NewHub()
+	stream, done := watch(t, context.Background(), hub)
+
+	hub.Publish(&pb.FlashSaleUpdate{Type: "status", FlashSaleId: "sale-1", Status: "completed"})
+	if got := receive(t, stream.sent); got.Status != "completed" {
+		t.Errorf("expected the completion to be sent, got %v", got)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected the stream to end cleanly, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the stream to end with the sale")
+	}
+}
@@ -0,0 +1,104 @@
+// Package watcher fans flash sale updates published by Postgres triggers out to
+// WatchFlashSale streams.
+package watcher
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	pb "github.com/Mubinabd/flash_sale/internal/pkg/genproto"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Channel is the Postgres notification channel the flash sale triggers write to.
+const Channel = "flash_sale_updates"
+
+// subscriberBuffer is how many updates a slow subscriber may fall behind before
+// updates to it are dropped.
+const subscriberBuffer = 64
+
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan *pb.FlashSaleUpdate]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subs: make(map[string]map[chan *pb.FlashSaleUpdate]struct{}),
+	}
+}
+
+// Subscribe returns a channel with the updates of one flash sale and a function
+// that ends the subscription.
+func (h *Hub) Subscribe(flashSaleID string) (<-chan *pb.FlashSaleUpdate, func()) {
+	ch := make(chan *pb.FlashSaleUpdate, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[flashSaleID] == nil {
+		h.subs[flashSaleID] = make(map[chan *pb.FlashSaleUpdate]struct{})
+	}
+	h.subs[flashSaleID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs[flashSaleID], ch)
+		if len(h.subs[flashSaleID]) == 0 {
+			delete(h.subs, flashSaleID)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Publish hands update to every subscriber of its flash sale. A subscriber whose
+// buffer is full misses it; stock updates carry absolute quantities, so the next
+// one brings it back in line.
+func (h *Hub) Publish(update *pb.FlashSaleUpdate) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[update.FlashSaleId] {
+		select {
+		case ch <- update:
+		default:
+			log.Printf("Dropped %s update of flash sale %s for a slow subscriber", update.Type, update.FlashSaleId)
+		}
+	}
+}
+
+// Listen publishes the notifications on Channel until ctx is canceled.
+func (h *Hub) Listen(ctx context.Context, connString string) error {
+	listener := pq.NewListener(connString, 10*time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("Flash sale update listener:", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// nil after a reconnect, updates sent while disconnected are lost
+			if n == nil {
+				continue
+			}
+			var update pb.FlashSaleUpdate
+			if err := protojson.Unmarshal([]byte(n.Extra), &update); err != nil {
+				log.Println("Error while decoding flash sale update:", err)
+				continue
+			}
+			h.Publish(&update)
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,2 @@
+drop index if exists order_items_order_id_idx;
+drop table if exists order_items;
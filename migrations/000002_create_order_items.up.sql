@@ -0,0 +1,12 @@
+-- ORDER ITEMS TABLE
+CREATE TABLE IF NOT EXISTS order_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id),
+    flash_sale_product_id UUID NOT NULL REFERENCES flash_sales_products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    discounted_price DECIMAL(10, 2) NOT NULL,
+    original_price DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON order_items (order_id);
@@ -0,0 +1,2 @@
+drop index if exists transactions_user_id_idx;
+drop table if exists transactions;
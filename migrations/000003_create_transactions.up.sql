@@ -0,0 +1,13 @@
+-- TRANSACTIONS TABLE
+CREATE TABLE IF NOT EXISTS transactions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    product_id UUID REFERENCES products(id),
+    flash_sale_product_id UUID REFERENCES flash_sales_products(id),
+    amount DECIMAL(10, 2) NOT NULL CHECK (amount > 0),
+    type transaction_type NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    deleted_at BIGINT DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS transactions_user_id_idx ON transactions (user_id);
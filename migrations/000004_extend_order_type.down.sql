@@ -0,0 +1,3 @@
+drop index if exists order_status_tracking_order_id_idx;
+
+-- postgres cannot drop values from an enum, so 'shipped' and 'delivered' stay in order_type
@@ -0,0 +1,5 @@
+-- ORDER TYPE: shipping statuses for the order state machine
+ALTER TYPE order_type ADD VALUE IF NOT EXISTS 'shipped' AFTER 'confirmed';
+ALTER TYPE order_type ADD VALUE IF NOT EXISTS 'delivered' AFTER 'shipped';
+
+CREATE INDEX IF NOT EXISTS order_status_tracking_order_id_idx ON order_status_tracking (order_id);
@@ -0,0 +1,8 @@
+-- IDEMPOTENCY KEYS TABLE
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    operation VARCHAR(50) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (operation, key)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE flash_sales_products DROP COLUMN IF EXISTS per_user_limit;
+ALTER TABLE flash_sales DROP COLUMN IF EXISTS per_user_limit;
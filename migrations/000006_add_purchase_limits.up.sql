@@ -0,0 +1,3 @@
+-- PER USER PURCHASE LIMITS, 0 MEANS NO LIMIT
+ALTER TABLE flash_sales ADD COLUMN IF NOT EXISTS per_user_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE flash_sales_products ADD COLUMN IF NOT EXISTS per_user_limit INTEGER NOT NULL DEFAULT 0;
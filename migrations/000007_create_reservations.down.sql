@@ -0,0 +1,3 @@
+drop index if exists reservations_held_expires_at_idx;
+drop table if exists reservations;
+drop type if exists reservation_status;
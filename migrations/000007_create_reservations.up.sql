@@ -0,0 +1,16 @@
+-- RESERVATION STATUS TYPE
+CREATE TYPE reservation_status AS ENUM ('held', 'confirmed', 'released', 'expired');
+
+-- RESERVATIONS TABLE
+CREATE TABLE IF NOT EXISTS reservations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    status reservation_status NOT NULL DEFAULT 'held',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP
+);
+
+-- the sweeper only ever looks at holds that are still open
+CREATE INDEX IF NOT EXISTS reservations_held_expires_at_idx ON reservations (expires_at) WHERE status = 'held';
@@ -0,0 +1,4 @@
+drop trigger if exists flash_sales_products_notify_stock on flash_sales_products;
+drop trigger if exists flash_sales_notify_status on flash_sales;
+drop function if exists notify_flash_sale_stock();
+drop function if exists notify_flash_sale_status();
@@ -0,0 +1,42 @@
+-- FLASH SALE UPDATES: pushed to WatchFlashSale subscribers through LISTEN flash_sale_updates
+CREATE OR REPLACE FUNCTION notify_flash_sale_status() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT'
+        OR NEW.status IS DISTINCT FROM OLD.status
+        OR NEW.start_time IS DISTINCT FROM OLD.start_time
+        OR NEW.end_time IS DISTINCT FROM OLD.end_time THEN
+        PERFORM pg_notify('flash_sale_updates', json_build_object(
+            'flash_sale_id', NEW.id,
+            'type', 'status',
+            'status', NEW.status,
+            'start_time', to_char(NEW.start_time, 'YYYY-MM-DD"T"HH24:MI:SS'),
+            'end_time', to_char(NEW.end_time, 'YYYY-MM-DD"T"HH24:MI:SS'),
+            'changed_at', to_char(NOW(), 'YYYY-MM-DD"T"HH24:MI:SS')
+        )::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_flash_sale_stock() RETURNS trigger AS $$
+BEGIN
+    IF NEW.available_quantity IS DISTINCT FROM OLD.available_quantity THEN
+        PERFORM pg_notify('flash_sale_updates', json_build_object(
+            'flash_sale_id', NEW.flash_sale_id,
+            'type', 'stock',
+            'flash_sale_product_id', NEW.id,
+            'available_quantity', COALESCE(NEW.available_quantity, 0),
+            'changed_at', to_char(NOW(), 'YYYY-MM-DD"T"HH24:MI:SS')
+        )::text);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER flash_sales_notify_status
+    AFTER INSERT OR UPDATE ON flash_sales
+    FOR EACH ROW EXECUTE FUNCTION notify_flash_sale_status();
+
+CREATE TRIGGER flash_sales_products_notify_stock
+    AFTER UPDATE OF available_quantity ON flash_sales_products
+    FOR EACH ROW EXECUTE FUNCTION notify_flash_sale_stock();
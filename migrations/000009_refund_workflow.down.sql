@@ -0,0 +1,7 @@
+drop index if exists refunds_order_id_idx;
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_status_check;
+ALTER TABLE refunds DROP COLUMN IF EXISTS transaction_id;
+ALTER TABLE refunds DROP COLUMN IF EXISTS reason;
+
+drop index if exists transactions_order_id_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS order_id;
@@ -0,0 +1,13 @@
+-- TRANSACTIONS: payments and refunds of an order point at it
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS order_id UUID REFERENCES orders(id);
+CREATE INDEX IF NOT EXISTS transactions_order_id_idx ON transactions (order_id);
+
+-- REFUNDS: review and ledger bookkeeping
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS reason VARCHAR;
+ALTER TABLE refunds ADD COLUMN IF NOT EXISTS transaction_id UUID REFERENCES transactions(id);
+ALTER TABLE refunds DROP CONSTRAINT IF EXISTS refunds_status_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check CHECK (refund_status IN ('pending', 'approved', 'processed', 'rejected'));
+CREATE INDEX IF NOT EXISTS refunds_order_id_idx ON refunds (order_id);
+
+-- refunds of unpaid orders were recorded with amount 0 and can never be processed
+UPDATE refunds SET refund_status = 'rejected', reason = 'nothing was paid', updated_at = NOW() WHERE refund_amount = 0 AND refund_status = 'pending';
@@ -0,0 +1,15 @@
+-- PAYMENTS TABLE: what the payment provider charged for an order
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id),
+    transaction_id UUID REFERENCES transactions(id),
+    provider VARCHAR NOT NULL,
+    provider_payment_id VARCHAR NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('authorized', 'captured', 'refunded', 'declined')),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP,
+    UNIQUE (provider, provider_payment_id)
+);
+
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id);
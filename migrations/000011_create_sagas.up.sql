@@ -0,0 +1,15 @@
+-- SAGAS TABLE: progress of multi-step workflows, so a restart can finish or undo them
+CREATE TABLE IF NOT EXISTS sagas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind VARCHAR NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('running', 'compensating', 'completed', 'compensated', 'failed')),
+    step INTEGER NOT NULL DEFAULT 0,
+    data JSONB NOT NULL DEFAULT '{}',
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the recovery worker only ever looks for unfinished sagas
+CREATE INDEX IF NOT EXISTS sagas_unfinished_idx ON sagas (updated_at) WHERE status IN ('running', 'compensating');
@@ -0,0 +1,15 @@
+-- OUTBOX TABLE: domain events written with the change they describe, relayed to kafka
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    topic VARCHAR NOT NULL,
+    aggregate_id VARCHAR NOT NULL,
+    payload BYTEA NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    available_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    sent_at TIMESTAMP
+);
+
+-- the relay only ever looks for events that were not sent yet
+CREATE INDEX IF NOT EXISTS outbox_unsent_idx ON outbox (available_at) WHERE sent_at IS NULL;
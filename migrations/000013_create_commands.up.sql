@@ -0,0 +1,10 @@
+-- COMMANDS TABLE: outcome of writes the gateway hands to flash_service over kafka
+CREATE TABLE IF NOT EXISTS commands (
+    id VARCHAR PRIMARY KEY,
+    topic VARCHAR NOT NULL,
+    status VARCHAR NOT NULL CHECK (status IN ('retrying', 'succeeded', 'failed')),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- PROCESSED_MESSAGES TABLE: kafka messages whose write already committed, so a
+-- redelivery is skipped. Rows older than the topics' retention can go.
+CREATE TABLE IF NOT EXISTS processed_messages (
+    message_id VARCHAR PRIMARY KEY,
+    processed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tokens_family_id_idx;
+DROP INDEX IF EXISTS tokens_token_idx;
+
+ALTER TABLE tokens
+    DROP COLUMN IF EXISTS rotated_at,
+    DROP COLUMN IF EXISTS family_id;
@@ -0,0 +1,27 @@
+-- TOKENS: refresh tokens are rotated on every refresh. The tokens issued
+-- from one login share a family_id, so that reuse of a rotated token can
+-- revoke all of them. Signed tokens outgrow VARCHAR(255).
+ALTER TABLE tokens
+    ALTER COLUMN token TYPE TEXT,
+    ADD COLUMN IF NOT EXISTS family_id UUID NOT NULL DEFAULT gen_random_uuid(),
+    ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP;
+
+-- tokens issued twice in one second were stored twice before they had a jti.
+-- Keep one row of each, a revoked one if there is any, so that the token can
+-- be made unique.
+DELETE FROM tokens
+WHERE id IN (
+    SELECT id FROM (
+        SELECT
+            id,
+            ROW_NUMBER() OVER (
+                PARTITION BY token
+                ORDER BY COALESCE(deleted_at, 0) DESC, created_at DESC NULLS LAST, id
+            ) AS copy
+        FROM tokens
+    ) copies
+    WHERE copy > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tokens_token_idx ON tokens (token);
+CREATE INDEX IF NOT EXISTS tokens_family_id_idx ON tokens (family_id);
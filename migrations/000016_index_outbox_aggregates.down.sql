@@ -0,0 +1 @@
+DROP INDEX IF EXISTS outbox_unsent_aggregate_idx;
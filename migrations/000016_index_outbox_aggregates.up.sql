@@ -0,0 +1,2 @@
+-- the relay holds back an event while an earlier one of its aggregate is unsent
+CREATE INDEX IF NOT EXISTS outbox_unsent_aggregate_idx ON outbox (aggregate_id, id) WHERE sent_at IS NULL;
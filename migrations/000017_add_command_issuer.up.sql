@@ -0,0 +1,2 @@
+-- the user a command was sent for; status lookups of other users don't find it
+ALTER TABLE commands ADD COLUMN IF NOT EXISTS user_id VARCHAR;
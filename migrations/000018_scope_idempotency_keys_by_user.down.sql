@@ -0,0 +1,18 @@
+-- keep the oldest use of every key, so that keys are unique across users again
+DELETE FROM idempotency_keys
+WHERE ctid IN (
+    SELECT ctid FROM (
+        SELECT
+            ctid,
+            ROW_NUMBER() OVER (
+                PARTITION BY operation, key
+                ORDER BY created_at NULLS LAST, user_id
+            ) AS copy
+        FROM idempotency_keys
+    ) copies
+    WHERE copy > 1
+);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (operation, key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS user_id;
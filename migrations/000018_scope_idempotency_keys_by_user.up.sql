@@ -0,0 +1,6 @@
+-- IDEMPOTENCY KEYS: keys are chosen by clients, so each user gets keys of
+-- their own. Keys stored before belong to no user.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS user_id VARCHAR NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (operation, user_id, key);